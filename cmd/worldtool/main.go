@@ -0,0 +1,160 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"log"
+	"os"
+	"time"
+)
+
+const usage = `Usage: worldtool <command> [flags]
+
+Commands:
+  pregen  Pre-generate chunks in a radius around a centre chunk.
+  prune   Delete chunks outside a border or chunks that were never modified.
+
+Run 'worldtool <command> -h' for the flags of a command.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	switch os.Args[1] {
+	case "pregen":
+		pregen(os.Args[2:])
+	case "prune":
+		prune(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+}
+
+// pregen runs the pregen command with the arguments passed.
+func pregen(args []string) {
+	set := flag.NewFlagSet("pregen", flag.ExitOnError)
+	dir := set.String("world", "world", "folder of the world to pre-generate chunks in")
+	dimName := set.String("dim", "overworld", "dimension to generate chunks in (overworld, nether or end)")
+	x := set.Int("x", 0, "x coordinate of the centre chunk")
+	z := set.Int("z", 0, "z coordinate of the centre chunk")
+	radius := set.Int("radius", 32, "radius in chunks to generate around the centre chunk")
+	workers := set.Int("workers", 0, "amount of chunks generated concurrently (default: number of CPUs)")
+	_ = set.Parse(args)
+
+	dim := parseDimension(*dimName)
+	db := openDB(*dir)
+	defer closeDB(db)
+
+	start := time.Now()
+	report := progressReporter()
+	err := world.PregenConfig{
+		Provider:  db,
+		Generator: server.DefaultGenerator(dim),
+		Dim:       dim,
+		Centre:    world.ChunkPos{int32(*x), int32(*z)},
+		Radius:    *radius,
+		Workers:   *workers,
+		Progress: func(done, total int) {
+			report("generated %v/%v chunks (%.1f%%)", done, total, float64(done)/float64(total)*100)
+		},
+	}.Pregenerate()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Pre-generation finished in %v.\n", time.Since(start).Round(time.Millisecond))
+}
+
+// prune runs the prune command with the arguments passed.
+func prune(args []string) {
+	set := flag.NewFlagSet("prune", flag.ExitOnError)
+	dir := set.String("world", "world", "folder of the world to prune chunks from")
+	dimName := set.String("dim", "", "dimension to prune chunks from (overworld, nether or end, default: all)")
+	radius := set.Int("radius", -1, "radius in chunks around the centre chunk of the border (default: no border)")
+	x := set.Int("x", 0, "x coordinate of the centre chunk of the border")
+	z := set.Int("z", 0, "z coordinate of the centre chunk of the border")
+	unmodified := set.Bool("unmodified", false, "delete chunks that are equal to newly generated chunks")
+	_ = set.Parse(args)
+
+	conf := mcdb.PruneConfig{Range: &mcdb.IteratorRange{}}
+	if *dimName != "" {
+		conf.Range.Dimension = parseDimension(*dimName)
+	}
+	if *radius >= 0 {
+		conf.Border = &mcdb.IteratorRange{
+			Min: world.ChunkPos{int32(*x - *radius), int32(*z - *radius)},
+			Max: world.ChunkPos{int32(*x + *radius + 1), int32(*z + *radius + 1)},
+		}
+	}
+	if *unmodified {
+		conf.Generator = server.DefaultGenerator
+	}
+	if conf.Border == nil && conf.Generator == nil {
+		log.Fatalln("Nothing to prune: pass -radius and/or -unmodified.")
+	}
+	report := progressReporter()
+	conf.Progress = func(p mcdb.PruneProgress) {
+		report("checked %v chunks, deleted %v", p.Checked, p.Deleted)
+	}
+
+	db := openDB(*dir)
+	defer closeDB(db)
+
+	start := time.Now()
+	p, err := db.Prune(conf)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	log.Printf("Pruning finished in %v: checked %v chunks, deleted %v.\n", time.Since(start).Round(time.Millisecond), p.Checked, p.Deleted)
+}
+
+// progressReporter returns a function that logs progress at most once every
+// second.
+func progressReporter() func(format string, a ...any) {
+	var last time.Time
+	return func(format string, a ...any) {
+		if time.Since(last) < time.Second {
+			return
+		}
+		last = time.Now()
+		log.Printf(format+"\n", a...)
+	}
+}
+
+// parseDimension parses a world.Dimension from its name.
+func parseDimension(name string) world.Dimension {
+	switch name {
+	case "overworld":
+		return world.Overworld
+	case "nether":
+		return world.Nether
+	case "end":
+		return world.End
+	}
+	log.Fatalf("Unknown dimension %q.\n", name)
+	return nil
+}
+
+// openDB opens the mcdb.DB in the directory passed.
+func openDB(dir string) *mcdb.DB {
+	if _, err := os.Stat(dir); err != nil {
+		log.Fatalln(err)
+	}
+	db, err := mcdb.Open(dir)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	return db
+}
+
+// closeDB closes the mcdb.DB passed.
+func closeDB(db *mcdb.DB) {
+	if err := db.Close(); err != nil {
+		log.Fatalln(err)
+	}
+}
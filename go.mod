@@ -10,8 +10,10 @@ require (
 	github.com/df-mc/worldupgrader v1.0.6
 	github.com/go-gl/mathgl v1.0.0
 	github.com/google/uuid v1.3.0
+	github.com/mattn/go-sqlite3 v1.14.19
 	github.com/pelletier/go-toml v1.9.5
 	github.com/rogpeppe/go-internal v1.9.0
+	github.com/sandertv/go-raknet v1.12.0
 	github.com/sandertv/gophertunnel v1.29.0
 	github.com/sirupsen/logrus v1.9.0
 	go.uber.org/atomic v1.10.0
@@ -24,7 +26,6 @@ require (
 	github.com/golang/snappy v0.0.4 // indirect
 	github.com/klauspost/compress v1.15.15 // indirect
 	github.com/muhammadmuzzammil1998/jsonc v1.0.0 // indirect
-	github.com/sandertv/go-raknet v1.12.0 // indirect
 	golang.org/x/crypto v0.5.0 // indirect
 	golang.org/x/image v0.5.0 // indirect
 	golang.org/x/net v0.7.0 // indirect
@@ -0,0 +1,356 @@
+package server
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AccessCommands returns commands that manage the bans and whitelist of an
+// AccessList while the Server is running. Players that are banned, or that are
+// not whitelisted when the whitelist is enabled, are disconnected
+// immediately. The commands are not registered by default and may be
+// registered using cmd.Register.
+//
+// The commands returned are:
+//   - /ban <player> [reason]: Bans a player by name and, if online, by XUID.
+//   - /tempban <player> <duration> [reason]: Bans a player for a duration,
+//     such as 30m, 12h or 7d.
+//   - /banip <ip|player> [reason]: Bans an IP address or the IP address of an
+//     online player.
+//   - /unban <player> and /unbanip <ip>: Remove bans.
+//   - /banlist [players|ips]: Lists the bans of the AccessList.
+//   - /whitelist <on|off|list|add|remove> [player]: Manages the whitelist.
+//
+// allow is called to check if a cmd.Source may execute the commands. If nil,
+// only sources other than players, such as the console and RCON clients, may
+// execute them.
+func AccessCommands(srv *Server, l *AccessList, allow func(src cmd.Source) bool) []cmd.Command {
+	a := accessCommand{srv: srv, l: l, allow: allow}
+	return []cmd.Command{
+		cmd.New("ban", "Bans a player from the server.", nil, banCommand{accessCommand: a}),
+		cmd.New("tempban", "Bans a player from the server for a duration.", nil, tempBanCommand{accessCommand: a}),
+		cmd.New("banip", "Bans an IP address from the server.", nil, banIPCommand{accessCommand: a}),
+		cmd.New("unban", "Removes the ban of a player.", nil, unbanCommand{accessCommand: a}),
+		cmd.New("unbanip", "Removes the ban of an IP address.", nil, unbanIPCommand{accessCommand: a}),
+		cmd.New("banlist", "Lists the players and IP addresses banned from the server.", nil, banListCommand{accessCommand: a}),
+		cmd.New("whitelist", "Manages the whitelist of the server.", nil,
+			whitelistToggle{accessCommand: a},
+			whitelistList{accessCommand: a},
+			whitelistAdd{accessCommand: a},
+			whitelistRemove{accessCommand: a},
+		),
+	}
+}
+
+// accessCommand holds the state shared by the Runnables of the commands
+// returned by AccessCommands. It implements cmd.Allower.
+type accessCommand struct {
+	srv   *Server
+	l     *AccessList
+	allow func(src cmd.Source) bool
+}
+
+// Allow ...
+func (a accessCommand) Allow(src cmd.Source) bool {
+	if a.allow == nil {
+		return nonPlayerSource(src)
+	}
+	return a.allow(src)
+}
+
+// ban adds the Ban passed to the AccessList, disconnects players that are now
+// banned and prints the result to the cmd.Output.
+func (a accessCommand) ban(src cmd.Source, o *cmd.Output, bans ...Ban) {
+	for _, b := range bans {
+		b.Source = sourceName(src)
+		if err := a.l.Ban(b); err != nil {
+			o.Errorf("Could not ban %v: %v", b.Target, err)
+			return
+		}
+	}
+	a.l.Enforce(a.srv.Players()...)
+
+	b, msg := bans[0], fmt.Sprintf("Banned %v", bans[0].Target)
+	if !b.Permanent() {
+		msg += " until " + b.Expires.Format("2006-01-02 15:04:05 MST")
+	}
+	if b.Reason != "" {
+		msg += ": " + b.Reason
+	}
+	o.Print(msg)
+}
+
+// playerBans returns the bans of the player with the name passed: A ban of the
+// name and, if the player is online, a ban of its XUID.
+func (a accessCommand) playerBans(name string, reason cmd.Optional[cmd.Varargs], expires time.Time) []Ban {
+	r, _ := reason.Load()
+	bans := []Ban{{Type: BanName, Target: name, Reason: string(r), Expires: expires}}
+	if p, ok := a.srv.PlayerByName(name); ok && p.XUID() != "" {
+		bans = append(bans, Ban{Type: BanXUID, Target: p.XUID(), Name: p.Name(), Reason: string(r), Expires: expires})
+	}
+	return bans
+}
+
+// banCommand implements the /ban command.
+type banCommand struct {
+	accessCommand
+	Player string                    `cmd:"player"`
+	Reason cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Run ...
+func (c banCommand) Run(src cmd.Source, o *cmd.Output) {
+	c.ban(src, o, c.playerBans(c.Player, c.Reason, time.Time{})...)
+}
+
+// tempBanCommand implements the /tempban command.
+type tempBanCommand struct {
+	accessCommand
+	Player   string                    `cmd:"player"`
+	Duration string                    `cmd:"duration"`
+	Reason   cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Run ...
+func (c tempBanCommand) Run(src cmd.Source, o *cmd.Output) {
+	d, err := parseBanDuration(c.Duration)
+	if err != nil {
+		o.Errorf("Invalid duration %v: Use a duration such as 30m, 12h or 7d.", c.Duration)
+		return
+	}
+	c.ban(src, o, c.playerBans(c.Player, c.Reason, time.Now().Add(d))...)
+}
+
+// banIPCommand implements the /banip command.
+type banIPCommand struct {
+	accessCommand
+	Target string                    `cmd:"target"`
+	Reason cmd.Optional[cmd.Varargs] `cmd:"reason"`
+}
+
+// Run ...
+func (c banIPCommand) Run(src cmd.Source, o *cmd.Output) {
+	r, _ := c.Reason.Load()
+	b := Ban{Type: BanIP, Target: c.Target, Reason: string(r)}
+	if net.ParseIP(c.Target) == nil {
+		p, ok := c.srv.PlayerByName(c.Target)
+		if !ok {
+			o.Errorf("%v is not a valid IP address or the name of an online player.", c.Target)
+			return
+		}
+		b.Target, b.Name = addrIP(p.Addr()), p.Name()
+	}
+	c.ban(src, o, b)
+}
+
+// unbanCommand implements the /unban command.
+type unbanCommand struct {
+	accessCommand
+	Player string `cmd:"player"`
+}
+
+// Run ...
+func (c unbanCommand) Run(_ cmd.Source, o *cmd.Output) {
+	ok, err := c.l.Unban(BanName, c.Player)
+	// Also remove the XUID bans of the player, which were added if the player
+	// was online when it was banned.
+	for _, b := range c.l.Bans() {
+		if b.Type == BanXUID && strings.EqualFold(b.Name, c.Player) && err == nil {
+			_, err = c.l.Unban(BanXUID, b.Target)
+			ok = true
+		}
+	}
+	if err != nil {
+		o.Errorf("Could not unban %v: %v", c.Player, err)
+		return
+	} else if !ok {
+		o.Errorf("%v is not banned.", c.Player)
+		return
+	}
+	o.Printf("Unbanned %v.", c.Player)
+}
+
+// unbanIPCommand implements the /unbanip command.
+type unbanIPCommand struct {
+	accessCommand
+	IP string `cmd:"ip"`
+}
+
+// Run ...
+func (c unbanIPCommand) Run(_ cmd.Source, o *cmd.Output) {
+	ok, err := c.l.Unban(BanIP, c.IP)
+	if err != nil {
+		o.Errorf("Could not unban %v: %v", c.IP, err)
+		return
+	} else if !ok {
+		o.Errorf("%v is not banned.", c.IP)
+		return
+	}
+	o.Printf("Unbanned %v.", c.IP)
+}
+
+// banListCommand implements the /banlist command.
+type banListCommand struct {
+	accessCommand
+	Type cmd.Optional[banListType] `cmd:"type"`
+}
+
+// Run ...
+func (c banListCommand) Run(_ cmd.Source, o *cmd.Output) {
+	typ := c.Type.LoadOr("players")
+	var bans []Ban
+	for _, b := range c.l.Bans() {
+		if (b.Type == BanIP) == (typ == "ips") {
+			bans = append(bans, b)
+		}
+	}
+	o.Printf("There are %v banned %v:", len(bans), typ)
+	for _, b := range bans {
+		line := "- " + b.Target
+		if b.Name != "" {
+			line += " (" + b.Name + ")"
+		}
+		if b.Source != "" {
+			line += " by " + b.Source
+		}
+		if !b.Permanent() {
+			line += " until " + b.Expires.Format("2006-01-02 15:04:05 MST")
+		}
+		if b.Reason != "" {
+			line += ": " + b.Reason
+		}
+		o.Print(line)
+	}
+}
+
+// banListType is the type of bans listed by the /banlist command.
+type banListType string
+
+// Type ...
+func (banListType) Type() string {
+	return "BanListType"
+}
+
+// Options ...
+func (banListType) Options(cmd.Source) []string {
+	return []string{"players", "ips"}
+}
+
+// whitelistToggle implements the /whitelist <on|off> command.
+type whitelistToggle struct {
+	accessCommand
+	State whitelistState `cmd:"state"`
+}
+
+// Run ...
+func (c whitelistToggle) Run(_ cmd.Source, o *cmd.Output) {
+	if err := c.l.SetWhitelistEnabled(c.State == "on"); err != nil {
+		o.Errorf("Could not update the whitelist: %v", err)
+		return
+	}
+	if c.State == "on" {
+		c.l.Enforce(c.srv.Players()...)
+		o.Print("Enabled the whitelist.")
+		return
+	}
+	o.Print("Disabled the whitelist.")
+}
+
+// whitelistState is the state of the whitelist set by the /whitelist command.
+type whitelistState string
+
+// Type ...
+func (whitelistState) Type() string {
+	return "WhitelistState"
+}
+
+// Options ...
+func (whitelistState) Options(cmd.Source) []string {
+	return []string{"on", "off"}
+}
+
+// whitelistList implements the /whitelist list command.
+type whitelistList struct {
+	accessCommand
+	List cmd.SubCommand `cmd:"list"`
+}
+
+// Run ...
+func (c whitelistList) Run(_ cmd.Source, o *cmd.Output) {
+	names, state := c.l.WhitelistedNames(), "disabled"
+	if c.l.WhitelistEnabled() {
+		state = "enabled"
+	}
+	o.Printf("The whitelist is %v and has %v players: %v", state, len(names), strings.Join(names, ", "))
+}
+
+// whitelistAdd implements the /whitelist add command.
+type whitelistAdd struct {
+	accessCommand
+	Add    cmd.SubCommand `cmd:"add"`
+	Player string         `cmd:"player"`
+}
+
+// Run ...
+func (c whitelistAdd) Run(_ cmd.Source, o *cmd.Output) {
+	if err := c.l.Whitelist(c.Player); err != nil {
+		o.Errorf("Could not add %v to the whitelist: %v", c.Player, err)
+		return
+	}
+	o.Printf("Added %v to the whitelist.", c.Player)
+}
+
+// whitelistRemove implements the /whitelist remove command.
+type whitelistRemove struct {
+	accessCommand
+	Remove cmd.SubCommand `cmd:"remove"`
+	Player string         `cmd:"player"`
+}
+
+// Run ...
+func (c whitelistRemove) Run(_ cmd.Source, o *cmd.Output) {
+	ok, err := c.l.Unwhitelist(c.Player)
+	if err != nil {
+		o.Errorf("Could not remove %v from the whitelist: %v", c.Player, err)
+		return
+	} else if !ok {
+		o.Errorf("%v is not on the whitelist.", c.Player)
+		return
+	}
+	c.l.Enforce(c.srv.Players()...)
+	o.Printf("Removed %v from the whitelist.", c.Player)
+}
+
+// parseBanDuration parses a duration of a ban. In addition to the units
+// supported by time.ParseDuration, durations may be specified in days (d) and
+// weeks (w), such as 7d.
+func parseBanDuration(s string) (time.Duration, error) {
+	unit := map[byte]time.Duration{'d': time.Hour * 24, 'w': time.Hour * 24 * 7}
+	if len(s) > 1 {
+		if mul, ok := unit[s[len(s)-1]]; ok {
+			n, err := strconv.ParseFloat(s[:len(s)-1], 64)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			return time.Duration(n * float64(mul)), nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+// sourceName returns the name of a cmd.Source, which is stored as the source
+// of bans.
+func sourceName(src cmd.Source) string {
+	if n, ok := src.(cmd.NamedTarget); ok {
+		return n.Name()
+	}
+	return "Console"
+}
@@ -0,0 +1,398 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BanType is the type of the target of a Ban.
+type BanType int
+
+const (
+	// BanName bans a player by its name. Names are matched case-insensitively.
+	BanName BanType = iota
+	// BanXUID bans a player by its XUID.
+	BanXUID
+	// BanIP bans all players connecting from an IP address.
+	BanIP
+)
+
+// String ...
+func (t BanType) String() string {
+	switch t {
+	case BanName:
+		return "name"
+	case BanXUID:
+		return "xuid"
+	case BanIP:
+		return "ip"
+	}
+	panic("should never happen")
+}
+
+// MarshalText ...
+func (t BanType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText ...
+func (t *BanType) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "name":
+		*t = BanName
+	case "xuid":
+		*t = BanXUID
+	case "ip":
+		*t = BanIP
+	default:
+		return fmt.Errorf("unknown ban type %q", b)
+	}
+	return nil
+}
+
+// Ban is a ban of a player name, XUID or IP address held by an AccessList.
+type Ban struct {
+	// Type is the type of the Target banned.
+	Type BanType `json:"type"`
+	// Target is the name, XUID or IP address banned, depending on the Type.
+	Target string `json:"target"`
+	// Name is the name of the player banned. It is set for XUID and IP bans
+	// of players whose name is known, so that the ban may be identified.
+	Name string `json:"name,omitempty"`
+	// Reason is the reason of the ban, shown to the player when it is
+	// disconnected. It may be empty.
+	Reason string `json:"reason,omitempty"`
+	// Source is the name of the player or console that created the ban. It
+	// may be empty.
+	Source string `json:"source,omitempty"`
+	// Created is the time at which the ban was created. If left zero when
+	// passed to AccessList.Ban, it is set to the current time.
+	Created time.Time `json:"created"`
+	// Expires is the time at which the ban expires. If zero, the ban is
+	// permanent.
+	Expires time.Time `json:"expires"`
+}
+
+// Permanent checks if the Ban never expires.
+func (b Ban) Permanent() bool {
+	return b.Expires.IsZero()
+}
+
+// Expired checks if the Ban has expired.
+func (b Ban) Expired() bool {
+	return !b.Permanent() && time.Now().After(b.Expires)
+}
+
+// Message returns the message shown to players disconnected because of the
+// Ban.
+func (b Ban) Message() string {
+	msg := "You are banned from this server."
+	if b.Reason != "" {
+		msg += "\nReason: " + b.Reason
+	}
+	if !b.Permanent() {
+		msg += "\nExpires: " + b.Expires.Format("2006-01-02 15:04:05 MST")
+	}
+	return msg
+}
+
+// banKey is the key of a Ban in an AccessList.
+type banKey struct {
+	t      BanType
+	target string
+}
+
+// AccessList is an Allower implementation that refuses players that are
+// banned by name, XUID or IP address and, if the whitelist is enabled, players
+// that are not on the whitelist. Changes to an AccessList take effect
+// immediately and are saved to the file it was created with. Players that are
+// already online are not disconnected when they are banned, unless
+// AccessList.Enforce is called.
+//
+// An AccessList may be used by setting it as Config.Allower. The commands
+// returned by AccessCommands may be used to manage it from within the game.
+type AccessList struct {
+	file string
+
+	mu        sync.Mutex
+	enabled   bool
+	whitelist map[string]string
+	bans      map[banKey]Ban
+}
+
+// accessListData is the format in which an AccessList is stored.
+type accessListData struct {
+	WhitelistEnabled bool     `json:"whitelist_enabled"`
+	Whitelist        []string `json:"whitelist"`
+	Bans             []Ban    `json:"bans"`
+}
+
+// NewAccessList creates an AccessList that stores its bans and whitelist in
+// the JSON file at the path passed. If the file exists, the bans and
+// whitelist are loaded from it. If path is empty, the AccessList is not
+// stored.
+func NewAccessList(path string) (*AccessList, error) {
+	l := &AccessList{file: path, whitelist: map[string]string{}, bans: map[banKey]Ban{}}
+	if path == "" {
+		return l, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read access list: %w", err)
+	}
+	var data accessListData
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, fmt.Errorf("decode access list: %w", err)
+	}
+	l.enabled = data.WhitelistEnabled
+	for _, name := range data.Whitelist {
+		l.whitelist[strings.ToLower(name)] = name
+	}
+	for _, ban := range data.Bans {
+		l.bans[banKey{t: ban.Type, target: normaliseTarget(ban.Type, ban.Target)}] = ban
+	}
+	return l, nil
+}
+
+// Allow refuses connections of players that are banned or, if the whitelist
+// is enabled, not whitelisted.
+func (l *AccessList) Allow(addr net.Addr, d login.IdentityData, _ login.ClientData) (string, bool) {
+	return l.allowed(d.DisplayName, d.XUID, addr)
+}
+
+// allowed checks if a player with the name, XUID and address passed is
+// allowed to join. If not, the message to disconnect the player with is
+// returned.
+func (l *AccessList) allowed(name, xuid string, addr net.Addr) (string, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	keys := []banKey{{t: BanName, target: strings.ToLower(name)}}
+	if xuid != "" {
+		keys = append(keys, banKey{t: BanXUID, target: xuid})
+	}
+	if ip := addrIP(addr); ip != "" {
+		keys = append(keys, banKey{t: BanIP, target: ip})
+	}
+	for _, key := range keys {
+		if ban, ok := l.bans[key]; ok && !ban.Expired() {
+			return ban.Message(), false
+		}
+	}
+	if _, ok := l.whitelist[strings.ToLower(name)]; l.enabled && !ok {
+		return "You are not whitelisted on this server.", false
+	}
+	return "", true
+}
+
+// Enforce disconnects all players passed that are no longer allowed to join,
+// for example because they were banned or because the whitelist was enabled.
+// It is typically called with Server.Players after changing the AccessList.
+func (l *AccessList) Enforce(players ...*player.Player) {
+	for _, p := range players {
+		if msg, ok := l.allowed(p.Name(), p.XUID(), p.Addr()); !ok {
+			p.Disconnect(msg)
+		}
+	}
+}
+
+// Ban adds a Ban to the AccessList, replacing any existing ban of the same
+// type and target. An error is returned if the target is not valid for the
+// type of the ban or if the AccessList could not be saved.
+func (l *AccessList) Ban(ban Ban) error {
+	if ban.Type == BanIP && net.ParseIP(ban.Target) == nil {
+		return fmt.Errorf("ban: invalid IP address %q", ban.Target)
+	}
+	if ban.Target == "" {
+		return fmt.Errorf("ban: empty target")
+	}
+	if ban.Created.IsZero() {
+		ban.Created = time.Now()
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.bans[banKey{t: ban.Type, target: normaliseTarget(ban.Type, ban.Target)}] = ban
+	return l.save()
+}
+
+// Unban removes the ban of the type and target passed. False is returned if no
+// such ban existed. An error is returned if the AccessList could not be saved.
+func (l *AccessList) Unban(t BanType, target string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	key := banKey{t: t, target: normaliseTarget(t, target)}
+	if _, ok := l.bans[key]; !ok {
+		return false, nil
+	}
+	delete(l.bans, key)
+	return true, l.save()
+}
+
+// Banned returns the Ban of the type and target passed. False is returned if
+// the target is not banned or if its ban has expired.
+func (l *AccessList) Banned(t BanType, target string) (Ban, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	ban, ok := l.bans[banKey{t: t, target: normaliseTarget(t, target)}]
+	if !ok || ban.Expired() {
+		return Ban{}, false
+	}
+	return ban, true
+}
+
+// Bans returns all bans in the AccessList that have not expired, ordered by
+// the time they were created.
+func (l *AccessList) Bans() []Ban {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	bans := make([]Ban, 0, len(l.bans))
+	for _, ban := range l.bans {
+		if !ban.Expired() {
+			bans = append(bans, ban)
+		}
+	}
+	slices.SortFunc(bans, func(a, b Ban) bool {
+		return a.Created.Before(b.Created)
+	})
+	return bans
+}
+
+// WhitelistEnabled checks if the whitelist of the AccessList is enabled.
+func (l *AccessList) WhitelistEnabled() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.enabled
+}
+
+// SetWhitelistEnabled enables or disables the whitelist. If enabled, only
+// players on the whitelist may join. An error is returned if the AccessList
+// could not be saved.
+func (l *AccessList) SetWhitelistEnabled(enabled bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.enabled = enabled
+	return l.save()
+}
+
+// Whitelist adds a player name to the whitelist. An error is returned if the
+// AccessList could not be saved.
+func (l *AccessList) Whitelist(name string) error {
+	if name == "" {
+		return fmt.Errorf("whitelist: empty name")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.whitelist[strings.ToLower(name)] = name
+	return l.save()
+}
+
+// Unwhitelist removes a player name from the whitelist. False is returned if
+// the name was not on the whitelist. An error is returned if the AccessList
+// could not be saved.
+func (l *AccessList) Unwhitelist(name string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.whitelist[strings.ToLower(name)]; !ok {
+		return false, nil
+	}
+	delete(l.whitelist, strings.ToLower(name))
+	return true, l.save()
+}
+
+// Whitelisted checks if a player name is on the whitelist.
+func (l *AccessList) Whitelisted(name string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, ok := l.whitelist[strings.ToLower(name)]
+	return ok
+}
+
+// WhitelistedNames returns all player names on the whitelist, sorted
+// alphabetically.
+func (l *AccessList) WhitelistedNames() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	names := maps.Values(l.whitelist)
+	slices.Sort(names)
+	return names
+}
+
+// save writes the AccessList to its file, removing bans that have expired. l.mu
+// must be held when save is called.
+func (l *AccessList) save() error {
+	data := accessListData{WhitelistEnabled: l.enabled, Whitelist: maps.Values(l.whitelist), Bans: make([]Ban, 0, len(l.bans))}
+	for key, ban := range l.bans {
+		if ban.Expired() {
+			delete(l.bans, key)
+			continue
+		}
+		data.Bans = append(data.Bans, ban)
+	}
+	if l.file == "" {
+		return nil
+	}
+	slices.Sort(data.Whitelist)
+	slices.SortFunc(data.Bans, func(a, b Ban) bool {
+		return a.Created.Before(b.Created)
+	})
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode access list: %w", err)
+	}
+	// Write to a temporary file first, so that the file is never left
+	// partially written.
+	if err := os.WriteFile(l.file+".tmp", b, 0666); err != nil {
+		return fmt.Errorf("write access list: %w", err)
+	}
+	if err := os.Rename(l.file+".tmp", l.file); err != nil {
+		return fmt.Errorf("write access list: %w", err)
+	}
+	return nil
+}
+
+// normaliseTarget returns the target of a ban in the form it is stored in an
+// AccessList.
+func normaliseTarget(t BanType, target string) string {
+	switch t {
+	case BanName:
+		return strings.ToLower(target)
+	case BanIP:
+		if ip := net.ParseIP(target); ip != nil {
+			return ip.String()
+		}
+	}
+	return target
+}
+
+// addrIP returns the IP address of a net.Addr as a string, or an empty string
+// if the net.Addr has no IP address.
+func addrIP(addr net.Addr) string {
+	switch addr := addr.(type) {
+	case *net.UDPAddr:
+		return addr.IP.String()
+	case *net.TCPAddr:
+		return addr.IP.String()
+	case nil:
+		return ""
+	}
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return ""
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.String()
+	}
+	return ""
+}
@@ -0,0 +1,208 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Bed is a block that allows players to sleep through the night and to set their spawn point. Beds consist of two
+// parts: A foot and a head. Beds explode when used in the Nether or the End.
+type Bed struct {
+	transparent
+	sourceWaterDisplacer
+
+	// Colour is the colour of the bed.
+	Colour item.Colour
+	// Facing is the direction that the bed is facing, from the foot to the head of the bed.
+	Facing cube.Direction
+	// Head is true if the block is the head part of the bed.
+	Head bool
+	// Occupied is true if a world.Sleeper is currently sleeping in the bed. It is set for both parts of the bed.
+	// The world.Sleeper sleeping in the bed keeps track of the position of the bed itself, which may be found
+	// using BedSleeper.
+	Occupied bool
+}
+
+// MaxCount ...
+func (Bed) MaxCount() int {
+	return 1
+}
+
+// Model ...
+func (Bed) Model() world.BlockModel {
+	return model.Bed{}
+}
+
+// SideClosed ...
+func (Bed) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (b Bed) BreakInfo() BreakInfo {
+	return newBreakInfo(0.2, alwaysHarvestable, nothingEffective, oneOf(Bed{Colour: b.Colour})).withBreakHandler(func(pos cube.Pos, w *world.World, _ item.User) {
+		headPos := pos
+		if !b.Head {
+			_, headPos, _ = b.side(pos, w)
+		}
+		if s, ok := BedSleeper(w, headPos); ok {
+			s.Wake()
+		}
+	})
+}
+
+// UseOnBlock ...
+func (b Bed) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) (used bool) {
+	if pos, _, used = firstReplaceable(w, pos, face, b); !used {
+		return false
+	}
+	b.Facing = user.Rotation().Direction()
+
+	head, headPos := Bed{Colour: b.Colour, Facing: b.Facing, Head: true}, pos.Side(b.Facing.Face())
+	if !replaceableWith(w, headPos, head) || !bedSupported(pos, w) || !bedSupported(headPos, w) {
+		return false
+	}
+
+	ctx.IgnoreBBox = true
+	place(w, headPos, head, user, ctx)
+	place(w, pos, b, user, ctx)
+	ctx.CountSub = 1
+	return placed(ctx)
+}
+
+// bedSupported checks if the block below a cube.Pos is able to support a bed.
+func bedSupported(pos cube.Pos, w *world.World) bool {
+	below := pos.Side(cube.FaceDown)
+	return w.Block(below).Model().FaceSolid(below, cube.FaceUp, w)
+}
+
+// Activate makes the world.Sleeper using the bed sleep in it. If the bed is not in the overworld, it explodes
+// instead.
+func (b Bed) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, _ *item.UseContext) bool {
+	s, ok := u.(world.Sleeper)
+	if !ok {
+		return false
+	}
+	headPos, ok := pos, true
+	if !b.Head {
+		_, headPos, ok = b.side(pos, w)
+	}
+	if !ok {
+		return false
+	}
+
+	if w.Dimension() != world.Overworld {
+		if w.RespawnBlocksExplode() {
+			w.SetBlock(pos, nil, nil)
+			if _, sidePos, ok := b.side(pos, w); ok {
+				w.SetBlock(sidePos, nil, nil)
+			}
+			ExplosionConfig{Size: 5, SpawnFire: true}.Explode(w, pos.Vec3Centre())
+		}
+		return true
+	}
+	if !w.CanSleep(headPos) {
+		s.Messaget("tile.bed.noSleep")
+		return true
+	}
+	if _, ok := BedSleeper(w, headPos); ok {
+		s.Messaget("tile.bed.occupied")
+		return true
+	}
+	s.Sleep(headPos)
+	return true
+}
+
+// NeighbourUpdateTick ...
+func (b Bed) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if _, _, ok := b.side(pos, w); !ok {
+		if s, ok := BedSleeper(w, pos); ok && b.Head {
+			s.Wake()
+		}
+		w.SetBlock(pos, nil, nil)
+	}
+}
+
+// BedSleeper returns the world.Sleeper sleeping in the bed with its head part at the cube.Pos passed. False is
+// returned if nobody is sleeping in the bed. BedSleeper may be used after the bed has already been removed.
+func BedSleeper(w *world.World, headPos cube.Pos) (world.Sleeper, bool) {
+	if b, ok := w.Block(headPos).(Bed); ok && !b.Occupied {
+		return nil, false
+	}
+	for _, e := range w.Entities() {
+		if s, ok := e.(world.Sleeper); ok {
+			if pos, sleeping := s.Sleeping(); sleeping && pos == headPos {
+				return s, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// SetBedOccupied sets the Occupied field of both parts of the bed with its head part at the cube.Pos passed.
+// Nothing happens if there is no bed at the position.
+func SetBedOccupied(w *world.World, headPos cube.Pos, occupied bool) {
+	head, ok := w.Block(headPos).(Bed)
+	if !ok || !head.Head {
+		return
+	}
+	if foot, footPos, ok := head.side(headPos, w); ok {
+		foot.Occupied = occupied
+		w.SetBlock(footPos, foot, nil)
+	}
+	head.Occupied = occupied
+	w.SetBlock(headPos, head, nil)
+}
+
+// side returns the other part of the bed and its position. False is returned if the other part of the bed is
+// missing.
+func (b Bed) side(pos cube.Pos, w *world.World) (Bed, cube.Pos, bool) {
+	face := b.Facing.Face()
+	if b.Head {
+		face = face.Opposite()
+	}
+	sidePos := pos.Side(face)
+	side, ok := w.Block(sidePos).(Bed)
+	return side, sidePos, ok && side.Head != b.Head && side.Facing == b.Facing
+}
+
+// EncodeItem ...
+func (b Bed) EncodeItem() (name string, meta int16) {
+	return "minecraft:bed", int16(b.Colour.Uint8())
+}
+
+// EncodeBlock ...
+func (b Bed) EncodeBlock() (name string, properties map[string]any) {
+	return "minecraft:bed", map[string]any{"direction": int32(horizontalDirection(b.Facing)), "occupied_bit": b.Occupied, "head_piece_bit": b.Head}
+}
+
+// EncodeNBT ...
+func (b Bed) EncodeNBT() map[string]any {
+	return map[string]any{
+		"id":    "Bed",
+		"color": b.Colour.Uint8(),
+	}
+}
+
+// DecodeNBT ...
+func (b Bed) DecodeNBT(m map[string]any) any {
+	if c := nbtconv.Uint8(m, "color"); int(c) < len(item.Colours()) {
+		b.Colour = item.Colours()[c]
+	}
+	return b
+}
+
+// allBeds returns all possible bed states.
+func allBeds() (beds []world.Block) {
+	for _, d := range cube.Directions() {
+		for _, occupied := range []bool{false, true} {
+			beds = append(beds, Bed{Facing: d, Occupied: occupied})
+			beds = append(beds, Bed{Facing: d, Head: true, Occupied: occupied})
+		}
+	}
+	return
+}
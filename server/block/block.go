@@ -73,6 +73,12 @@ type EntityInsider interface {
 	EntityInside(pos cube.Pos, w *world.World, e world.Entity)
 }
 
+// Climbable represents a block that entities are able to climb, such as a ladder.
+type Climbable interface {
+	// Climbable returns true if entities are able to climb the block.
+	Climbable() bool
+}
+
 // Frictional represents a block that may have a custom friction value, friction is used for entity drag when the
 // entity is on ground. If a block does not implement this interface, it should be assumed that its friction is 0.6.
 type Frictional interface {
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/loot"
 	"github.com/df-mc/dragonfly/server/world"
 	"math"
 	"math/rand"
@@ -72,6 +73,23 @@ func BreaksInstantly(b world.Block, i item.Stack) bool {
 	return (t.BaseMiningEfficiency(b)+efficiencyVal)*hasteVal >= hardness*30
 }
 
+// Drops returns the items dropped by the block passed when broken using the tool and enchantments passed. If a
+// loot table is registered for the block (see loot.BlockTable), the items are generated from the loot table.
+// Otherwise, the drops of the block's BreakInfo are returned.
+func Drops(b world.Block, t item.Tool, enchantments []item.Enchantment) []item.Stack {
+	if table, ok := loot.BlockTable(b); ok {
+		var tool item.Stack
+		if it, ok := t.(world.Item); ok {
+			tool = item.NewStack(it, 1).WithEnchantments(enchantments...)
+		}
+		return table.Generate(loot.Context{Tool: tool})
+	}
+	if breakable, ok := b.(Breakable); ok {
+		return breakable.BreakInfo().Drops(t, enchantments)
+	}
+	return nil
+}
+
 // BreakInfo is a struct returned by every block. It holds information on block breaking related data, such as
 // the tool type and tier required to break it.
 type BreakInfo struct {
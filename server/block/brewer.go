@@ -0,0 +1,155 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/brewing"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"sync"
+	"time"
+)
+
+const (
+	// brewDuration is the duration it takes for a brewer to brew its potions.
+	brewDuration = time.Second * 20
+	// blazePowderFuel is the amount of brews that a single blaze powder provides fuel for.
+	blazePowderFuel = 20
+)
+
+// brewer is a struct that may be embedded by blocks that can brew potions, such as brewing stands.
+type brewer struct {
+	mu sync.Mutex
+
+	viewers   map[ContainerViewer]struct{}
+	inventory *inventory.Inventory
+
+	duration   time.Duration
+	fuelAmount int32
+	fuelTotal  int32
+	ingredient item.Stack
+}
+
+// newBrewer creates a new initialised brewer. The inventory of the brewer holds the ingredient in the first slot,
+// the three bottles in the next slots and the fuel in the last slot.
+func newBrewer() *brewer {
+	b := &brewer{viewers: make(map[ContainerViewer]struct{})}
+	b.inventory = inventory.New(5, func(slot int, _, item item.Stack) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for viewer := range b.viewers {
+			viewer.ViewSlotChange(slot, item)
+		}
+	})
+	return b
+}
+
+// Duration returns the remaining duration of the current brew of the brewer. If the brewer is not brewing, 0 is
+// returned.
+func (b *brewer) Duration() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.duration
+}
+
+// Fuel returns the amount of brews the brewer has fuel left for, and the total amount of brews the last fuel item
+// provided fuel for.
+func (b *brewer) Fuel() (fuelAmount, fuelTotal int32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.fuelAmount, b.fuelTotal
+}
+
+// Inventory returns the inventory of the brewer.
+func (b *brewer) Inventory() *inventory.Inventory {
+	return b.inventory
+}
+
+// AddViewer adds a viewer to the brewer, so that it is updated whenever the inventory of the brewer is changed.
+func (b *brewer) AddViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the brewer, so that slot updates in the inventory are no longer sent to it.
+func (b *brewer) RemoveViewer(v ContainerViewer, _ *world.World, _ cube.Pos) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.viewers, v)
+}
+
+// setDuration sets the remaining brew duration of the brewer.
+func (b *brewer) setDuration(duration time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.duration = duration
+}
+
+// setFuel sets the fuel amount and fuel total of the brewer.
+func (b *brewer) setFuel(fuelAmount, fuelTotal int32) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.fuelAmount, b.fuelTotal = fuelAmount, fuelTotal
+}
+
+// tickBrewing ticks the brewer, refuelling it if needed and brewing the bottles in it for the necessary duration
+// if a valid ingredient is present.
+func (b *brewer) tickBrewing(pos cube.Pos, w *world.World) {
+	b.mu.Lock()
+
+	// Keep track of our past values, so that we can update the viewers if any of them changed.
+	prevDuration, prevFuelAmount, prevFuelTotal := b.duration, b.fuelAmount, b.fuelTotal
+
+	// We don't need to validate errors here since we know the bounds of the brewer.
+	ingredient, _ := b.inventory.Item(0)
+	fuel, _ := b.inventory.Item(4)
+	bottles := make([]item.Stack, 3)
+	for i := range bottles {
+		bottles[i], _ = b.inventory.Item(i + 1)
+	}
+
+	// The brewer can only brew if at least one of the bottles may be brewed using the ingredient.
+	var brewable bool
+	for _, bottle := range bottles {
+		if _, ok := brewing.Brew(bottle, ingredient); ok {
+			brewable = true
+			break
+		}
+	}
+
+	// Refuel the brewer if it ran out of fuel and has something to brew.
+	if _, ok := fuel.Item().(item.BlazePowder); ok && b.fuelAmount <= 0 && brewable {
+		b.fuelAmount, b.fuelTotal = blazePowderFuel, blazePowderFuel
+		defer b.inventory.SetItem(4, fuel.Grow(-1))
+	}
+
+	if b.duration > 0 {
+		b.duration -= time.Millisecond * 50
+		if !brewable || !ingredient.Comparable(b.ingredient) {
+			// The ingredient was removed or changed, or there are no longer bottles to brew, so the brew is stopped.
+			b.duration = 0
+		} else if b.duration <= 0 {
+			// The brew is finished: Brew all bottles and consume a single ingredient.
+			for i, bottle := range bottles {
+				if result, ok := brewing.Brew(bottle, ingredient); ok {
+					defer b.inventory.SetItem(i+1, result)
+				}
+			}
+			defer b.inventory.SetItem(0, ingredient.Grow(-1))
+			w.PlaySound(pos.Vec3Centre(), sound.PotionBrewed{})
+		}
+	} else if brewable && b.fuelAmount > 0 {
+		// Start a new brew, using one fuel.
+		b.fuelAmount--
+		b.duration, b.ingredient = brewDuration, ingredient
+	}
+
+	// Update the viewers on the new values.
+	for v := range b.viewers {
+		v.ViewBrewingUpdate(prevDuration, b.duration, prevFuelAmount, b.fuelAmount, prevFuelTotal, b.fuelTotal)
+	}
+
+	b.mu.Unlock()
+}
@@ -0,0 +1,446 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"image/color"
+	"math/rand"
+)
+
+const (
+	// cauldronMaxFillLevel is the fill level of a full cauldron.
+	cauldronMaxFillLevel = 6
+	// cauldronBottleFillLevel is the amount of fill levels that a single bottle adds to or takes from a cauldron.
+	cauldronBottleFillLevel = 2
+	// cauldronDripstoneRange is the maximum distance between a cauldron and the dripstone dripping into it.
+	cauldronDripstoneRange = 11
+)
+
+// Cauldron is a block that can hold water, lava, powder snow and potions. It is filled using buckets and bottles,
+// by rain or snow falling into it and by liquids dripping into it from dripstone. The water in a cauldron may be
+// dyed and used to dye or wash leather armour, and to wash patterns off banners.
+type Cauldron struct {
+	transparent
+
+	// Liquid is the liquid held by the cauldron. It is only relevant if FillLevel is above 0.
+	Liquid CauldronLiquid
+	// FillLevel is the level of the liquid in the cauldron, ranging from 0 for an empty cauldron to 6 for a full
+	// one. Buckets fill a cauldron completely, whereas bottles add or take two levels at a time.
+	FillLevel int
+	// Potion is the potion held by the cauldron. It is either nil, an item.Potion, an item.SplashPotion or an
+	// item.LingeringPotion. If nil and the Liquid is CauldronWater, the cauldron holds plain water.
+	Potion world.Item
+	// Colour is the colour of the dyed water held by the cauldron. It is only used if the cauldron holds water and
+	// no potion. The zero value means the water is not dyed.
+	Colour color.RGBA
+}
+
+// Model ...
+func (Cauldron) Model() world.BlockModel {
+	return model.Cauldron{}
+}
+
+// SideClosed ...
+func (Cauldron) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// BreakInfo ...
+func (c Cauldron) BreakInfo() BreakInfo {
+	return newBreakInfo(2, pickaxeHarvestable, pickaxeEffective, oneOf(Cauldron{}))
+}
+
+// Activate ...
+func (c Cauldron) Activate(pos cube.Pos, _ cube.Face, w *world.World, u item.User, ctx *item.UseContext) bool {
+	held, _ := u.HeldItems()
+	switch it := held.Item().(type) {
+	case item.Bucket:
+		return c.useBucket(it, pos, w, ctx)
+	case item.GlassBottle:
+		return c.takeBottle(pos, w, ctx)
+	case item.Potion:
+		return c.addPotion(it, it.Type, pos, w, ctx)
+	case item.SplashPotion:
+		return c.addPotion(it, it.Type, pos, w, ctx)
+	case item.LingeringPotion:
+		return c.addPotion(it, it.Type, pos, w, ctx)
+	case item.Dye:
+		return c.addDye(it.Colour, pos, w, ctx)
+	case Banner:
+		return c.cleanBanner(it, pos, w, ctx)
+	}
+	if colour, ok := leatherArmourColour(held.Item()); ok {
+		return c.dyeArmour(held, colour, pos, w, ctx)
+	}
+	return false
+}
+
+// useBucket fills the cauldron using the bucket passed if it holds water or lava, or fills the bucket using the
+// contents of the cauldron if it is empty and the cauldron is full.
+func (c Cauldron) useBucket(b item.Bucket, pos cube.Pos, w *world.World, ctx *item.UseContext) bool {
+	if b.Empty() {
+		if c.FillLevel != cauldronMaxFillLevel || c.Potion != nil {
+			return false
+		}
+		var liq world.Liquid
+		switch c.Liquid {
+		case CauldronWater():
+			liq = Water{Still: true, Depth: 8}
+			w.PlaySound(pos.Vec3Centre(), sound.CauldronTakeWater{})
+		case CauldronLava():
+			liq = Lava{Still: true, Depth: 8}
+			w.PlaySound(pos.Vec3Centre(), sound.CauldronTakeLava{})
+		default:
+			// Powder snow can't be collected using a bucket.
+			return false
+		}
+		w.SetBlock(pos, Cauldron{}, nil)
+
+		ctx.NewItem = item.NewStack(item.Bucket{Content: item.LiquidBucketContent(liq)}, 1)
+		ctx.NewItemSurvivalOnly = true
+		ctx.SubtractFromCount(1)
+		return true
+	}
+	liq, ok := b.Content.Liquid()
+	if !ok {
+		return false
+	}
+	filled := Cauldron{FillLevel: cauldronMaxFillLevel}
+	switch liq.LiquidType() {
+	case "water":
+		filled.Liquid = CauldronWater()
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronFillWater{})
+	case "lava":
+		filled.Liquid = CauldronLava()
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronFillLava{})
+	default:
+		return false
+	}
+	if c == filled {
+		// The cauldron is already full of this liquid.
+		return false
+	}
+	w.SetBlock(pos, filled, nil)
+
+	ctx.NewItem = item.NewStack(item.Bucket{}, 1)
+	ctx.NewItemSurvivalOnly = true
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// takeBottle fills a glass bottle with the water or potion held by the cauldron.
+func (c Cauldron) takeBottle(pos cube.Pos, w *world.World, ctx *item.UseContext) bool {
+	if c.Liquid != CauldronWater() || c.FillLevel < cauldronBottleFillLevel {
+		return false
+	}
+	var bottle world.Item = item.Potion{Type: potion.Water()}
+	if c.Potion != nil {
+		bottle = c.Potion
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronTakePotion{})
+	} else {
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronTakeWater{})
+	}
+	w.SetBlock(pos, c.withFillLevel(c.FillLevel-cauldronBottleFillLevel), nil)
+
+	ctx.NewItem = item.NewStack(bottle, 1)
+	ctx.NewItemSurvivalOnly = true
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// addPotion adds the potion passed to the cauldron. Adding water bottles fills the cauldron with water, whereas
+// other potions are stored in the cauldron. Mixing different potions, or potions and dyed water, empties the cauldron.
+func (c Cauldron) addPotion(it world.Item, t potion.Potion, pos cube.Pos, w *world.World, ctx *item.UseContext) bool {
+	if c.FillLevel > 0 && (c.Liquid != CauldronWater() || c.FillLevel >= cauldronMaxFillLevel) {
+		return false
+	}
+	water := it == item.Potion{Type: potion.Water()}
+	switch {
+	case c.FillLevel == 0:
+		c = Cauldron{FillLevel: cauldronBottleFillLevel}
+		if !water {
+			c.Potion = it
+		}
+		w.SetBlock(pos, c, nil)
+	case water && c.Potion == nil, !water && c.Potion == it:
+		w.SetBlock(pos, c.withFillLevel(c.FillLevel+cauldronBottleFillLevel), nil)
+	default:
+		c.mix(pos, w)
+	}
+	if water {
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronFillWater{})
+	} else {
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronFillPotion{})
+	}
+
+	ctx.NewItem = item.NewStack(item.GlassBottle{}, 1)
+	ctx.NewItemSurvivalOnly = true
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// addDye dyes the water held by the cauldron using the colour passed. If the water was already dyed, the colours are
+// mixed.
+func (c Cauldron) addDye(colour item.Colour, pos cube.Pos, w *world.World, ctx *item.UseContext) bool {
+	if c.Liquid != CauldronWater() || c.FillLevel == 0 {
+		return false
+	}
+	ctx.SubtractFromCount(1)
+	if c.Potion != nil {
+		c.mix(pos, w)
+		return true
+	}
+	rgba := colour.RGBA()
+	if c.Colour != (color.RGBA{}) {
+		rgba = color.RGBA{
+			R: uint8((int(c.Colour.R) + int(rgba.R)) / 2),
+			G: uint8((int(c.Colour.G) + int(rgba.G)) / 2),
+			B: uint8((int(c.Colour.B) + int(rgba.B)) / 2),
+			A: 0xff,
+		}
+	}
+	c.Colour = rgba
+	w.SetBlock(pos, c, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.CauldronAddDye{})
+	return true
+}
+
+// dyeArmour dyes the leather armour passed using the dyed water in the cauldron, or washes the dye off the armour if
+// the water in the cauldron is not dyed.
+func (c Cauldron) dyeArmour(armour item.Stack, colour color.RGBA, pos cube.Pos, w *world.World, ctx *item.UseContext) bool {
+	if c.Liquid != CauldronWater() || c.FillLevel == 0 || c.Potion != nil || c.Colour == colour {
+		return false
+	}
+	if c.Colour == (color.RGBA{}) {
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronCleanArmour{})
+	} else {
+		w.PlaySound(pos.Vec3Centre(), sound.CauldronDyeArmour{})
+	}
+	w.SetBlock(pos, c.withFillLevel(c.FillLevel-1), nil)
+
+	ctx.NewItem = duplicateStack(armour.Grow(1-armour.Count()), withLeatherArmourColour(armour.Item(), c.Colour))
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// cleanBanner washes the top-most pattern off the banner passed.
+func (c Cauldron) cleanBanner(b Banner, pos cube.Pos, w *world.World, ctx *item.UseContext) bool {
+	if c.Liquid != CauldronWater() || c.FillLevel == 0 || c.Potion != nil || len(b.Patterns) == 0 || b.Illager {
+		return false
+	}
+	b.Patterns = append([]BannerPatternLayer(nil), b.Patterns[:len(b.Patterns)-1]...)
+	w.SetBlock(pos, c.withFillLevel(c.FillLevel-1), nil)
+	w.PlaySound(pos.Vec3Centre(), sound.CauldronCleanBanner{})
+
+	ctx.NewItem = item.NewStack(b, 1)
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// mix empties the cauldron as a result of mixing incompatible contents in it.
+func (c Cauldron) mix(pos cube.Pos, w *world.World) {
+	w.SetBlock(pos, Cauldron{}, nil)
+	w.PlaySound(pos.Vec3Centre(), sound.CauldronExplode{})
+}
+
+// RandomTick ...
+func (c Cauldron) RandomTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	above := pos.Side(cube.FaceUp)
+	if w.RainingAt(above) {
+		c.fill(CauldronWater(), pos, w)
+	} else if w.SnowingAt(above) {
+		c.fill(CauldronPowderSnow(), pos, w)
+	} else {
+		c.drip(pos, w)
+	}
+}
+
+// drip fills the cauldron with the liquid dripping from dripstone above it. Liquid only drips from the dripstone if
+// it has a water or lava source directly above it and if there are only air blocks between the dripstone and the
+// cauldron.
+func (c Cauldron) drip(pos cube.Pos, w *world.World) {
+	for y := 1; y <= cauldronDripstoneRange; y++ {
+		abovePos := pos.Add(cube.Pos{0, y})
+		switch w.Block(abovePos).(type) {
+		case Air:
+			continue
+		case Dripstone:
+			liq, ok := w.Liquid(abovePos.Side(cube.FaceUp))
+			if !ok || liq.LiquidDepth() != 8 || liq.LiquidFalling() {
+				return
+			}
+			switch liq.(type) {
+			case Water:
+				if c.fill(CauldronWater(), pos, w) {
+					w.PlaySound(pos.Vec3Centre(), sound.CauldronDripWater{})
+				}
+			case Lava:
+				if c.fill(CauldronLava(), pos, w) {
+					w.PlaySound(pos.Vec3Centre(), sound.CauldronDripLava{})
+				}
+			}
+		}
+		return
+	}
+}
+
+// fill fills the cauldron by a single level of the liquid passed. If the cauldron holds a different liquid or a
+// potion, or if it is already full, fill returns false.
+func (c Cauldron) fill(liquid CauldronLiquid, pos cube.Pos, w *world.World) bool {
+	if c.FillLevel == 0 {
+		c = Cauldron{Liquid: liquid}
+	} else if c.Liquid != liquid || c.Potion != nil || c.FillLevel >= cauldronMaxFillLevel {
+		return false
+	}
+	w.SetBlock(pos, c.withFillLevel(c.FillLevel+1), nil)
+	return true
+}
+
+// EntityInside ...
+func (c Cauldron) EntityInside(pos cube.Pos, w *world.World, e world.Entity) {
+	if c.FillLevel == 0 {
+		return
+	}
+	switch c.Liquid {
+	case CauldronWater():
+		if flammable, ok := e.(flammableEntity); ok && flammable.OnFireDuration() > 0 && c.Potion == nil {
+			flammable.Extinguish()
+			w.SetBlock(pos, c.withFillLevel(c.FillLevel-1), nil)
+		}
+	case CauldronLava():
+		Lava{Still: true, Depth: 8}.EntityInside(pos, w, e)
+	}
+}
+
+// withFillLevel returns the cauldron with its fill level set to the level passed. If the level is 0 or lower, an
+// empty cauldron is returned.
+func (c Cauldron) withFillLevel(level int) Cauldron {
+	if level <= 0 {
+		return Cauldron{}
+	}
+	c.FillLevel = min(level, cauldronMaxFillLevel)
+	return c
+}
+
+// EncodeItem ...
+func (Cauldron) EncodeItem() (name string, meta int16) {
+	return "minecraft:cauldron", 0
+}
+
+// EncodeBlock ...
+func (c Cauldron) EncodeBlock() (string, map[string]any) {
+	if c.FillLevel == 0 {
+		return "minecraft:cauldron", map[string]any{"cauldron_liquid": "water", "fill_level": int32(0)}
+	}
+	name := "minecraft:cauldron"
+	if c.Liquid == CauldronLava() {
+		name = "minecraft:lava_cauldron"
+	}
+	return name, map[string]any{"cauldron_liquid": c.Liquid.String(), "fill_level": int32(c.FillLevel)}
+}
+
+// EncodeNBT ...
+func (c Cauldron) EncodeNBT() map[string]any {
+	m := map[string]any{"id": "Cauldron", "PotionId": int16(-1), "PotionType": int16(-1)}
+	switch p := c.Potion.(type) {
+	case item.Potion:
+		m["PotionId"], m["PotionType"] = int16(p.Type.Uint8()), int16(0)
+	case item.SplashPotion:
+		m["PotionId"], m["PotionType"] = int16(p.Type.Uint8()), int16(1)
+	case item.LingeringPotion:
+		m["PotionId"], m["PotionType"] = int16(p.Type.Uint8()), int16(2)
+	}
+	if c.Colour != (color.RGBA{}) {
+		m["CustomColor"] = nbtconv.Int32FromRGBA(c.Colour)
+	}
+	return m
+}
+
+// DecodeNBT ...
+func (c Cauldron) DecodeNBT(data map[string]any) any {
+	c.Potion, c.Colour = nil, color.RGBA{}
+	if id := nbtconv.Int16(data, "PotionId"); id > 0 {
+		t := potion.From(int32(id))
+		switch nbtconv.Int16(data, "PotionType") {
+		case 0:
+			c.Potion = item.Potion{Type: t}
+		case 1:
+			c.Potion = item.SplashPotion{Type: t}
+		case 2:
+			c.Potion = item.LingeringPotion{Type: t}
+		}
+	}
+	if _, ok := data["CustomColor"]; ok {
+		c.Colour = nbtconv.RGBAFromInt32(nbtconv.Int32(data, "CustomColor"))
+	}
+	return c
+}
+
+// leatherArmourColour returns the colour of the leather armour item passed. If the item is not leather armour, false
+// is returned.
+func leatherArmourColour(it world.Item) (color.RGBA, bool) {
+	var tier item.ArmourTier
+	switch a := it.(type) {
+	case item.Helmet:
+		tier = a.Tier
+	case item.Chestplate:
+		tier = a.Tier
+	case item.Leggings:
+		tier = a.Tier
+	case item.Boots:
+		tier = a.Tier
+	}
+	t, ok := tier.(item.ArmourTierLeather)
+	return t.Colour, ok
+}
+
+// withLeatherArmourColour returns the leather armour item passed with its colour changed to the colour passed.
+func withLeatherArmourColour(it world.Item, c color.RGBA) world.Item {
+	tier := item.ArmourTierLeather{Colour: c}
+	switch a := it.(type) {
+	case item.Helmet:
+		a.Tier = tier
+		return a
+	case item.Chestplate:
+		a.Tier = tier
+		return a
+	case item.Leggings:
+		a.Tier = tier
+		return a
+	case item.Boots:
+		a.Tier = tier
+		return a
+	}
+	return it
+}
+
+// duplicateStack duplicates an item.Stack with the new item type given, keeping its count, durability, custom
+// name, lore, enchantments and other values.
+func duplicateStack(input item.Stack, newType world.Item) item.Stack {
+	outputStack := item.NewStack(newType, input.Count()).
+		Damage(input.MaxDurability() - input.Durability()).
+		WithCustomName(input.CustomName()).
+		WithLore(input.Lore()...).
+		WithEnchantments(input.Enchantments()...).
+		WithAnvilCost(input.AnvilCost())
+	for k, v := range input.Values() {
+		outputStack = outputStack.WithValue(k, v)
+	}
+	return outputStack
+}
+
+// allCauldrons returns all possible cauldron states.
+func allCauldrons() (cauldrons []world.Block) {
+	cauldrons = append(cauldrons, Cauldron{})
+	for _, liquid := range CauldronLiquids() {
+		for level := 1; level <= cauldronMaxFillLevel; level++ {
+			cauldrons = append(cauldrons, Cauldron{Liquid: liquid, FillLevel: level})
+		}
+	}
+	return
+}
@@ -28,8 +28,8 @@ func (c crop) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
 	if _, ok := w.Block(pos.Side(cube.FaceDown)).(Farmland); !ok {
 		b := w.Block(pos)
 		w.SetBlock(pos, nil, nil)
-		if breakable, ok := b.(Breakable); ok {
-			for _, drop := range breakable.BreakInfo().Drops(item.ToolNone{}, []item.Enchantment{}) {
+		if _, ok := b.(Breakable); ok {
+			for _, drop := range Drops(b, item.ToolNone{}, nil) {
 				dropItem(w, drop, pos.Vec3Centre())
 			}
 		}
@@ -0,0 +1,105 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// DetectorRail is a rail that becomes powered while a minecart is riding over it.
+type DetectorRail struct {
+	transparent
+	empty
+
+	// Shape is the shape of the rail, which determines the directions that it connects to. A DetectorRail cannot
+	// be curved.
+	Shape RailShape
+	// Powered specifies if the rail is currently powered, which is the case while a minecart is on it.
+	Powered bool
+}
+
+// Detect powers the DetectorRail at the position passed, as a result of a minecart riding over it. The rail
+// stays powered for as long as a minecart remains on it.
+func (r DetectorRail) Detect(pos cube.Pos, w *world.World) {
+	if !r.Powered {
+		r.Powered = true
+		w.SetBlock(pos, r, nil)
+	}
+	w.ScheduleBlockUpdate(pos, time.Second)
+}
+
+// ScheduledTick ...
+func (r DetectorRail) ScheduledTick(pos cube.Pos, w *world.World, _ *rand.Rand) {
+	if !r.Powered {
+		return
+	}
+	for _, e := range w.EntitiesWithin(cube.Box(0, 0, 0, 1, 1, 1).Translate(pos.Vec3()), nil) {
+		if strings.HasSuffix(e.Type().EncodeEntity(), "minecart") {
+			w.ScheduleBlockUpdate(pos, time.Second)
+			return
+		}
+	}
+	r.Powered = false
+	w.SetBlock(pos, r, nil)
+}
+
+// RailShape ...
+func (r DetectorRail) RailShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r DetectorRail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (DetectorRail) curvable() bool {
+	return false
+}
+
+// UseOnBlock ...
+func (r DetectorRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	return placeRail(r, pos, face, w, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r DetectorRail) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	breakUnsupportedRail(r, pos, w)
+}
+
+// HasLiquidDrops ...
+func (DetectorRail) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r DetectorRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(DetectorRail{}))
+}
+
+// EncodeItem ...
+func (DetectorRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:detector_rail", 0
+}
+
+// EncodeBlock ...
+func (r DetectorRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:detector_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// allDetectorRails ...
+func allDetectorRails() (rails []world.Block) {
+	for _, s := range RailShapes() {
+		if s.Curved() {
+			continue
+		}
+		rails = append(rails, DetectorRail{Shape: s}, DetectorRail{Shape: s, Powered: true})
+	}
+	return
+}
@@ -132,10 +132,10 @@ func (c ExplosionConfig) Explode(w *world.World, explosionPos mgl64.Vec3) {
 		bl := w.Block(pos)
 		if explodable, ok := bl.(Explodable); ok {
 			explodable.Explode(explosionPos, pos, w, c)
-		} else if breakable, ok := bl.(Breakable); ok {
+		} else if _, ok := bl.(Breakable); ok {
 			w.SetBlock(pos, nil, nil)
 			if !c.DisableItemDrops && 1/c.Size > r.Float64() {
-				for _, drop := range breakable.BreakInfo().Drops(item.ToolNone{}, nil) {
+				for _, drop := range Drops(bl, item.ToolNone{}, nil) {
 					dropItem(w, drop, pos.Vec3Centre())
 				}
 			}
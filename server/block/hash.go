@@ -13,6 +13,7 @@ const (
 	hashBarrier
 	hashBasalt
 	hashBeacon
+	hashBed
 	hashBedrock
 	hashBeetrootSeeds
 	hashBlackstone
@@ -20,12 +21,14 @@ const (
 	hashBlueIce
 	hashBone
 	hashBookshelf
+	hashBrewingStand
 	hashBricks
 	hashCactus
 	hashCake
 	hashCalcite
 	hashCarpet
 	hashCarrot
+	hashCauldron
 	hashChain
 	hashChest
 	hashChiseledQuartz
@@ -45,6 +48,7 @@ const (
 	hashDeepslate
 	hashDeepslateBricks
 	hashDeepslateTiles
+	hashDetectorRail
 	hashDiamond
 	hashDiamondOre
 	hashDiorite
@@ -96,6 +100,7 @@ const (
 	hashLitPumpkin
 	hashLog
 	hashLoom
+	hashMangroveRoots
 	hashMelon
 	hashMelonSeeds
 	hashMossCarpet
@@ -119,6 +124,7 @@ const (
 	hashPodzol
 	hashPolishedBlackstoneBrick
 	hashPotato
+	hashPoweredRail
 	hashPrismarine
 	hashPumpkin
 	hashPumpkinSeeds
@@ -127,12 +133,14 @@ const (
 	hashQuartz
 	hashQuartzBricks
 	hashQuartzPillar
+	hashRail
 	hashRawCopper
 	hashRawGold
 	hashRawIron
 	hashReinforcedDeepslate
 	hashSand
 	hashSandstone
+	hashSapling
 	hashSeaLantern
 	hashSeaPickle
 	hashShroomlight
@@ -210,6 +218,10 @@ func (Beacon) Hash() uint64 {
 	return hashBeacon
 }
 
+func (b Bed) Hash() uint64 {
+	return hashBed | uint64(b.Facing)<<8 | uint64(boolByte(b.Head))<<10 | uint64(boolByte(b.Occupied))<<11
+}
+
 func (b Bedrock) Hash() uint64 {
 	return hashBedrock | uint64(boolByte(b.InfiniteBurning))<<8
 }
@@ -238,6 +250,10 @@ func (Bookshelf) Hash() uint64 {
 	return hashBookshelf
 }
 
+func (b BrewingStand) Hash() uint64 {
+	return hashBrewingStand | uint64(boolByte(b.LeftSlot))<<8 | uint64(boolByte(b.MiddleSlot))<<9 | uint64(boolByte(b.RightSlot))<<10
+}
+
 func (Bricks) Hash() uint64 {
 	return hashBricks
 }
@@ -262,6 +278,10 @@ func (c Carrot) Hash() uint64 {
 	return hashCarrot | uint64(c.Growth)<<8
 }
 
+func (c Cauldron) Hash() uint64 {
+	return hashCauldron | uint64(c.Liquid.Uint8())<<8 | uint64(c.FillLevel)<<10
+}
+
 func (c Chain) Hash() uint64 {
 	return hashChain | uint64(c.Axis)<<8
 }
@@ -338,6 +358,10 @@ func (d DeepslateTiles) Hash() uint64 {
 	return hashDeepslateTiles | uint64(boolByte(d.Cracked))<<8
 }
 
+func (r DetectorRail) Hash() uint64 {
+	return hashDetectorRail | uint64(r.Shape.Uint8())<<8 | uint64(boolByte(r.Powered))<<12
+}
+
 func (Diamond) Hash() uint64 {
 	return hashDiamond
 }
@@ -542,6 +566,10 @@ func (l Loom) Hash() uint64 {
 	return hashLoom | uint64(l.Facing)<<8
 }
 
+func (MangroveRoots) Hash() uint64 {
+	return hashMangroveRoots
+}
+
 func (Melon) Hash() uint64 {
 	return hashMelon
 }
@@ -634,6 +662,10 @@ func (p Potato) Hash() uint64 {
 	return hashPotato | uint64(p.Growth)<<8
 }
 
+func (r PoweredRail) Hash() uint64 {
+	return hashPoweredRail | uint64(r.Shape.Uint8())<<8 | uint64(boolByte(r.Powered))<<12
+}
+
 func (p Prismarine) Hash() uint64 {
 	return hashPrismarine | uint64(p.Type.Uint8())<<8
 }
@@ -666,6 +698,10 @@ func (q QuartzPillar) Hash() uint64 {
 	return hashQuartzPillar | uint64(q.Axis)<<8
 }
 
+func (r Rail) Hash() uint64 {
+	return hashRail | uint64(r.Shape.Uint8())<<8
+}
+
 func (RawCopper) Hash() uint64 {
 	return hashRawCopper
 }
@@ -690,6 +726,10 @@ func (s Sandstone) Hash() uint64 {
 	return hashSandstone | uint64(s.Type.Uint8())<<8 | uint64(boolByte(s.Red))<<10
 }
 
+func (s Sapling) Hash() uint64 {
+	return hashSapling | uint64(s.Wood.Uint8())<<8 | uint64(boolByte(s.Ready))<<12
+}
+
 func (SeaLantern) Hash() uint64 {
 	return hashSeaLantern
 }
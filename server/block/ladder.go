@@ -57,6 +57,11 @@ func (l Ladder) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.
 	return placed(ctx)
 }
 
+// Climbable ...
+func (Ladder) Climbable() bool {
+	return true
+}
+
 // EntityInside ...
 func (l Ladder) EntityInside(_ cube.Pos, _ *world.World, e world.Entity) {
 	if fallEntity, ok := e.(fallDistanceEntity); ok {
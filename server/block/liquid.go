@@ -170,8 +170,8 @@ func flowInto(b world.Liquid, src, pos cube.Pos, w *world.World, falling bool) b
 			w.SetBlock(pos, nil, nil)
 		}
 		if removable.HasLiquidDrops() {
-			if b, ok := existing.(Breakable); ok {
-				for _, d := range b.BreakInfo().Drops(item.ToolNone{}, nil) {
+			if _, ok := existing.(Breakable); ok {
+				for _, d := range Drops(existing, item.ToolNone{}, nil) {
 					dropItem(w, d, pos.Vec3Centre())
 				}
 			} else {
@@ -0,0 +1,39 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// MangroveRoots are the roots grown by mangrove trees. They may be waterlogged and are converted to muddy mangrove
+// roots when grown into mud.
+type MangroveRoots struct {
+	leaves
+	transparent
+	sourceWaterDisplacer
+}
+
+// FlammabilityInfo ...
+func (MangroveRoots) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(5, 20, false)
+}
+
+// BreakInfo ...
+func (m MangroveRoots) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, axeEffective, oneOf(m))
+}
+
+// SideClosed ...
+func (MangroveRoots) SideClosed(cube.Pos, cube.Pos, *world.World) bool {
+	return false
+}
+
+// EncodeItem ...
+func (MangroveRoots) EncodeItem() (name string, meta int16) {
+	return "minecraft:mangrove_roots", 0
+}
+
+// EncodeBlock ...
+func (MangroveRoots) EncodeBlock() (string, map[string]any) {
+	return "minecraft:mangrove_roots", nil
+}
@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Cauldron is a model used by cauldrons. It has a floor and four walls, leaving an opening at the top.
+type Cauldron struct{}
+
+// BBox ...
+func (Cauldron) BBox(cube.Pos, *world.World) []cube.BBox {
+	return []cube.BBox{
+		cube.Box(0, 0, 0, 1, 0.3125, 1),
+		cube.Box(0, 0, 0, 0.125, 1, 1),
+		cube.Box(0.875, 0, 0, 1, 1, 1),
+		cube.Box(0, 0, 0, 1, 1, 0.125),
+		cube.Box(0, 0, 0.875, 1, 1, 1),
+	}
+}
+
+// FaceSolid ...
+func (Cauldron) FaceSolid(_ cube.Pos, face cube.Face, _ *world.World) bool {
+	return face != cube.FaceUp
+}
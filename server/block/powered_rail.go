@@ -0,0 +1,79 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// PoweredRail is a rail that accelerates minecarts riding over it while it is powered. While unpowered, it slows
+// minecarts down until they come to a halt.
+type PoweredRail struct {
+	transparent
+	empty
+
+	// Shape is the shape of the rail, which determines the directions that it connects to. A PoweredRail cannot
+	// be curved.
+	Shape RailShape
+	// Powered specifies if the rail is powered. Powered rails accelerate minecarts, while unpowered rails slow
+	// them down.
+	Powered bool
+}
+
+// RailShape ...
+func (r PoweredRail) RailShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r PoweredRail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (PoweredRail) curvable() bool {
+	return false
+}
+
+// UseOnBlock ...
+func (r PoweredRail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	return placeRail(r, pos, face, w, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r PoweredRail) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	breakUnsupportedRail(r, pos, w)
+}
+
+// HasLiquidDrops ...
+func (PoweredRail) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r PoweredRail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(PoweredRail{}))
+}
+
+// EncodeItem ...
+func (PoweredRail) EncodeItem() (name string, meta int16) {
+	return "minecraft:golden_rail", 0
+}
+
+// EncodeBlock ...
+func (r PoweredRail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:golden_rail", map[string]any{"rail_direction": int32(r.Shape.Uint8()), "rail_data_bit": boolByte(r.Powered)}
+}
+
+// allPoweredRails ...
+func allPoweredRails() (rails []world.Block) {
+	for _, s := range RailShapes() {
+		if s.Curved() {
+			continue
+		}
+		rails = append(rails, PoweredRail{Shape: s}, PoweredRail{Shape: s, Powered: true})
+	}
+	return
+}
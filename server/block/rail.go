@@ -0,0 +1,182 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// RailBlock is a block that minecarts are able to ride on, such as a Rail, PoweredRail or DetectorRail.
+type RailBlock interface {
+	world.Block
+	// RailShape returns the shape of the rail, which determines the directions that minecarts may ride on it.
+	RailShape() RailShape
+}
+
+// shapedRail is a RailBlock of which the shape may be changed to connect to neighbouring rails.
+type shapedRail interface {
+	RailBlock
+	// withRailShape returns the rail with its shape changed to the RailShape passed.
+	withRailShape(s RailShape) world.Block
+	// curvable checks if the rail may be curved.
+	curvable() bool
+}
+
+// Rail is a block that minecarts ride on. Unlike other rails, a Rail may be curved to connect to rails in
+// perpendicular directions.
+type Rail struct {
+	transparent
+	empty
+
+	// Shape is the shape of the rail, which determines the directions that it connects to.
+	Shape RailShape
+}
+
+// RailShape ...
+func (r Rail) RailShape() RailShape {
+	return r.Shape
+}
+
+// withRailShape ...
+func (r Rail) withRailShape(s RailShape) world.Block {
+	r.Shape = s
+	return r
+}
+
+// curvable ...
+func (Rail) curvable() bool {
+	return true
+}
+
+// UseOnBlock ...
+func (r Rail) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	return placeRail(r, pos, face, w, user, ctx)
+}
+
+// NeighbourUpdateTick ...
+func (r Rail) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	breakUnsupportedRail(r, pos, w)
+}
+
+// HasLiquidDrops ...
+func (Rail) HasLiquidDrops() bool {
+	return true
+}
+
+// BreakInfo ...
+func (r Rail) BreakInfo() BreakInfo {
+	return newBreakInfo(0.7, alwaysHarvestable, pickaxeEffective, oneOf(Rail{}))
+}
+
+// EncodeItem ...
+func (Rail) EncodeItem() (name string, meta int16) {
+	return "minecraft:rail", 0
+}
+
+// EncodeBlock ...
+func (r Rail) EncodeBlock() (string, map[string]any) {
+	return "minecraft:rail", map[string]any{"rail_direction": int32(r.Shape.Uint8())}
+}
+
+// allRails ...
+func allRails() (rails []world.Block) {
+	for _, s := range RailShapes() {
+		rails = append(rails, Rail{Shape: s})
+	}
+	return
+}
+
+// placeRail places the rail passed at the position clicked, connecting it to any neighbouring rails.
+func placeRail(r shapedRail, pos cube.Pos, face cube.Face, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, r)
+	if !used || !railSupported(pos, w) {
+		return false
+	}
+	place(w, pos, r.withRailShape(railShapeAt(r, pos, w, user.Rotation().Direction())), user, ctx)
+	if !placed(ctx) {
+		return false
+	}
+	for _, d := range cube.Directions() {
+		for _, y := range []int{0, 1, -1} {
+			npos := pos.Side(d.Face()).Add(cube.Pos{0, y})
+			if n, ok := w.Block(npos).(shapedRail); ok && railConnections(n, npos, w) < 2 {
+				w.SetBlock(npos, n.withRailShape(railShapeAt(n, npos, w, d)), nil)
+			}
+		}
+	}
+	return true
+}
+
+// breakUnsupportedRail breaks the rail at the position passed if the block below it no longer supports it.
+func breakUnsupportedRail(r world.Block, pos cube.Pos, w *world.World) {
+	if !railSupported(pos, w) {
+		w.SetBlock(pos, nil, nil)
+		dropItem(w, item.NewStack(r.(world.Item), 1), pos.Vec3Centre())
+	}
+}
+
+// railSupported checks if the block below the position passed is able to support a rail.
+func railSupported(pos cube.Pos, w *world.World) bool {
+	below := pos.Side(cube.FaceDown)
+	return w.Block(below).Model().FaceSolid(below, cube.FaceUp, w)
+}
+
+// railConnections returns the amount of ends of the rail passed that are connected to another rail.
+func railConnections(r RailBlock, pos cube.Pos, w *world.World) (n int) {
+	asc, ascending := r.RailShape().Ascending()
+	for _, d := range r.RailShape().Connections() {
+		npos := pos.Side(d.Face())
+		if ascending && d == asc {
+			npos = npos.Side(cube.FaceUp)
+		}
+		if _, ok := w.Block(npos).(RailBlock); ok {
+			n++
+		} else if _, ok := w.Block(npos.Side(cube.FaceDown)).(RailBlock); ok && !ascending {
+			n++
+		}
+	}
+	return n
+}
+
+// railShapeAt computes the RailShape of the rail passed at a position, so that it connects to up to two of the
+// rails around it. If no rails are around it, the rail runs along the axis of the fallback direction passed.
+func railShapeAt(r shapedRail, pos cube.Pos, w *world.World, fallback cube.Direction) RailShape {
+	var conn []cube.Direction
+	up := map[cube.Direction]bool{}
+	for _, d := range []cube.Direction{cube.North, cube.South, cube.West, cube.East} {
+		side := pos.Side(d.Face())
+		if _, ok := w.Block(side.Side(cube.FaceUp)).(RailBlock); ok {
+			conn, up[d] = append(conn, d), true
+		} else if _, ok := w.Block(side).(RailBlock); ok {
+			conn = append(conn, d)
+		} else if _, ok := w.Block(side.Side(cube.FaceDown)).(RailBlock); ok {
+			conn = append(conn, d)
+		}
+	}
+	straight := func(d cube.Direction) RailShape {
+		if up[d] {
+			return AscendingRailShape(d)
+		} else if up[d.Opposite()] {
+			return AscendingRailShape(d.Opposite())
+		}
+		return FlatRailShape(d.Face().Axis())
+	}
+	switch len(conn) {
+	case 0:
+		return FlatRailShape(fallback.Face().Axis())
+	case 1:
+		return straight(conn[0])
+	}
+	for i, a := range conn {
+		for _, b := range conn[i+1:] {
+			if a == b.Opposite() {
+				return straight(a)
+			}
+		}
+	}
+	if r.curvable() {
+		return CurvedRailShape(conn[0], conn[1])
+	}
+	return straight(conn[0])
+}
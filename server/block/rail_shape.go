@@ -0,0 +1,106 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// RailShape represents the shape of a rail, which determines the two directions that the rail connects to and
+// whether it is ascending.
+type RailShape struct {
+	railShape
+}
+
+// FlatRailShape returns the RailShape of a flat, straight rail running along the horizontal axis passed.
+func FlatRailShape(axis cube.Axis) RailShape {
+	if axis == cube.X {
+		return RailShape{1}
+	}
+	return RailShape{0}
+}
+
+// AscendingRailShape returns the RailShape of a straight rail that ascends towards the direction passed.
+func AscendingRailShape(d cube.Direction) RailShape {
+	switch d {
+	case cube.East:
+		return RailShape{2}
+	case cube.West:
+		return RailShape{3}
+	case cube.North:
+		return RailShape{4}
+	}
+	return RailShape{5}
+}
+
+// CurvedRailShape returns the RailShape of a curved rail connecting the two directions passed. One of the
+// directions must be either north or south, while the other must be either east or west. If the directions
+// passed do not form a curve, the flat RailShape along the axis of the first direction is returned.
+func CurvedRailShape(a, b cube.Direction) RailShape {
+	if a == cube.East || a == cube.West {
+		a, b = b, a
+	}
+	switch {
+	case a == cube.South && b == cube.East:
+		return RailShape{6}
+	case a == cube.South && b == cube.West:
+		return RailShape{7}
+	case a == cube.North && b == cube.West:
+		return RailShape{8}
+	case a == cube.North && b == cube.East:
+		return RailShape{9}
+	}
+	return FlatRailShape(a.Face().Axis())
+}
+
+// RailShapes returns all possible RailShapes.
+func RailShapes() []RailShape {
+	s := make([]RailShape, 0, 10)
+	for i := railShape(0); i < 10; i++ {
+		s = append(s, RailShape{i})
+	}
+	return s
+}
+
+type railShape uint8
+
+// Uint8 returns the RailShape as a uint8.
+func (r railShape) Uint8() uint8 {
+	return uint8(r)
+}
+
+// Curved checks if the RailShape is curved.
+func (r railShape) Curved() bool {
+	return r >= 6
+}
+
+// Ascending returns the direction that the RailShape ascends towards. If the RailShape is not ascending, false is
+// returned.
+func (r railShape) Ascending() (cube.Direction, bool) {
+	switch r {
+	case 2:
+		return cube.East, true
+	case 3:
+		return cube.West, true
+	case 4:
+		return cube.North, true
+	case 5:
+		return cube.South, true
+	}
+	return 0, false
+}
+
+// Connections returns the two directions that the RailShape connects to.
+func (r railShape) Connections() [2]cube.Direction {
+	switch r {
+	case 0, 4, 5:
+		return [2]cube.Direction{cube.North, cube.South}
+	case 1, 2, 3:
+		return [2]cube.Direction{cube.West, cube.East}
+	case 6:
+		return [2]cube.Direction{cube.South, cube.East}
+	case 7:
+		return [2]cube.Direction{cube.South, cube.West}
+	case 8:
+		return [2]cube.Direction{cube.North, cube.West}
+	}
+	return [2]cube.Direction{cube.North, cube.East}
+}
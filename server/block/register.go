@@ -60,6 +60,7 @@ func init() {
 	world.RegisterBlock(Iron{})
 	world.RegisterBlock(Jukebox{})
 	world.RegisterBlock(Lapis{})
+	world.RegisterBlock(MangroveRoots{})
 	world.RegisterBlock(Melon{})
 	world.RegisterBlock(MossCarpet{})
 	world.RegisterBlock(MudBricks{})
@@ -114,16 +115,19 @@ func init() {
 
 	registerAll(allAnvils())
 	registerAll(allBanners())
+	registerAll(allBeds())
 	registerAll(allBarrels())
 	registerAll(allBasalt())
 	registerAll(allBeetroot())
 	registerAll(allBlackstone())
 	registerAll(allBlastFurnaces())
 	registerAll(allBoneBlock())
+	registerAll(allBrewingStands())
 	registerAll(allCactus())
 	registerAll(allCake())
 	registerAll(allCarpet())
 	registerAll(allCarrots())
+	registerAll(allCauldrons())
 	registerAll(allChains())
 	registerAll(allChests())
 	registerAll(allCocoaBeans())
@@ -133,6 +137,7 @@ func init() {
 	registerAll(allCoral())
 	registerAll(allCoralBlocks())
 	registerAll(allDeepslate())
+	registerAll(allDetectorRails())
 	registerAll(allDoors())
 	registerAll(allDoubleFlowers())
 	registerAll(allDoubleTallGrass())
@@ -163,12 +168,15 @@ func init() {
 	registerAll(allNetherWart())
 	registerAll(allPlanks())
 	registerAll(allPotato())
+	registerAll(allPoweredRails())
 	registerAll(allPrismarine())
 	registerAll(allPumpkinStems())
 	registerAll(allPumpkins())
 	registerAll(allPurpurs())
 	registerAll(allQuartz())
+	registerAll(allRails())
 	registerAll(allSandstones())
+	registerAll(allSaplings())
 	registerAll(allSeaPickles())
 	registerAll(allSigns())
 	registerAll(allSkulls())
@@ -208,9 +216,11 @@ func init() {
 	world.RegisterItem(BlueIce{})
 	world.RegisterItem(Bone{})
 	world.RegisterItem(Bookshelf{})
+	world.RegisterItem(BrewingStand{})
 	world.RegisterItem(Bricks{})
 	world.RegisterItem(Cactus{})
 	world.RegisterItem(Cake{})
+	world.RegisterItem(Cauldron{})
 	world.RegisterItem(Calcite{})
 	world.RegisterItem(Carrot{})
 	world.RegisterItem(Chain{})
@@ -230,6 +240,7 @@ func init() {
 	world.RegisterItem(DeepslateTiles{})
 	world.RegisterItem(Diamond{})
 	world.RegisterItem(Diorite{Polished: true})
+	world.RegisterItem(DetectorRail{})
 	world.RegisterItem(Diorite{})
 	world.RegisterItem(DirtPath{})
 	world.RegisterItem(Dirt{Coarse: true})
@@ -266,6 +277,7 @@ func init() {
 	world.RegisterItem(Lapis{})
 	world.RegisterItem(LitPumpkin{})
 	world.RegisterItem(Loom{})
+	world.RegisterItem(MangroveRoots{})
 	world.RegisterItem(MelonSeeds{})
 	world.RegisterItem(Melon{})
 	world.RegisterItem(MossCarpet{})
@@ -290,6 +302,7 @@ func init() {
 	world.RegisterItem(PolishedBlackstoneBrick{Cracked: true})
 	world.RegisterItem(PolishedBlackstoneBrick{})
 	world.RegisterItem(Potato{})
+	world.RegisterItem(PoweredRail{})
 	world.RegisterItem(PumpkinSeeds{})
 	world.RegisterItem(Pumpkin{Carved: true})
 	world.RegisterItem(Pumpkin{})
@@ -299,6 +312,7 @@ func init() {
 	world.RegisterItem(QuartzPillar{})
 	world.RegisterItem(Quartz{Smooth: true})
 	world.RegisterItem(Quartz{})
+	world.RegisterItem(Rail{})
 	world.RegisterItem(RawCopper{})
 	world.RegisterItem(RawGold{})
 	world.RegisterItem(RawIron{})
@@ -349,6 +363,7 @@ func init() {
 	}
 	for _, c := range item.Colours() {
 		world.RegisterItem(Banner{Colour: c})
+		world.RegisterItem(Bed{Colour: c})
 		world.RegisterItem(Carpet{Colour: c})
 		world.RegisterItem(ConcretePowder{Colour: c})
 		world.RegisterItem(Concrete{Colour: c})
@@ -361,6 +376,7 @@ func init() {
 	for _, w := range WoodTypes() {
 		if w != WarpedWood() && w != CrimsonWood() {
 			world.RegisterItem(Leaves{Wood: w, Persistent: true})
+			world.RegisterItem(Sapling{Wood: w})
 		}
 		world.RegisterItem(Log{Wood: w, Stripped: true})
 		world.RegisterItem(Log{Wood: w})
@@ -0,0 +1,129 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"time"
+)
+
+// Sapling is a non-solid plant that grows into a tree over time. Saplings exist for all wood types except crimson
+// and warped wood, of which the trees grow from fungi instead. The mangrove sapling is better known as a mangrove
+// propagule.
+type Sapling struct {
+	empty
+	transparent
+
+	// Wood is the type of wood of the tree that the sapling grows into.
+	Wood WoodType
+	// Ready specifies if the sapling is ready to grow into a tree. A sapling that is ready grows the next time it
+	// is ticked successfully. Mangrove propagules skip this stage and grow directly.
+	Ready bool
+}
+
+// BoneMeal ...
+func (s Sapling) BoneMeal(pos cube.Pos, w *world.World) bool {
+	if rand.Float64() < 0.45 {
+		s.advance(pos, w, rand.New(rand.NewSource(rand.Int63())))
+	}
+	return true
+}
+
+// RandomTick ...
+func (s Sapling) RandomTick(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if w.Light(pos.Side(cube.FaceUp)) >= 9 && r.Intn(7) == 0 {
+		s.advance(pos, w, r)
+	}
+}
+
+// advance advances the growth of the sapling, either making it ready to grow or growing it into a tree.
+func (s Sapling) advance(pos cube.Pos, w *world.World, r *rand.Rand) {
+	if !s.Ready && s.Wood != Mangrove() {
+		s.Ready = true
+		w.SetBlock(pos, s, nil)
+		return
+	}
+	growTree(pos, w, s.Wood, r)
+}
+
+// NeighbourUpdateTick ...
+func (s Sapling) NeighbourUpdateTick(pos, _ cube.Pos, w *world.World) {
+	if !supportsVegetation(s, w.Block(pos.Side(cube.FaceDown))) {
+		w.SetBlock(pos, nil, nil)
+		w.AddParticle(pos.Vec3Centre(), particle.BlockBreak{Block: s})
+		dropItem(w, item.NewStack(Sapling{Wood: s.Wood}, 1), pos.Vec3Centre())
+	}
+}
+
+// UseOnBlock ...
+func (s Sapling) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(w, pos, face, s)
+	if !used {
+		return false
+	}
+	if !supportsVegetation(s, w.Block(pos.Side(cube.FaceDown))) {
+		return false
+	}
+
+	place(w, pos, s, user, ctx)
+	return placed(ctx)
+}
+
+// HasLiquidDrops ...
+func (Sapling) HasLiquidDrops() bool {
+	return true
+}
+
+// FlammabilityInfo ...
+func (Sapling) FlammabilityInfo() FlammabilityInfo {
+	return newFlammabilityInfo(60, 100, false)
+}
+
+// BreakInfo ...
+func (s Sapling) BreakInfo() BreakInfo {
+	return newBreakInfo(0, alwaysHarvestable, nothingEffective, oneOf(Sapling{Wood: s.Wood}))
+}
+
+// CompostChance ...
+func (Sapling) CompostChance() float64 {
+	return 0.3
+}
+
+// FuelInfo ...
+func (Sapling) FuelInfo() item.FuelInfo {
+	return newFuelInfo(time.Second * 5)
+}
+
+// EncodeItem ...
+func (s Sapling) EncodeItem() (name string, meta int16) {
+	if s.Wood == Mangrove() {
+		return "minecraft:mangrove_propagule", 0
+	}
+	return "minecraft:sapling", int16(s.Wood.Uint8())
+}
+
+// EncodeBlock ...
+func (s Sapling) EncodeBlock() (string, map[string]any) {
+	if s.Wood == Mangrove() {
+		return "minecraft:mangrove_propagule", map[string]any{"hanging": false, "propagule_stage": int32(0)}
+	}
+	return "minecraft:sapling", map[string]any{"sapling_type": s.Wood.String(), "age_bit": s.Ready}
+}
+
+// allSaplings returns a list of all possible sapling states.
+func allSaplings() (saplings []world.Block) {
+	for _, w := range WoodTypes() {
+		switch w {
+		case CrimsonWood(), WarpedWood():
+			continue
+		case Mangrove():
+			saplings = append(saplings, Sapling{Wood: w})
+		default:
+			saplings = append(saplings, Sapling{Wood: w}, Sapling{Wood: w, Ready: true})
+		}
+	}
+	return
+}
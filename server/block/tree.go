@@ -0,0 +1,349 @@
+package block
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/world"
+	"math/rand"
+)
+
+// growTree attempts to grow a tree of a WoodType from the sapling at a cube.Pos. Spruce and jungle saplings grow
+// into giant trees if placed in a 2x2 square, which is required for dark oak saplings to grow at all. False is
+// returned if there was not enough space for the tree or if growing the tree was cancelled.
+func growTree(pos cube.Pos, w *world.World, wood WoodType, r *rand.Rand) bool {
+	origin, mega := pos, false
+	if wood == SpruceWood() || wood == JungleWood() || wood == DarkOakWood() {
+		origin, mega = megaSaplings(pos, w, wood)
+	}
+	t := tree{w: w, wood: wood, blocks: make(map[cube.Pos]world.Block)}
+
+	var ok bool
+	switch wood {
+	case OakWood():
+		ok = t.oak(origin, 4+r.Intn(3), r)
+	case BirchWood():
+		ok = t.oak(origin, 5+r.Intn(3), r)
+	case JungleWood():
+		if mega {
+			ok = t.megaJungle(origin, r)
+		} else {
+			ok = t.oak(origin, 4+r.Intn(7), r)
+		}
+	case SpruceWood():
+		if mega {
+			ok = t.megaSpruce(origin, r)
+		} else {
+			ok = t.spruce(origin, r)
+		}
+	case AcaciaWood():
+		ok = t.acacia(origin, r)
+	case DarkOakWood():
+		ok = mega && t.darkOak(origin, r)
+	case Mangrove():
+		ok = t.mangrove(origin, r)
+	}
+	if !ok {
+		return false
+	}
+
+	ctx := event.C()
+	if w.Handler().HandleTreeGrow(ctx, pos, t.blocks); ctx.Cancelled() {
+		return false
+	}
+	for p, b := range t.blocks {
+		w.SetBlock(p, b, nil)
+	}
+	return true
+}
+
+// megaSaplings looks for a 2x2 square of saplings of a WoodType that includes the sapling at the cube.Pos passed.
+// If found, the north-west corner of the square is returned along with true.
+func megaSaplings(pos cube.Pos, w *world.World, wood WoodType) (cube.Pos, bool) {
+	for _, offset := range [...]cube.Pos{{0, 0, 0}, {-1, 0, 0}, {0, 0, -1}, {-1, 0, -1}} {
+		origin, found := pos.Add(offset), true
+		for x := 0; x < 2 && found; x++ {
+			for z := 0; z < 2 && found; z++ {
+				s, ok := w.Block(origin.Add(cube.Pos{x, 0, z})).(Sapling)
+				found = ok && s.Wood == wood
+			}
+		}
+		if found {
+			return origin, true
+		}
+	}
+	return pos, false
+}
+
+// tree holds the blocks of a tree that is being grown. No blocks are placed in the world until the tree is
+// complete.
+type tree struct {
+	w      *world.World
+	wood   WoodType
+	blocks map[cube.Pos]world.Block
+}
+
+// replaceable checks if the block at a cube.Pos may be replaced by a block of the tree.
+func (t tree) replaceable(pos cube.Pos) bool {
+	if pos.OutOfBounds(t.w.Range()) {
+		return false
+	}
+	switch b := t.w.Block(pos).(type) {
+	case Leaves, Sapling:
+		return true
+	case Replaceable:
+		return b.ReplaceableBy(Leaves{})
+	}
+	return false
+}
+
+// log adds a log facing an axis to the tree at a cube.Pos. False is returned if the block at the position could
+// not be replaced.
+func (t tree) log(pos cube.Pos, axis cube.Axis) bool {
+	if !t.replaceable(pos) {
+		return false
+	}
+	t.blocks[pos] = Log{Wood: t.wood, Axis: axis}
+	return true
+}
+
+// leaves adds leaves to the tree at a cube.Pos, unless the block there cannot be replaced or the tree already has
+// a log at that position.
+func (t tree) leaves(pos cube.Pos) {
+	if _, ok := t.blocks[pos].(Log); ok || !t.replaceable(pos) {
+		return
+	}
+	t.blocks[pos] = Leaves{Wood: t.wood}
+}
+
+// trunk adds a straight trunk of logs with a height to the tree. The trunk is a square with sides of the size passed,
+// starting at the north-west corner origin. Grass below the trunk is turned into dirt. False is returned if any of
+// the blocks of the trunk could not be replaced.
+func (t tree) trunk(origin cube.Pos, height, size int) bool {
+	for y := 0; y < height; y++ {
+		for x := 0; x < size; x++ {
+			for z := 0; z < size; z++ {
+				if !t.log(origin.Add(cube.Pos{x, y, z}), cube.Y) {
+					return false
+				}
+			}
+		}
+	}
+	for x := 0; x < size; x++ {
+		for z := 0; z < size; z++ {
+			t.dirt(origin.Add(cube.Pos{x, -1, z}))
+		}
+	}
+	return true
+}
+
+// dirt turns the block at a cube.Pos into dirt if it is a block that trees may grow on, such as grass.
+func (t tree) dirt(pos cube.Pos) {
+	switch t.w.Block(pos).(type) {
+	case Grass, Farmland, Podzol, DirtPath:
+		t.blocks[pos] = Dirt{}
+	}
+}
+
+// layer adds a square layer of leaves with a radius around a trunk with sides of the size passed, starting at the
+// north-west corner origin. trimCorner is called for every corner of the layer and returns true if the corner
+// should be left empty.
+func (t tree) layer(origin cube.Pos, radius, size int, trimCorner func() bool) {
+	for x := -radius; x < radius+size; x++ {
+		for z := -radius; z < radius+size; z++ {
+			dx, dz := max(max(-x, x-size+1), 0), max(max(-z, z-size+1), 0)
+			if radius > 0 && dx == radius && dz == radius && trimCorner() {
+				continue
+			}
+			t.leaves(origin.Add(cube.Pos{x, 0, z}))
+		}
+	}
+}
+
+// oak adds a regular oak tree with a trunk of a height to the tree. Birch and small jungle trees share the same
+// shape.
+func (t tree) oak(origin cube.Pos, height int, r *rand.Rand) bool {
+	if !t.trunk(origin, height, 1) {
+		return false
+	}
+	for y := height - 3; y <= height; y++ {
+		top := y == height
+		t.layer(origin.Add(cube.Pos{0, y}), 1-(y-height)/2, 1, func() bool {
+			return top || r.Intn(2) == 0
+		})
+	}
+	return true
+}
+
+// spruce adds a regular, conical spruce tree to the tree.
+func (t tree) spruce(origin cube.Pos, r *rand.Rand) bool {
+	height := 6 + r.Intn(4)
+	if !t.trunk(origin, height, 1) {
+		return false
+	}
+	bare, maxRadius := 1+r.Intn(2), 2+r.Intn(2)
+	radius, limit, next := 0, 1, 0
+	for y := height; y >= bare; y-- {
+		t.layer(origin.Add(cube.Pos{0, y}), radius, 1, alwaysTrim)
+		if radius >= limit {
+			radius, next, limit = next, 1, min(limit+1, maxRadius)
+		} else {
+			radius++
+		}
+	}
+	return true
+}
+
+// megaSpruce adds a giant spruce tree with a 2x2 trunk to the tree.
+func (t tree) megaSpruce(origin cube.Pos, r *rand.Rand) bool {
+	height := 13 + r.Intn(15)
+	if !t.trunk(origin, height, 2) {
+		return false
+	}
+	crown := min(10+r.Intn(6), height-3)
+	for i := 0; i <= crown; i++ {
+		t.layer(origin.Add(cube.Pos{0, height - i}), min(i/3+i%2, 3), 2, alwaysTrim)
+	}
+	return true
+}
+
+// megaJungle adds a giant jungle tree with a 2x2 trunk and a number of branches to the tree.
+func (t tree) megaJungle(origin cube.Pos, r *rand.Rand) bool {
+	height := 10 + r.Intn(20)
+	if !t.trunk(origin, height, 2) {
+		return false
+	}
+	for y := height - 2 - r.Intn(4); y > height/2; y -= 2 + r.Intn(4) {
+		dx, dz := r.Intn(3)-1, r.Intn(3)-1
+		if dx == 0 && dz == 0 {
+			continue
+		}
+		axis := cube.X
+		if abs(dz) > abs(dx) {
+			axis = cube.Z
+		}
+		// Branches start at the side of the trunk they grow towards.
+		pos := origin.Add(cube.Pos{max(dx, 0), y, max(dz, 0)})
+		if dx == 0 {
+			pos[0] += r.Intn(2)
+		}
+		if dz == 0 {
+			pos[2] += r.Intn(2)
+		}
+		for i, length := 0, 1+r.Intn(2); i < length; i++ {
+			pos = pos.Add(cube.Pos{dx, i % 2, dz})
+			if !t.log(pos, axis) {
+				break
+			}
+		}
+		t.layer(pos, 2, 1, func() bool { return r.Intn(2) == 0 })
+		t.layer(pos.Side(cube.FaceUp), 1, 1, alwaysTrim)
+	}
+	t.layer(origin.Add(cube.Pos{0, height - 1}), 3, 2, func() bool { return r.Intn(2) == 0 })
+	t.layer(origin.Add(cube.Pos{0, height}), 3, 2, alwaysTrim)
+	t.layer(origin.Add(cube.Pos{0, height + 1}), 2, 2, alwaysTrim)
+	return true
+}
+
+// darkOak adds a dark oak tree with a 2x2 trunk and a wide, flat canopy to the tree.
+func (t tree) darkOak(origin cube.Pos, r *rand.Rand) bool {
+	height := 6 + r.Intn(3)
+	if !t.trunk(origin, height, 2) {
+		return false
+	}
+	t.layer(origin.Add(cube.Pos{0, height - 2}), 2, 2, func() bool { return r.Intn(2) == 0 })
+	t.layer(origin.Add(cube.Pos{0, height - 1}), 3, 2, alwaysTrim)
+	t.layer(origin.Add(cube.Pos{0, height}), 2, 2, func() bool { return r.Intn(2) == 0 })
+	t.layer(origin.Add(cube.Pos{0, height + 1}), 1, 2, alwaysTrim)
+	return true
+}
+
+// acacia adds an acacia tree to the tree. The trunk of acacia trees bends to one side near its top and may have a
+// second branch, both ending in a flat canopy.
+func (t tree) acacia(origin cube.Pos, r *rand.Rand) bool {
+	height := 5 + r.Intn(3)
+	if !t.trunk(origin, 1, 1) {
+		return false
+	}
+	faces := cube.HorizontalFaces()
+	face := faces[r.Intn(len(faces))]
+
+	pos, bendAt, bend := origin, height-1-r.Intn(4), 1+r.Intn(3)
+	for y := 1; y < height; y++ {
+		pos = pos.Side(cube.FaceUp)
+		if y >= bendAt && bend > 0 {
+			pos, bend = pos.Side(face), bend-1
+		}
+		if !t.log(pos, cube.Y) {
+			return false
+		}
+	}
+	t.layer(pos, 3, 1, alwaysTrim)
+	t.layer(pos.Side(cube.FaceUp), 1, 1, alwaysTrim)
+
+	if branchFace := faces[r.Intn(len(faces))]; branchFace != face && r.Intn(2) == 0 {
+		pos = origin.Add(cube.Pos{0, bendAt - 1 - r.Intn(2)})
+		for i, length := 0, 1+r.Intn(3); i < length; i++ {
+			pos = pos.Side(branchFace).Side(cube.FaceUp)
+			if !t.log(pos, cube.Y) {
+				return true
+			}
+		}
+		t.layer(pos, 2, 1, alwaysTrim)
+		t.layer(pos.Side(cube.FaceUp), 1, 1, alwaysTrim)
+	}
+	return true
+}
+
+// mangrove adds a mangrove tree to the tree. The trunk of the mangrove tree is raised above the ground by roots
+// which spread out downwards, turning into muddy mangrove roots where they grow into mud.
+func (t tree) mangrove(origin cube.Pos, r *rand.Rand) bool {
+	raise := 1 + r.Intn(3)
+	for y := 0; y < raise; y++ {
+		if !t.replaceable(origin.Add(cube.Pos{0, y})) {
+			return false
+		}
+	}
+	base, height := origin.Add(cube.Pos{0, raise}), 4+r.Intn(4)
+	if !t.trunk(base, height, 1) {
+		return false
+	}
+	// Any dirt added by the trunk is below the roots rather than the trunk, so it is removed again.
+	delete(t.blocks, base.Side(cube.FaceDown))
+	for y := 0; y < raise; y++ {
+		t.root(origin.Add(cube.Pos{0, y}))
+	}
+	for _, face := range cube.HorizontalFaces() {
+		pos := base.Side(cube.FaceDown).Side(face)
+		for i := 0; i < 8 && t.root(pos); i++ {
+			if pos = pos.Side(cube.FaceDown); r.Intn(2) == 0 {
+				pos = pos.Side(face)
+			}
+		}
+	}
+
+	top := base.Add(cube.Pos{0, height - 1})
+	t.layer(top.Add(cube.Pos{0, -2}), 2, 1, func() bool { return r.Intn(2) == 0 })
+	t.layer(top.Add(cube.Pos{0, -1}), 3, 1, alwaysTrim)
+	t.layer(top, 3, 1, alwaysTrim)
+	t.layer(top.Side(cube.FaceUp), 2, 1, alwaysTrim)
+	return true
+}
+
+// root adds a mangrove root to the tree at a cube.Pos. Roots grown into mud become muddy mangrove roots. True is
+// returned if the root may continue to grow further down.
+func (t tree) root(pos cube.Pos) bool {
+	if _, ok := t.w.Block(pos).(Mud); ok {
+		t.blocks[pos] = MuddyMangroveRoots{Axis: cube.Y}
+		return false
+	}
+	if _, ok := t.blocks[pos].(Log); ok || !t.replaceable(pos) {
+		return false
+	}
+	t.blocks[pos] = MangroveRoots{}
+	return true
+}
+
+// alwaysTrim may be passed to tree.layer to leave all corners of a layer empty.
+func alwaysTrim() bool {
+	return true
+}
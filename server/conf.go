@@ -5,8 +5,10 @@ import (
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/internal/packbuilder"
+	"github.com/df-mc/dragonfly/server/item/loot"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/playerdb"
+	"github.com/df-mc/dragonfly/server/query"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/biome"
@@ -16,8 +18,10 @@ import (
 	"github.com/sandertv/gophertunnel/minecraft/resource"
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/slices"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Config contains options for starting a Minecraft server.
@@ -49,8 +53,50 @@ type Config struct {
 	DisableResourceBuilding bool
 	// Allower may be used to specify what players can join the server and what
 	// players cannot. By returning false in the Allow method, for example if
-	// the player has been banned, will prevent the player from joining.
+	// the player has been banned, will prevent the player from joining. An
+	// AccessList may be used as Allower to manage bans and a whitelist.
 	Allower Allower
+	// ConnectionThrottle, if non-nil, limits the rate at which connections
+	// from a single IP address are accepted. Connections exceeding the limit
+	// are refused before being passed to the Allower.
+	ConnectionThrottle *ThrottleConfig
+	// PacketMiddleware is a list of session.PacketMiddleware added to the
+	// session of every player that joins the server. Middleware may be used
+	// to observe, modify or drop packets sent between the server and its
+	// players. Middleware specific to a single player may be added using
+	// player.Player.UsePacketMiddleware.
+	PacketMiddleware []session.PacketMiddleware
+	// MovementValidation, if non-nil, enables server-side validation of the
+	// movement of players. Movement that deviates from the movement expected
+	// by the server, for example because of speed, fly or noclip hacks, is
+	// rejected and the player is teleported back. Violations are passed to
+	// player.Handler.HandleMovementViolation.
+	MovementValidation *session.MovementConfig
+	// CombatValidation, if non-nil, enables server-side validation of the
+	// attacks of players. Attacks on entities out of reach or behind blocks,
+	// and attacks exceeding the maximum click rate, are rejected. Violations
+	// are passed to player.Handler.HandleAttackViolation.
+	CombatValidation *session.CombatConfig
+	// Query, if non-nil, enables a responder for the query protocol, which
+	// server lists and monitoring tools use to obtain information about the
+	// server, such as its name, version and the names of online players.
+	Query *QueryConfig
+	// RCON, if non-nil, enables a remote console using the Source RCON
+	// protocol, allowing commands to be executed remotely by clients that
+	// authenticate with a password.
+	RCON *RCONConfig
+	// Metrics, if non-nil, enables an HTTP server exporting metrics of the
+	// server, such as tick durations, loaded chunks and packet counts, in the
+	// Prometheus text exposition format. Metrics may also be exported on an
+	// existing HTTP server using Server.MetricsHandler.
+	Metrics *MetricsConfig
+	// Proxy, if non-nil, enables proxy mode for the standard listener. In
+	// proxy mode, the real address and XUID of players joining through a
+	// proxy such as WaterdogPE are obtained from the login forwarded by the
+	// proxy, after verifying that the connection originates from a trusted
+	// proxy. Proxies generally do not forward XBOX Live authentication, so
+	// AuthDisabled must typically be set to true in proxy mode.
+	Proxy *ProxyConfig
 	// AuthDisabled specifies if XBOX Live authentication should be disabled.
 	// Note that this should generally only be done for testing purposes or for
 	// local games. Allowing players to join without authentication is generally
@@ -96,6 +142,17 @@ type Config struct {
 	// may be added to the Server's worlds. If no entity types are registered,
 	// Entities will be set to entity.DefaultRegistry.
 	Entities world.EntityRegistry
+	// LootTables is a file system holding Bedrock Edition loot tables in JSON
+	// format, such as the root of a behaviour pack with a loot_tables
+	// directory. The loot tables are registered using loot.Load, overriding
+	// the default loot tables with the same name. If left nil, only the
+	// default loot tables are used.
+	LootTables fs.FS
+
+	// queryResponder is the query.Responder that answers queries on the port
+	// of the standard listener. It is nil if queries are not answered on that
+	// port.
+	queryResponder *query.Responder
 }
 
 // Logger is used to report information and errors from a dragonfly Server. Any
@@ -131,7 +188,7 @@ func (conf Config) New() *Server {
 		conf.WorldProvider = world.NopProvider{}
 	}
 	if conf.Generator == nil {
-		conf.Generator = loadGenerator
+		conf.Generator = DefaultGenerator
 	}
 	if conf.MaxChunkRadius == 0 {
 		conf.MaxChunkRadius = 12
@@ -139,6 +196,11 @@ func (conf Config) New() *Server {
 	if len(conf.Entities.Types()) == 0 {
 		conf.Entities = entity.DefaultRegistry
 	}
+	if conf.LootTables != nil {
+		if err := loot.Load(conf.LootTables); err != nil {
+			conf.Log.Errorf("config: load loot tables: %v", err)
+		}
+	}
 	if !conf.DisableResourceBuilding {
 		if pack, ok := packbuilder.BuildResourcePack(); ok {
 			conf.Resources = append(conf.Resources, pack)
@@ -151,6 +213,7 @@ func (conf Config) New() *Server {
 		conf:     conf,
 		incoming: make(chan *session.Session),
 		p:        make(map[uuid.UUID]*player.Player),
+		throttle: newThrottle(conf.ConnectionThrottle),
 		world:    &world.World{}, nether: &world.World{}, end: &world.World{},
 	}
 	srv.world = srv.createWorld(world.Overworld, &srv.nether, &srv.end)
@@ -159,6 +222,7 @@ func (conf Config) New() *Server {
 
 	srv.registerTargetFunc()
 	srv.checkNetIsolation()
+	srv.createQueryResponder()
 
 	return srv
 }
@@ -173,6 +237,44 @@ type UserConfig struct {
 		// Address is the address on which the server should listen. Players may
 		// connect to this address in order to join.
 		Address string
+		// QueryEnabled specifies if the server should answer queries, which
+		// server lists and monitoring tools use to obtain information such as
+		// the names of online players.
+		QueryEnabled bool
+		// QueryAddress is the UDP address on which queries are answered. If
+		// empty, queries are answered on the same port as Address.
+		QueryAddress string
+		// RCONEnabled specifies if the server should run a remote console
+		// using the Source RCON protocol.
+		RCONEnabled bool
+		// RCONAddress is the TCP address on which the remote console listens.
+		RCONAddress string
+		// RCONPassword is the password that remote console clients must
+		// authenticate with. It must not be empty if RCONEnabled is true.
+		RCONPassword string
+		// MetricsEnabled specifies if the server should export metrics over
+		// HTTP in the Prometheus text exposition format.
+		MetricsEnabled bool
+		// MetricsAddress is the TCP address on which metrics are exported,
+		// under the /metrics path.
+		MetricsAddress string
+		// ProxyEnabled specifies if the server runs behind a proxy such as
+		// WaterdogPE, which forwards the real address and XUID of players.
+		// AuthEnabled should typically be set to false if ProxyEnabled is true.
+		// At least one of ProxyTrustedAddresses and ProxySecret must be set.
+		ProxyEnabled bool
+		// ProxyTrustedAddresses is a list of IP addresses and CIDR ranges of
+		// proxies allowed to connect. Connections from other addresses are
+		// refused. If empty, connections from any address are accepted.
+		ProxyTrustedAddresses []string
+		// ProxySecret is a secret shared with the proxy, used to verify the
+		// signature of forwarded identities. If empty, no signature is
+		// required.
+		ProxySecret string
+		// ConnectionThrottle is the maximum amount of connections accepted
+		// from a single IP address per minute. If 0, connections are not
+		// throttled.
+		ConnectionThrottle int
 	}
 	Server struct {
 		// Name is the name of the server as it shows up in the server list.
@@ -221,6 +323,12 @@ type UserConfig struct {
 		// Folder controls where the player data will be stored by the default
 		// LevelDB player provider if it is enabled.
 		Folder string
+		// AccessListFile is the JSON file that player bans and the whitelist
+		// are stored in. If set, the *AccessList created is set as
+		// Config.Allower, and may be managed using the commands returned by
+		// AccessCommands. If empty, no AccessList is created and the Allower
+		// of the Config is left empty.
+		AccessListFile string
 	}
 	Resources struct {
 		// AutoBuildPack is if the server should automatically generate a
@@ -232,6 +340,11 @@ type UserConfig struct {
 		// Required is a boolean to force the client to load the resource pack
 		// on join. If they do not accept, they'll have to leave the server.
 		Required bool
+		// LootTables is the folder that loot tables overriding the default
+		// ones are loaded from. The folder should hold a loot_tables directory,
+		// like the root of a behaviour pack. If empty, only the default loot
+		// tables are used.
+		LootTables string
 	}
 }
 
@@ -262,12 +375,36 @@ func (uc UserConfig) Config(log Logger) (Config, error) {
 	if err != nil {
 		return conf, fmt.Errorf("load resources: %w", err)
 	}
+	if uc.Resources.LootTables != "" {
+		conf.LootTables = os.DirFS(uc.Resources.LootTables)
+	}
 	if uc.Players.SaveData {
 		conf.PlayerProvider, err = playerdb.NewProvider(uc.Players.Folder)
 		if err != nil {
 			return conf, fmt.Errorf("create player provider: %w", err)
 		}
 	}
+	if uc.Players.AccessListFile != "" {
+		conf.Allower, err = NewAccessList(uc.Players.AccessListFile)
+		if err != nil {
+			return conf, fmt.Errorf("create access list: %w", err)
+		}
+	}
+	if uc.Network.ConnectionThrottle > 0 {
+		conf.ConnectionThrottle = &ThrottleConfig{Connections: uc.Network.ConnectionThrottle, Period: time.Minute}
+	}
+	if uc.Network.QueryEnabled {
+		conf.Query = &QueryConfig{Address: uc.Network.QueryAddress}
+	}
+	if uc.Network.RCONEnabled {
+		conf.RCON = &RCONConfig{Address: uc.Network.RCONAddress, Password: uc.Network.RCONPassword}
+	}
+	if uc.Network.MetricsEnabled {
+		conf.Metrics = &MetricsConfig{Address: uc.Network.MetricsAddress}
+	}
+	if uc.Network.ProxyEnabled {
+		conf.Proxy = &ProxyConfig{TrustedAddresses: uc.Network.ProxyTrustedAddresses, Secret: uc.Network.ProxySecret}
+	}
 	conf.Listeners = append(conf.Listeners, uc.listenerFunc)
 	return conf, nil
 }
@@ -290,8 +427,9 @@ func loadResources(dir string) ([]*resource.Pack, error) {
 	return packs, nil
 }
 
-// loadGenerator loads a standard world.Generator for a world.Dimension.
-func loadGenerator(dim world.Dimension) world.Generator {
+// DefaultGenerator returns the standard world.Generator for a world.Dimension.
+// It is the generator used if Config.Generator is left empty.
+func DefaultGenerator(dim world.Dimension) world.Generator {
 	switch dim {
 	case world.Overworld:
 		return generator.NewFlat(biome.Plains{}, []world.Block{block.Grass{}, block.Dirt{}, block.Dirt{}, block.Bedrock{}})
@@ -307,6 +445,8 @@ func loadGenerator(dim world.Dimension) world.Generator {
 func DefaultConfig() UserConfig {
 	c := UserConfig{}
 	c.Network.Address = ":19132"
+	c.Network.RCONAddress = ":25575"
+	c.Network.MetricsAddress = ":9100"
 	c.Server.Name = "Dragonfly Server"
 	c.Server.ShutdownMessage = "Server closed."
 	c.Server.AuthEnabled = true
@@ -317,6 +457,7 @@ func DefaultConfig() UserConfig {
 	c.Players.MaximumChunkRadius = 32
 	c.Players.SaveData = true
 	c.Players.Folder = "players"
+	c.Players.AccessListFile = "access_list.json"
 	c.Resources.AutoBuildPack = true
 	c.Resources.Folder = "resources"
 	c.Resources.Required = false
@@ -24,6 +24,10 @@ type DeathAction struct{ action }
 // item in its hand being eaten.
 type EatAction struct{ action }
 
+// WakeUpAction is a world.EntityAction that makes a sleeping entity or player display the animation of waking up
+// and leaving its bed.
+type WakeUpAction struct{ action }
+
 // ArrowShakeAction makes an arrow entity display a shaking animation for the given duration.
 type ArrowShakeAction struct {
 	// Duration is the duration of the shake.
@@ -44,6 +48,33 @@ type PickedUpAction struct {
 // FireworkExplosionAction is a world.EntityAction that makes a Firework rocket display an explosion particle.
 type FireworkExplosionAction struct{ action }
 
+// FishingHookBubbleAction is a world.EntityAction that makes a fishing hook display bubbles, indicating that a
+// fish is approaching it.
+type FishingHookBubbleAction struct{ action }
+
+// FishingHookTeaseAction is a world.EntityAction that makes a fishing hook display the animation of a fish biting
+// it.
+type FishingHookTeaseAction struct{ action }
+
+// MountAction is a world.EntityAction that makes an entity start riding a vehicle, such as a boat or a minecart.
+type MountAction struct {
+	// Vehicle is the entity that is being ridden.
+	Vehicle world.Entity
+	// Driver specifies if the entity occupies the seat of the driver of the Vehicle, which controls its
+	// movement.
+	Driver bool
+
+	action
+}
+
+// DismountAction is a world.EntityAction that makes an entity stop riding a vehicle.
+type DismountAction struct {
+	// Vehicle is the entity that was being ridden.
+	Vehicle world.Entity
+
+	action
+}
+
 // action implements the Action interface. Structures in this package may embed it to gets its functionality
 // out of the box.
 type action struct{}
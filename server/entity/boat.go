@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewBoat creates a boat entity of the type passed, facing the yaw passed. Boats float on water and may be
+// ridden by up to two entities, of which the first one steers the boat.
+func NewBoat(pos mgl64.Vec3, yaw float64, t item.BoatType) *Ent {
+	config := boatConf
+	config.Type = t
+	e := Config{Behaviour: config.New()}.New(BoatType{}, pos)
+	e.rot = cube.Rotation{yaw, 0}
+	return e
+}
+
+var boatConf = BoatBehaviourConfig{
+	Gravity: 0.04,
+	Drag:    0.05,
+}
+
+// BoatType is a world.EntityType implementation for boats.
+type BoatType struct{}
+
+func (BoatType) EncodeEntity() string   { return "minecraft:boat" }
+func (BoatType) NetworkOffset() float64 { return 0.375 }
+func (BoatType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.7, 0, -0.7, 0.7, 0.455, 0.7)
+}
+
+func (BoatType) DecodeNBT(m map[string]any) world.Entity {
+	t := item.OakBoat()
+	for _, bt := range item.BoatTypes() {
+		if int32(bt.Uint8()) == nbtconv.Int32(m, "Variant") {
+			t = bt
+		}
+	}
+	b := NewBoat(nbtconv.Vec3(m, "Pos"), float64(nbtconv.Float32(m, "Yaw")), t)
+	b.vel = nbtconv.Vec3(m, "Motion")
+	return b
+}
+
+func (BoatType) EncodeNBT(e world.Entity) map[string]any {
+	b := e.(*Ent)
+	return map[string]any{
+		"Pos":     nbtconv.Vec3ToFloat32Slice(b.Position()),
+		"Yaw":     float32(b.Rotation().Yaw()),
+		"Motion":  nbtconv.Vec3ToFloat32Slice(b.Velocity()),
+		"Variant": int32(b.Behaviour().(*BoatBehaviour).Type().Uint8()),
+	}
+}
@@ -0,0 +1,100 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// BoatBehaviourConfig holds optional parameters for a BoatBehaviour.
+type BoatBehaviourConfig struct {
+	// Type is the type of wood that the boat is made of. It determines the item dropped when the boat is broken.
+	Type item.BoatType
+	// Gravity is the amount of Y velocity subtracted every tick while the boat is not in water.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is multiplied with (1-Drag) every
+	// tick.
+	Drag float64
+}
+
+// New creates a BoatBehaviour using the parameters in conf.
+func (conf BoatBehaviourConfig) New() *BoatBehaviour {
+	b := &BoatBehaviour{conf: conf, mc: &MovementComputer{Gravity: conf.Gravity, Drag: conf.Drag, DragBeforeGravity: true}}
+	b.vehicle = vehicle{passengers: passengers{seats: 2}, hurtDirection: 1, drops: b.drops}
+	return b
+}
+
+// BoatBehaviour implements the behaviour of a boat. Boats float on water and may be ridden by two entities. A
+// boat driven by a Rider, such as a player, is moved by its driver through calls to Drive. Otherwise, it floats
+// passively.
+type BoatBehaviour struct {
+	vehicle
+	conf BoatBehaviourConfig
+	mc   *MovementComputer
+}
+
+// Type returns the type of wood that the boat is made of.
+func (b *BoatBehaviour) Type() item.BoatType {
+	return b.conf.Type
+}
+
+// Variant returns the variant of the boat entity, which is the type of wood it is made of.
+func (b *BoatBehaviour) Variant() int32 {
+	return int32(b.conf.Type.Uint8())
+}
+
+// SeatPosition ...
+func (b *BoatBehaviour) SeatPosition(passenger world.Entity) (mgl64.Vec3, bool) {
+	i, n := b.seat(passenger)
+	switch {
+	case i == -1:
+		return mgl64.Vec3{}, false
+	case n == 1:
+		return mgl64.Vec3{0, -0.6}, true
+	case i == 0:
+		return mgl64.Vec3{0.2, -0.6}, true
+	}
+	return mgl64.Vec3{-0.6, -0.6}, true
+}
+
+// Drive ...
+func (b *BoatBehaviour) Drive(e *Ent, pos mgl64.Vec3, rot cube.Rotation) {
+	w := e.World()
+	e.mu.Lock()
+	m := &Movement{v: w.Viewers(pos), e: e, pos: pos, dpos: pos.Sub(e.pos), dvel: e.vel.Mul(-1), rot: rot}
+	e.pos, e.vel, e.rot = pos, mgl64.Vec3{}, rot
+	e.mu.Unlock()
+	m.Send()
+}
+
+// Tick makes the boat float on water or fall while on land, unless it is being driven.
+func (b *BoatBehaviour) Tick(e *Ent) *Movement {
+	b.tickVehicle(e)
+	if d, ok := Driver(e); ok {
+		if _, ok := d.(Rider); ok {
+			// The boat is moved by its driver.
+			return nil
+		}
+	}
+	w := e.World()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	pos, vel := e.pos, e.vel
+	if surface, ok := waterSurface(w, cube.PosFromVec3(pos.Add(mgl64.Vec3{0, 0.1}))); ok {
+		// The boat is in water, so it floats up until it reaches the surface.
+		b.mc.Gravity = 0
+		vel[1] = vel[1]*0.8 + (surface-0.1-pos[1])*0.1
+	} else {
+		b.mc.Gravity = b.conf.Gravity
+	}
+	m := b.mc.TickMovement(e, pos, vel, e.rot)
+	e.pos, e.vel = m.pos, m.vel
+	return m
+}
+
+// drops returns the boat item of the type of the boat.
+func (b *BoatBehaviour) drops(*Ent) []item.Stack {
+	return []item.Stack{item.NewStack(item.Boat{Type: b.conf.Type}, 1)}
+}
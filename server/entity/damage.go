@@ -46,6 +46,10 @@ type (
 
 	// ExplosionDamageSource is used for damage caused by an explosion.
 	ExplosionDamageSource struct{}
+
+	// BorderDamageSource is used for damage caused by an entity being outside
+	// of the world.Border of its world.
+	BorderDamageSource struct{}
 )
 
 func (FallDamageSource) ReducedByArmour() bool     { return false }
@@ -73,6 +77,9 @@ func (SuffocationDamageSource) Fire() bool                { return false }
 func (DrowningDamageSource) ReducedByResistance() bool    { return false }
 func (DrowningDamageSource) ReducedByArmour() bool        { return false }
 func (DrowningDamageSource) Fire() bool                   { return false }
+func (BorderDamageSource) ReducedByResistance() bool      { return false }
+func (BorderDamageSource) ReducedByArmour() bool          { return false }
+func (BorderDamageSource) Fire() bool                     { return false }
 func (ProjectileDamageSource) ReducedByResistance() bool  { return true }
 func (ProjectileDamageSource) ReducedByArmour() bool      { return true }
 func (ProjectileDamageSource) Fire() bool                 { return false }
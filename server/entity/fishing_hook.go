@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewFishingHook creates a fishing hook entity cast by the owner passed using
+// the fishing rod item stack passed. The enchantments of the rod influence the
+// loot caught with the hook.
+func NewFishingHook(pos mgl64.Vec3, owner world.Entity, rod item.Stack) *Ent {
+	conf := fishingHookConf
+	conf.Rod = rod
+	return Config{Behaviour: conf.New(owner)}.New(FishingHookType{}, pos)
+}
+
+var fishingHookConf = FishingHookBehaviourConfig{
+	Gravity: 0.03,
+	Drag:    0.08,
+}
+
+// FishingHookType is a world.EntityType implementation for fishing hooks.
+// Fishing hooks are not saved, as they cannot exist without their owner.
+type FishingHookType struct{}
+
+func (FishingHookType) EncodeEntity() string { return "minecraft:fishing_hook" }
+func (FishingHookType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.125, 0, -0.125, 0.125, 0.25, 0.125)
+}
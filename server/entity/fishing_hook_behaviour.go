@@ -0,0 +1,314 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/item/loot"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// FishingHookBehaviourConfig holds optional parameters for a
+// FishingHookBehaviour.
+type FishingHookBehaviourConfig struct {
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick.
+	Drag float64
+	// Rod is the fishing rod item stack that the hook was cast with. The Luck
+	// of the Sea and Lure enchantments of the rod influence the loot caught
+	// and the time it takes for a fish to bite.
+	Rod item.Stack
+}
+
+// New creates a FishingHookBehaviour using the parameters in conf. The owner
+// passed is the entity that cast the hook.
+func (conf FishingHookBehaviourConfig) New(owner world.Entity) *FishingHookBehaviour {
+	f := &FishingHookBehaviour{conf: conf}
+	f.projectile = ProjectileBehaviourConfig{
+		Gravity:                conf.Gravity,
+		Drag:                   conf.Drag,
+		Damage:                 -1,
+		Hit:                    f.hit,
+		SurviveBlockCollision:  true,
+		SurviveEntityCollision: true,
+		DisablePickup:          true,
+	}.New(owner)
+	return f
+}
+
+// FishingHookBehaviour implements the behaviour of the hook of a fishing rod.
+// The hook flies like a projectile until it hooks an entity or lands in
+// water, where it bobs until a fish bites. The hook is removed once it is
+// reeled in by its owner or once the owner stops holding a fishing rod.
+type FishingHookBehaviour struct {
+	conf       FishingHookBehaviourConfig
+	projectile *ProjectileBehaviour
+
+	mu      sync.Mutex
+	closed  bool
+	hooked  world.Entity
+	inWater bool
+	// waitTicks, approachTicks and biteTicks are the ticks left until a fish
+	// starts approaching the hook, until the fish bites the hook and until the
+	// fish lets go of the hook respectively.
+	waitTicks, approachTicks, biteTicks int
+}
+
+// maxFishingDistance is the maximum distance between a fishing hook and its
+// owner. Hooks further away are removed.
+const maxFishingDistance = 32
+
+// Owner returns the entity that cast the fishing hook.
+func (f *FishingHookBehaviour) Owner() world.Entity {
+	return f.projectile.Owner()
+}
+
+// Hooked returns the entity hooked by the fishing hook, if any.
+func (f *FishingHookBehaviour) Hooked() (world.Entity, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.hooked, f.hooked != nil
+}
+
+// Tick runs the tick-based behaviour of a FishingHookBehaviour and returns the
+// Movement within the tick.
+func (f *FishingHookBehaviour) Tick(e *Ent) *Movement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closed || !f.ownerValid(e) {
+		_ = e.Close()
+		return nil
+	}
+	if f.hooked != nil {
+		return f.tickHooked(e)
+	}
+	if f.inWater {
+		return f.tickWater(e)
+	}
+	m := f.projectile.Tick(e)
+	if m != nil && water(e.World(), cube.PosFromVec3(m.pos)) {
+		f.inWater = true
+		f.resetWait()
+
+		e.mu.Lock()
+		e.vel = e.vel.Mul(0.3)
+		e.mu.Unlock()
+	}
+	return m
+}
+
+// Reel reels in the fishing hook. If a fish was biting, loot and experience
+// are given to the owner, and if an entity was hooked, it is pulled towards
+// the owner. Reel returns the damage that should be dealt to the fishing rod.
+func (f *FishingHookBehaviour) Reel(e *Ent) (damage int) {
+	f.mu.Lock()
+	if f.closed {
+		f.mu.Unlock()
+		return 0
+	}
+	f.closed = true
+	hooked, biting, collided := f.hooked, f.biteTicks > 0, f.projectile.collided
+	f.mu.Unlock()
+	defer func() {
+		_ = e.Close()
+	}()
+
+	w, owner := e.World(), f.projectile.Owner()
+	if w == nil || owner == nil {
+		return 0
+	}
+	pos, target := e.Position(), owner.Position()
+	switch {
+	case hooked != nil:
+		if l, ok := hooked.(Living); ok {
+			l.SetVelocity(target.Sub(hooked.Position()).Mul(0.1))
+		}
+		return 5
+	case biting:
+		if t, ok := loot.Lookup(loot.FishingTable); ok {
+			d := target.Sub(pos)
+			vel := mgl64.Vec3{d[0] * 0.1, d[1]*0.1 + math.Sqrt(d.Len())*0.08, d[2] * 0.1}
+			for _, s := range t.Generate(loot.Context{Luck: float64(f.luck()), Tool: f.conf.Rod, Entity: owner}) {
+				w.AddEntity(w.EntityRegistry().Config().Item(s, pos, vel))
+			}
+		}
+		for _, orb := range NewExperienceOrbs(target, rand.Intn(6)+1) {
+			w.AddEntity(orb)
+		}
+		return 1
+	case collided:
+		return 2
+	}
+	return 0
+}
+
+// tickHooked moves the fishing hook along with the entity it hooked. If the
+// entity is no longer valid, the hook is released.
+func (f *FishingHookBehaviour) tickHooked(e *Ent) *Movement {
+	w := e.World()
+	if l, ok := f.hooked.(Living); (ok && l.Dead()) || f.hooked.World() != w {
+		f.hooked = nil
+		return nil
+	}
+	pos := f.hooked.Position().Add(mgl64.Vec3{0, f.hooked.Type().BBox(f.hooked).Height() * 0.8})
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	m := &Movement{v: w.Viewers(pos), e: e, pos: pos, dpos: pos.Sub(e.pos), dvel: e.vel.Mul(-1), rot: e.rot}
+	e.pos, e.vel = pos, mgl64.Vec3{}
+	return m
+}
+
+// tickWater makes the fishing hook bob on the surface of the water it landed
+// in and ticks the timers of fish approaching and biting the hook.
+func (f *FishingHookBehaviour) tickWater(e *Ent) *Movement {
+	w := e.World()
+
+	e.mu.Lock()
+	pos, vel := e.pos, e.vel
+	bpos := cube.PosFromVec3(pos)
+	surface, ok := waterSurface(w, bpos)
+	if !ok {
+		// The hook is no longer in water, for example because the water was
+		// removed, so it continues to fall.
+		e.mu.Unlock()
+		f.inWater, f.approachTicks, f.biteTicks = false, 0, 0
+		return f.projectile.Tick(e)
+	}
+	target := surface - 0.1
+	if f.biteTicks > 0 {
+		// A fish biting the hook pulls it under water.
+		target -= 0.3
+	}
+	vel = mgl64.Vec3{vel[0] * 0.9, vel[1]*0.8 + (target-pos[1])*0.1, vel[2] * 0.9}
+	end := pos.Add(vel)
+	m := &Movement{v: w.Viewers(end), e: e, pos: end, vel: vel, dpos: end.Sub(pos), dvel: vel.Sub(e.vel), rot: e.rot}
+	e.pos, e.vel = end, vel
+	e.mu.Unlock()
+
+	f.tickFishing(e, w, bpos)
+	return m
+}
+
+// tickFishing ticks the timers of fish approaching and biting the fishing
+// hook. Fish bite faster in the rain.
+func (f *FishingHookBehaviour) tickFishing(e *Ent, w *world.World, pos cube.Pos) {
+	switch {
+	case f.biteTicks > 0:
+		if f.biteTicks--; f.biteTicks == 0 {
+			// The fish got away.
+			f.resetWait()
+		}
+	case f.approachTicks > 0:
+		if f.approachTicks%4 == 0 {
+			for _, v := range w.Viewers(e.Position()) {
+				v.ViewEntityAction(e, FishingHookBubbleAction{})
+			}
+		}
+		if f.approachTicks--; f.approachTicks == 0 {
+			f.biteTicks = 20 + rand.Intn(21)
+			w.PlaySound(e.Position(), sound.FishingHookSplash{})
+			for _, v := range w.Viewers(e.Position()) {
+				v.ViewEntityAction(e, FishingHookTeaseAction{})
+			}
+		}
+	default:
+		f.waitTicks--
+		if w.RainingAt(pos) {
+			f.waitTicks--
+		}
+		if f.waitTicks <= 0 {
+			f.approachTicks = 20 + rand.Intn(61)
+		}
+	}
+}
+
+// resetWait resets the time until a fish starts approaching the hook, taking
+// into account the Lure enchantment of the fishing rod.
+func (f *FishingHookBehaviour) resetWait() {
+	f.waitTicks = 100 + rand.Intn(501)
+	if l, ok := f.conf.Rod.Enchantment(enchantment.Lure{}); ok {
+		f.waitTicks -= int((enchantment.Lure{}).WaitTimeReduction(l.Level()) / (time.Second / 20))
+	}
+	if f.waitTicks < 1 {
+		f.waitTicks = 1
+	}
+}
+
+// luck returns the luck of the fishing rod used, based on its Luck of the Sea
+// enchantment.
+func (f *FishingHookBehaviour) luck() int {
+	if l, ok := f.conf.Rod.Enchantment(enchantment.LuckOfTheSea{}); ok {
+		return (enchantment.LuckOfTheSea{}).Luck(l.Level())
+	}
+	return 0
+}
+
+// hit is called when the fishing hook hits a target. Living entities hit are
+// hooked, unless it is the owner of the hook.
+func (f *FishingHookBehaviour) hit(_ *Ent, target trace.Result) {
+	if r, ok := target.(trace.EntityResult); ok && r.Entity() != f.projectile.Owner() {
+		if _, ok := r.Entity().(Living); ok {
+			f.hooked = r.Entity()
+		}
+	}
+}
+
+// ownerValid checks if the owner of the fishing hook is still alive, in the
+// same world, close enough and holding a fishing rod.
+func (f *FishingHookBehaviour) ownerValid(e *Ent) bool {
+	owner := f.projectile.Owner()
+	if owner == nil || owner.World() != e.World() {
+		return false
+	}
+	if l, ok := owner.(Living); ok && l.Dead() {
+		return false
+	}
+	if c, ok := owner.(item.Carrier); ok {
+		if held, _ := c.HeldItems(); held.Empty() {
+			return false
+		} else if _, ok := held.Item().(item.FishingRod); !ok {
+			return false
+		}
+	}
+	return owner.Position().Sub(e.Position()).Len() <= maxFishingDistance
+}
+
+// water checks if the block at the position passed holds water.
+func water(w *world.World, pos cube.Pos) bool {
+	l, ok := w.Liquid(pos)
+	if !ok {
+		return false
+	}
+	_, ok = l.(block.Water)
+	return ok
+}
+
+// waterSurface returns the height of the surface of the water that the position
+// passed is in. If neither the block at the position nor the block below it
+// holds water, false is returned.
+func waterSurface(w *world.World, pos cube.Pos) (float64, bool) {
+	if !water(w, pos) {
+		if pos = pos.Side(cube.FaceDown); !water(w, pos) {
+			return 0, false
+		}
+	}
+	for water(w, pos.Side(cube.FaceUp)) && !pos.Side(cube.FaceUp).OutOfBounds(w.Range()) {
+		pos = pos.Side(cube.FaceUp)
+	}
+	l, _ := w.Liquid(pos)
+	if l.LiquidFalling() {
+		return float64(pos[1]) + 1, true
+	}
+	return float64(pos[1]) + float64(l.LiquidDepth())/9, true
+}
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewMinecart creates a minecart entity. Minecarts ride on rails and may be ridden by a single entity.
+func NewMinecart(pos mgl64.Vec3) *Ent {
+	return Config{Behaviour: minecartConf.New()}.New(MinecartType{}, pos)
+}
+
+// NewChestMinecart creates a minecart entity with a chest in it, which holds an inventory of 27 slots.
+func NewChestMinecart(pos mgl64.Vec3) *Ent {
+	config := minecartConf
+	config.InventorySize, config.Item = 27, item.ChestMinecart{}
+	return Config{Behaviour: config.New()}.New(ChestMinecartType{}, pos)
+}
+
+// NewHopperMinecart creates a minecart entity with a hopper in it, which holds an inventory of 5 slots and
+// collects items above it.
+func NewHopperMinecart(pos mgl64.Vec3) *Ent {
+	config := minecartConf
+	config.InventorySize, config.Item, config.Hopper = 5, item.HopperMinecart{}, true
+	return Config{Behaviour: config.New()}.New(HopperMinecartType{}, pos)
+}
+
+var minecartConf = MinecartBehaviourConfig{
+	Gravity:  0.04,
+	Drag:     0.05,
+	MaxSpeed: 0.4,
+	Item:     item.Minecart{},
+}
+
+// MinecartType is a world.EntityType implementation for minecarts.
+type MinecartType struct{}
+
+func (MinecartType) EncodeEntity() string   { return "minecraft:minecart" }
+func (MinecartType) NetworkOffset() float64 { return 0.35 }
+func (MinecartType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.49, 0, -0.49, 0.49, 0.7, 0.49)
+}
+
+func (MinecartType) DecodeNBT(m map[string]any) world.Entity {
+	return decodeMinecart(NewMinecart(nbtconv.Vec3(m, "Pos")), m)
+}
+
+func (MinecartType) EncodeNBT(e world.Entity) map[string]any {
+	return encodeMinecart(e.(*Ent))
+}
+
+// ChestMinecartType is a world.EntityType implementation for minecarts with a chest.
+type ChestMinecartType struct{}
+
+func (ChestMinecartType) EncodeEntity() string   { return "minecraft:chest_minecart" }
+func (ChestMinecartType) NetworkOffset() float64 { return 0.35 }
+func (ChestMinecartType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.49, 0, -0.49, 0.49, 0.7, 0.49)
+}
+
+func (ChestMinecartType) DecodeNBT(m map[string]any) world.Entity {
+	return decodeMinecart(NewChestMinecart(nbtconv.Vec3(m, "Pos")), m)
+}
+
+func (ChestMinecartType) EncodeNBT(e world.Entity) map[string]any {
+	return encodeMinecart(e.(*Ent))
+}
+
+// HopperMinecartType is a world.EntityType implementation for minecarts with a hopper.
+type HopperMinecartType struct{}
+
+func (HopperMinecartType) EncodeEntity() string   { return "minecraft:hopper_minecart" }
+func (HopperMinecartType) NetworkOffset() float64 { return 0.35 }
+func (HopperMinecartType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.49, 0, -0.49, 0.49, 0.7, 0.49)
+}
+
+func (HopperMinecartType) DecodeNBT(m map[string]any) world.Entity {
+	return decodeMinecart(NewHopperMinecart(nbtconv.Vec3(m, "Pos")), m)
+}
+
+func (HopperMinecartType) EncodeNBT(e world.Entity) map[string]any {
+	return encodeMinecart(e.(*Ent))
+}
+
+// decodeMinecart decodes the motion, rotation and items of a minecart from the map passed.
+func decodeMinecart(e *Ent, m map[string]any) *Ent {
+	e.vel, e.rot = nbtconv.Vec3(m, "Motion"), nbtconv.Rotation(m)
+	if inv := e.Behaviour().(*MinecartBehaviour).Inventory(); inv != nil {
+		nbtconv.InvFromNBT(inv, nbtconv.Slice(m, "Items"))
+	}
+	return e
+}
+
+// encodeMinecart encodes the position, motion, rotation and items of a minecart into a map.
+func encodeMinecart(e *Ent) map[string]any {
+	yaw, pitch := e.Rotation().Elem()
+	m := map[string]any{
+		"Pos":    nbtconv.Vec3ToFloat32Slice(e.Position()),
+		"Motion": nbtconv.Vec3ToFloat32Slice(e.Velocity()),
+		"Yaw":    float32(yaw),
+		"Pitch":  float32(pitch),
+	}
+	if inv := e.Behaviour().(*MinecartBehaviour).Inventory(); inv != nil {
+		m["Items"] = nbtconv.InvToNBT(inv)
+	}
+	return m
+}
+
+// railAt returns the rail that an entity at the position passed is riding on. The rail is either at the
+// position or directly below it, in which case the entity is at the top of an ascending rail.
+func railAt(w *world.World, pos mgl64.Vec3) (block.RailBlock, cube.Pos, bool) {
+	bpos := cube.PosFromVec3(pos)
+	if r, ok := w.Block(bpos).(block.RailBlock); ok {
+		return r, bpos, true
+	}
+	bpos = bpos.Side(cube.FaceDown)
+	r, ok := w.Block(bpos).(block.RailBlock)
+	return r, bpos, ok
+}
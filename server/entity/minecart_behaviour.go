@@ -0,0 +1,282 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"sync"
+	"time"
+)
+
+// Container represents the Behaviour of an entity that holds an inventory which may be opened, such as a chest
+// minecart.
+type Container interface {
+	// Inventory returns the inventory of the entity.
+	Inventory() *inventory.Inventory
+	// AddViewer adds a viewer to the container, so that it is updated whenever the inventory of the container
+	// changes.
+	AddViewer(v block.ContainerViewer)
+	// RemoveViewer removes a viewer from the container, so that slot updates in the inventory are no longer
+	// sent to it.
+	RemoveViewer(v block.ContainerViewer)
+}
+
+// MinecartBehaviourConfig holds optional parameters for a MinecartBehaviour.
+type MinecartBehaviourConfig struct {
+	// Gravity is the amount of Y velocity subtracted every tick while the minecart is not on rails.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick while the minecart is not on rails. Velocity
+	// is multiplied with (1-Drag) every tick.
+	Drag float64
+	// MaxSpeed is the maximum speed of the minecart on rails in blocks/tick.
+	MaxSpeed float64
+	// InventorySize is the size of the inventory of the minecart. Minecarts with an InventorySize of 0 have no
+	// inventory and may be ridden instead.
+	InventorySize int
+	// Hopper specifies if the minecart collects items above it into its inventory, like a hopper.
+	Hopper bool
+	// Item is the item dropped when the minecart is broken.
+	Item world.Item
+}
+
+// New creates a MinecartBehaviour using the parameters in conf.
+func (conf MinecartBehaviourConfig) New() *MinecartBehaviour {
+	seats := 0
+	if conf.InventorySize == 0 {
+		seats = 1
+	}
+	m := &MinecartBehaviour{conf: conf, mc: &MovementComputer{Gravity: conf.Gravity, Drag: conf.Drag, DragBeforeGravity: true}}
+	m.vehicle = vehicle{passengers: passengers{seats: seats}, hurtDirection: 1, drops: m.drops}
+	if conf.InventorySize > 0 {
+		m.viewers = map[block.ContainerViewer]struct{}{}
+		m.inv = inventory.New(conf.InventorySize, func(slot int, _, after item.Stack) {
+			m.viewerMu.RLock()
+			defer m.viewerMu.RUnlock()
+			for v := range m.viewers {
+				v.ViewSlotChange(slot, after)
+			}
+		})
+	}
+	return m
+}
+
+// MinecartBehaviour implements the behaviour of minecarts. Minecarts follow the rails they are on, are
+// accelerated by powered rails and power detector rails that they ride over. Minecarts without an inventory may
+// be ridden, while minecarts with an inventory may be opened as a Container.
+type MinecartBehaviour struct {
+	vehicle
+	conf MinecartBehaviourConfig
+	mc   *MovementComputer
+
+	inv      *inventory.Inventory
+	viewerMu sync.RWMutex
+	viewers  map[block.ContainerViewer]struct{}
+}
+
+// Inventory returns the inventory of the minecart. Nil is returned if the minecart has no inventory.
+func (m *MinecartBehaviour) Inventory() *inventory.Inventory {
+	return m.inv
+}
+
+// Hopper checks if the minecart collects items into its inventory, like a hopper.
+func (m *MinecartBehaviour) Hopper() bool {
+	return m.conf.Hopper
+}
+
+// AddViewer ...
+func (m *MinecartBehaviour) AddViewer(v block.ContainerViewer) {
+	m.viewerMu.Lock()
+	defer m.viewerMu.Unlock()
+	if m.viewers != nil {
+		m.viewers[v] = struct{}{}
+	}
+}
+
+// RemoveViewer ...
+func (m *MinecartBehaviour) RemoveViewer(v block.ContainerViewer) {
+	m.viewerMu.Lock()
+	defer m.viewerMu.Unlock()
+	delete(m.viewers, v)
+}
+
+// SeatPosition ...
+func (m *MinecartBehaviour) SeatPosition(passenger world.Entity) (mgl64.Vec3, bool) {
+	if i, _ := m.seat(passenger); i == -1 {
+		return mgl64.Vec3{}, false
+	}
+	return mgl64.Vec3{0, -0.35}, true
+}
+
+// Tick moves the minecart along the rails it is on, or makes it fall if it is not on rails.
+func (m *MinecartBehaviour) Tick(e *Ent) *Movement {
+	m.tickVehicle(e)
+	w := e.World()
+	m.push(e, w)
+
+	e.mu.Lock()
+	pos, vel := e.pos, e.vel
+	rail, rpos, onRail := railAt(w, pos)
+	var mv *Movement
+	if onRail {
+		mv = m.tickRail(e, w, rail, rpos, pos, vel)
+	} else {
+		mv = m.mc.TickMovement(e, pos, vel, e.rot)
+	}
+	e.pos, e.vel, e.rot = mv.pos, mv.vel, mv.rot
+	e.mu.Unlock()
+
+	if d, ok := rail.(block.DetectorRail); ok {
+		d.Detect(rpos, w)
+	}
+	if m.conf.Hopper && e.Age()%(time.Second/5) == 0 {
+		m.collect(e, w)
+	}
+	return mv
+}
+
+// tickRail moves the minecart along the rail passed, changing its velocity depending on the type of rail.
+func (m *MinecartBehaviour) tickRail(e *Ent, w *world.World, rail block.RailBlock, rpos cube.Pos, pos, vel mgl64.Vec3) *Movement {
+	shape := rail.RailShape()
+	conn := shape.Connections()
+	centre := rpos.Vec3Middle()
+	a, b := centre.Add(directionVec(conn[0]).Mul(0.5)), centre.Add(directionVec(conn[1]).Mul(0.5))
+	track := b.Sub(a).Normalize()
+
+	asc, ascending := shape.Ascending()
+	if ascending {
+		// Minecarts on slopes accelerate downwards.
+		vel = vel.Sub(directionVec(asc).Mul(0.0078125))
+	}
+	horizontal := mgl64.Vec3{vel[0], 0, vel[2]}
+	speed := math.Min(horizontal.Len(), m.conf.MaxSpeed)
+	if horizontal.Dot(track) < 0 {
+		track = track.Mul(-1)
+	}
+	switch r := rail.(type) {
+	case block.PoweredRail:
+		switch {
+		case !r.Powered && speed < 0.03:
+			speed = 0
+		case !r.Powered:
+			speed *= 0.5
+		case speed > 0.01:
+			speed = math.Min(speed+0.06, m.conf.MaxSpeed)
+		default:
+			// A powered rail starts moving a stationary minecart if it is placed against a solid block.
+			for i, d := range conn {
+				side := rpos.Side(d.Face())
+				if w.Block(side).Model().FaceSolid(side, d.Face().Opposite(), w) {
+					track, speed = directionVec(conn[1-i]), 0.02
+				}
+			}
+		}
+	}
+	if _, ok := Driver(e); ok {
+		speed *= 0.997
+	} else {
+		speed *= 0.96
+	}
+	vel = track.Mul(speed)
+
+	// Put the minecart on the line between the two ends of the rail before moving it along the rail.
+	line := b.Sub(a)
+	t := mgl64.Vec3{pos[0] - a[0], 0, pos[2] - a[2]}.Dot(line) / line.Dot(line)
+	start := a.Add(line.Mul(t))
+	end := start.Add(vel)
+	if next := cube.PosFromVec3(mgl64.Vec3{end[0], pos[1], end[2]}); next != rpos {
+		if _, ok := w.Block(next).(block.RailBlock); !ok && len(w.Block(next).Model().BBox(next, w)) > 0 {
+			end, vel = start, mgl64.Vec3{}
+		}
+	}
+	end[1] = float64(rpos[1]) + 0.0625
+	if ascending {
+		d := directionVec(asc)
+		progress := end.Sub(centre.Sub(d.Mul(0.5))).Dot(d)
+		end[1] += math.Max(0, math.Min(progress, 1))
+	}
+	rot := e.rot
+	if speed > 0.001 {
+		rot = cube.Rotation{mgl64.RadToDeg(math.Atan2(-vel[0], vel[2])), 0}
+	}
+	return &Movement{v: w.Viewers(end), e: e, pos: end, vel: vel, dpos: end.Sub(pos), dvel: vel.Sub(e.vel), rot: rot, onGround: true}
+}
+
+// push pushes the minecart away from entities that are not riding it and walk into it.
+func (m *MinecartBehaviour) push(e *Ent, w *world.World) {
+	pos := e.Position()
+	box := e.Type().BBox(e).Translate(pos).Grow(0.2)
+	for _, other := range w.EntitiesWithin(box, func(other world.Entity) bool { return other == e }) {
+		if _, ok := other.(Living); !ok || !other.Type().BBox(other).Translate(other.Position()).IntersectsWith(box) {
+			continue
+		}
+		if r, ok := other.(Rider); ok {
+			if v, ok := r.Riding(); ok && v == e {
+				continue
+			}
+		}
+		diff := pos.Sub(other.Position())
+		diff[1] = 0
+		if diff.Len() < 0.01 {
+			continue
+		}
+		e.SetVelocity(e.Velocity().Add(diff.Normalize().Mul(0.05)))
+	}
+}
+
+// collect collects item entities above the hopper minecart into its inventory, and pulls a single item from a
+// container block directly above it.
+func (m *MinecartBehaviour) collect(e *Ent, w *world.World) {
+	pos := e.Position()
+	box := cube.Box(-0.5, 0, -0.5, 0.5, 1.5, 0.5).Translate(pos)
+	for _, other := range w.EntitiesWithin(box, nil) {
+		ent, ok := other.(*Ent)
+		if !ok {
+			continue
+		}
+		i, ok := ent.Behaviour().(*ItemBehaviour)
+		if !ok || i.pickupDelay > 0 {
+			continue
+		}
+		n, _ := m.inv.AddItem(i.Item())
+		if n == 0 {
+			continue
+		}
+		if n < i.Item().Count() {
+			w.AddEntity(NewItem(i.Item().Grow(-n), ent.Position()))
+		}
+		_ = ent.Close()
+	}
+
+	above := cube.PosFromVec3(pos).Side(cube.FaceUp)
+	c, ok := w.Block(above).(block.Container)
+	if !ok {
+		return
+	}
+	for slot, it := range c.Inventory().Slots() {
+		if it.Empty() {
+			continue
+		}
+		if n, _ := m.inv.AddItem(it.Grow(1 - it.Count())); n == 1 {
+			_ = c.Inventory().SetItem(slot, it.Grow(-1))
+			return
+		}
+	}
+}
+
+// drops returns the minecart item and the contents of its inventory, if any.
+func (m *MinecartBehaviour) drops(*Ent) []item.Stack {
+	drops := []item.Stack{item.NewStack(m.conf.Item, 1)}
+	if m.inv != nil {
+		drops = append(drops, m.inv.Clear()...)
+	}
+	return drops
+}
+
+// directionVec returns a horizontal unit vector pointing in the direction passed.
+func directionVec(d cube.Direction) mgl64.Vec3 {
+	return cube.Pos{}.Side(d.Face()).Vec3()
+}
@@ -69,6 +69,7 @@ func (c *MovementComputer) TickMovement(e world.Entity, pos, vel mgl64.Vec3, rot
 	velBefore := vel
 	vel = c.applyHorizontalForces(w, pos, c.applyVerticalForces(vel))
 	dPos, vel := c.checkCollision(e, pos, vel)
+	dPos, vel = c.checkBorder(w, pos, dPos, vel)
 
 	return &Movement{v: viewers, e: e,
 		pos: pos.Add(dPos), vel: vel, dpos: dPos, dvel: vel.Sub(velBefore),
@@ -169,6 +170,23 @@ func (c *MovementComputer) checkCollision(e world.Entity, pos, vel mgl64.Vec3) (
 	return mgl64.Vec3{deltaX, deltaY, deltaZ}, vel
 }
 
+// checkBorder prevents an entity from moving through the world.Border of the world.World it is in. If the movement
+// passed would move the entity from inside the world.Border to outside of it, the movement and velocity on the axes
+// crossing the world.Border are cancelled.
+func (c *MovementComputer) checkBorder(w *world.World, pos, dPos, vel mgl64.Vec3) (mgl64.Vec3, mgl64.Vec3) {
+	b, end := w.Border(), pos.Add(dPos)
+	if !b.Contains(pos) || b.Contains(end) {
+		return dPos, vel
+	}
+	clamped := b.Clamp(end).Sub(pos)
+	for _, axis := range [...]int{0, 2} {
+		if !mgl64.FloatEqual(clamped[axis], dPos[axis]) {
+			vel[axis] = 0
+		}
+	}
+	return clamped, vel
+}
+
 // blockBBoxsAround returns all blocks around the entity passed, using the BBox passed to make a prediction of
 // what blocks need to have their BBox returned.
 func blockBBoxsAround(e world.Entity, box cube.BBox) []cube.BBox {
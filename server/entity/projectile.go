@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
@@ -66,6 +67,15 @@ type ProjectileBehaviourConfig struct {
 	// multiplier such as 0.5 will reduce the projectile's velocity, but retain
 	// half of it after inverting the axis on which the projectile collided.
 	BlockCollisionVelocityMultiplier float64
+	// SurviveEntityCollision specifies if a projectile with this
+	// ProjectileBehaviour should survive collision with an entity. If set to
+	// true, the projectile bounces off the first entity it hits, after which it
+	// no longer collides with entities, like a trident does.
+	SurviveEntityCollision bool
+	// PiercedEntities is the amount of entities that the projectile passes
+	// through before it collides with an entity. Entities that were pierced are
+	// hurt, but do not stop the projectile.
+	PiercedEntities int
 	// DisablePickup specifies if picking up the projectile should be disabled,
 	// which is relevant in the case SurviveBlockCollision is set to true. Some
 	// projectiles, such as arrows, cannot be picked up if they are shot by
@@ -100,6 +110,9 @@ type ProjectileBehaviour struct {
 
 	collisionPos cube.Pos
 	collided     bool
+
+	pierced        []world.Entity
+	entityCollided bool
 }
 
 // Owner returns the owner of the projectile.
@@ -167,6 +180,26 @@ func (lt *ProjectileBehaviour) Tick(e *Ent) *Movement {
 		if l, ok := r.Entity().(Living); ok && lt.conf.Damage >= 0 {
 			lt.hitEntity(l, e, before, vel)
 		}
+		if len(lt.pierced) < lt.conf.PiercedEntities {
+			// The projectile pierces the entity, so it continues moving with
+			// its old velocity and no longer collides with this entity.
+			lt.pierced = append(lt.pierced, r.Entity())
+			lt.hit(e, result)
+
+			e.mu.Lock()
+			e.vel = vel
+			e.mu.Unlock()
+			return m
+		}
+		if lt.conf.SurviveEntityCollision {
+			lt.entityCollided = true
+			lt.hit(e, result)
+
+			e.mu.Lock()
+			e.vel = mgl64.Vec3{vel[0] * -0.01, vel[1] * -0.1, vel[2] * -0.01}
+			e.mu.Unlock()
+			return m
+		}
 	case trace.BlockResult:
 		bpos := r.BlockPosition()
 		if t, ok := w.Block(bpos).(block.TNT); ok && e.OnFireDuration() > 0 {
@@ -174,17 +207,24 @@ func (lt *ProjectileBehaviour) Tick(e *Ent) *Movement {
 		}
 		if lt.conf.SurviveBlockCollision {
 			lt.hitBlockSurviving(e, r, m)
+			lt.hit(e, result)
 			return m
 		}
 	}
-	if lt.conf.Hit != nil {
-		lt.conf.Hit(e, result)
-	}
+	lt.hit(e, result)
 
 	lt.close = true
 	return m
 }
 
+// hit calls ProjectileBehaviourConfig.Hit with the target passed if it is not
+// nil.
+func (lt *ProjectileBehaviour) hit(e *Ent, target trace.Result) {
+	if lt.conf.Hit != nil {
+		lt.conf.Hit(e, target)
+	}
+}
+
 // tickAttached performs the attached logic for a projectile. It checks if the
 // projectile is still attached to a block and if it can be picked up.
 func (lt *ProjectileBehaviour) tickAttached(e *Ent) bool {
@@ -321,12 +361,13 @@ func (lt *ProjectileBehaviour) tickMovement(e *Ent) (*Movement, trace.Result) {
 }
 
 // ignores returns a function to ignore entities in trace.Perform that are
-// either a spectator, not living, the entity itself or its owner in the first
-// 5 ticks.
+// either a spectator, not living, the entity itself, its owner in the first
+// 5 ticks or an entity that was already pierced. If the projectile survived
+// an entity collision, all entities are ignored.
 func (lt *ProjectileBehaviour) ignores(e *Ent) func(other world.Entity) bool {
 	return func(other world.Entity) (ignored bool) {
 		g, ok := other.(interface{ GameMode() world.GameMode })
 		_, living := other.(Living)
-		return (ok && !g.GameMode().HasCollision()) || e == other || !living || (e.age < time.Second/4 && lt.owner == other)
+		return (ok && !g.GameMode().HasCollision()) || e == other || !living || (e.age < time.Second/4 && lt.owner == other) || lt.entityCollided || sliceutil.Index(lt.pierced, other) != -1
 	}
 }
@@ -15,19 +15,26 @@ import (
 var DefaultRegistry = conf.New([]world.EntityType{
 	AreaEffectCloudType{},
 	ArrowType{},
+	BoatType{},
 	BottleOfEnchantingType{},
+	ChestMinecartType{},
 	EggType{},
 	EnderPearlType{},
 	ExperienceOrbType{},
 	FallingBlockType{},
 	FireworkType{},
+	FishingHookType{},
+	HopperMinecartType{},
 	ItemType{},
 	LightningType{},
 	LingeringPotionType{},
+	MinecartType{},
 	SnowballType{},
 	SplashPotionType{},
 	TNTType{},
 	TextType{},
+	TridentType{},
+	VillagerType{},
 })
 
 var conf = world.EntityRegistryConfig{
@@ -47,10 +54,11 @@ var conf = world.EntityRegistryConfig{
 		b.vel = vel
 		return b
 	},
-	Arrow: func(pos, vel mgl64.Vec3, rot cube.Rotation, damage float64, owner world.Entity, critical, disallowPickup, obtainArrowOnPickup bool, punchLevel int, tip any) world.Entity {
+	Arrow: func(pos, vel mgl64.Vec3, rot cube.Rotation, damage float64, owner world.Entity, critical, disallowPickup, obtainArrowOnPickup bool, punchLevel, piercingLevel int, tip any) world.Entity {
 		a := NewTippedArrowWithDamage(pos, rot, damage, owner, tip.(potion.Potion))
 		b := a.conf.Behaviour.(*ProjectileBehaviour)
 		b.conf.KnockBackForceAddend = float64(punchLevel) * (enchantment.Punch{}).KnockBackMultiplier()
+		b.conf.PiercedEntities = (enchantment.Piercing{}).PiercedEntities(piercingLevel)
 		b.conf.DisablePickup = disallowPickup
 		if obtainArrowOnPickup {
 			b.conf.PickupItem = item.NewStack(item.Arrow{Tip: tip.(potion.Potion)}, 1)
@@ -69,8 +77,12 @@ var conf = world.EntityRegistryConfig{
 		e.vel = vel
 		return e
 	},
-	Firework: func(pos mgl64.Vec3, rot cube.Rotation, attached bool, firework world.Item, owner world.Entity) world.Entity {
-		return NewFireworkAttached(pos, rot, firework.(item.Firework), owner, attached)
+	Firework: func(pos, vel mgl64.Vec3, rot cube.Rotation, attached bool, firework world.Item, owner world.Entity, sidewaysVelocityMultiplier, upwardsAcceleration float64) world.Entity {
+		f := NewFireworkAttached(pos, rot, firework.(item.Firework), owner, attached)
+		b := f.conf.Behaviour.(*FireworkBehaviour)
+		b.conf.SidewaysVelocityMultiplier, b.conf.UpwardsAcceleration = sidewaysVelocityMultiplier, upwardsAcceleration
+		f.vel = vel
+		return f
 	},
 	LingeringPotion: func(pos, vel mgl64.Vec3, t any, owner world.Entity) world.Entity {
 		p := NewLingeringPotion(pos, owner, t.(potion.Potion))
@@ -90,4 +102,28 @@ var conf = world.EntityRegistryConfig{
 	Lightning: func(pos mgl64.Vec3) world.Entity {
 		return NewLightning(pos)
 	},
+	Trident: func(pos, vel mgl64.Vec3, rot cube.Rotation, trident any, owner world.Entity, obtainTridentOnPickup bool) world.Entity {
+		t := NewTrident(pos, rot, owner, trident.(item.Stack))
+		if !obtainTridentOnPickup {
+			t.conf.Behaviour.(*TridentBehaviour).projectile.conf.PickupItem = item.Stack{}
+		}
+		t.vel = vel
+		return t
+	},
+	Boat: func(pos mgl64.Vec3, yaw float64, boatType any) world.Entity {
+		t, ok := boatType.(item.BoatType)
+		if !ok {
+			t = item.OakBoat()
+		}
+		return NewBoat(pos, yaw, t)
+	},
+	Minecart: func(pos mgl64.Vec3) world.Entity {
+		return NewMinecart(pos)
+	},
+	ChestMinecart: func(pos mgl64.Vec3) world.Entity {
+		return NewChestMinecart(pos)
+	},
+	HopperMinecart: func(pos mgl64.Vec3) world.Entity {
+		return NewHopperMinecart(pos)
+	},
 }
@@ -0,0 +1,120 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"sync"
+)
+
+// Rideable represents the Behaviour of an entity that other entities are able to ride, such as a boat or a
+// minecart.
+type Rideable interface {
+	// Passengers returns the entities currently riding the entity, ordered by the seat they occupy. The
+	// passenger in the first seat, if any, is the driver of the entity.
+	Passengers() []world.Entity
+	// AddPassenger seats the entity passed in the first free seat of the Rideable entity. False is returned if
+	// no seat was free.
+	AddPassenger(passenger world.Entity) bool
+	// RemovePassenger removes a passenger from the seat it occupies. Passengers in seats after it move up a seat.
+	RemovePassenger(passenger world.Entity)
+	// SeatPosition returns the position of the seat of the passenger passed, relative to the position of the
+	// Rideable entity. False is returned if the entity passed is not a passenger.
+	SeatPosition(passenger world.Entity) (mgl64.Vec3, bool)
+}
+
+// Rider represents an entity that is able to ride Rideable entities, such as a player.
+type Rider interface {
+	world.Entity
+	// Riding returns the entity that the Rider is currently riding. False is returned if it is not riding any
+	// entity.
+	Riding() (world.Entity, bool)
+	// Dismount makes the Rider stop riding the entity it is currently riding, if any.
+	Dismount()
+}
+
+// RideableOf returns the Rideable Behaviour of the entity passed. False is returned if the entity cannot be
+// ridden.
+func RideableOf(e world.Entity) (Rideable, bool) {
+	if ent, ok := e.(*Ent); ok {
+		r, ok := ent.Behaviour().(Rideable)
+		return r, ok
+	}
+	r, ok := e.(Rideable)
+	return r, ok
+}
+
+// Driver returns the passenger in the first seat of the entity passed, which controls its movement. False is
+// returned if the entity cannot be ridden or has no passengers.
+func Driver(e world.Entity) (world.Entity, bool) {
+	if r, ok := RideableOf(e); ok {
+		if p := r.Passengers(); len(p) > 0 {
+			return p[0], true
+		}
+	}
+	return nil, false
+}
+
+// passengers implements the management of the passengers of a Rideable entity. It is embedded in behaviours
+// that may be ridden.
+type passengers struct {
+	mu    sync.Mutex
+	seats int
+	p     []world.Entity
+}
+
+// Passengers ...
+func (p *passengers) Passengers() []world.Entity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]world.Entity(nil), p.p...)
+}
+
+// AddPassenger ...
+func (p *passengers) AddPassenger(passenger world.Entity) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.p) >= p.seats || p.index(passenger) != -1 {
+		return false
+	}
+	p.p = append(p.p, passenger)
+	return true
+}
+
+// RemovePassenger ...
+func (p *passengers) RemovePassenger(passenger world.Entity) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := p.index(passenger); i != -1 {
+		p.p = append(p.p[:i], p.p[i+1:]...)
+	}
+}
+
+// seat returns the index of the seat of the passenger passed and the total amount of passengers. -1 is
+// returned if the entity passed is not a passenger.
+func (p *passengers) seat(passenger world.Entity) (index, n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.index(passenger), len(p.p)
+}
+
+// index returns the index of the passenger passed, or -1 if it is not a passenger. index does not lock the
+// mutex of the passengers.
+func (p *passengers) index(passenger world.Entity) int {
+	for i, e := range p.p {
+		if e == passenger {
+			return i
+		}
+	}
+	return -1
+}
+
+// dismountAll makes all passengers that are Riders dismount the entity.
+func (p *passengers) dismountAll() {
+	for _, e := range p.Passengers() {
+		if r, ok := e.(Rider); ok {
+			r.Dismount()
+			continue
+		}
+		p.RemovePassenger(e)
+	}
+}
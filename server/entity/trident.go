@@ -0,0 +1,69 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewTrident creates a thrown trident entity from the trident item stack
+// passed. The enchantments of the trident influence its behaviour once thrown.
+// The trident may be picked up once it has hit a target.
+func NewTrident(pos mgl64.Vec3, rot cube.Rotation, owner world.Entity, trident item.Stack) *Ent {
+	conf := tridentConf
+	conf.Item = trident
+	t := Config{Behaviour: conf.New(owner)}.New(TridentType{}, pos)
+	t.rot = rot
+	return t
+}
+
+var tridentConf = TridentBehaviourConfig{
+	Gravity: 0.05,
+	Drag:    0.01,
+	Damage:  8,
+}
+
+// TridentType is a world.EntityType implementation for Trident.
+type TridentType struct{}
+
+func (TridentType) EncodeEntity() string { return "minecraft:thrown_trident" }
+func (TridentType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.125, 0, -0.125, 0.125, 0.25, 0.125)
+}
+
+func (TridentType) DecodeNBT(m map[string]any) world.Entity {
+	t := NewTrident(nbtconv.Vec3(m, "Pos"), nbtconv.Rotation(m), nil, nbtconv.MapItem(m, "Trident"))
+	b := t.conf.Behaviour.(*TridentBehaviour)
+	t.vel = nbtconv.Vec3(m, "Motion")
+	if nbtconv.Bool(m, "isCreative") {
+		b.projectile.conf.PickupItem = item.Stack{}
+	}
+	if _, ok := m["StuckToBlockPos"]; ok {
+		b.projectile.collisionPos = nbtconv.Pos(m, "StuckToBlockPos")
+		b.projectile.collided = true
+	}
+	b.projectile.entityCollided = nbtconv.Bool(m, "DealtDamage")
+	return t
+}
+
+func (TridentType) EncodeNBT(e world.Entity) map[string]any {
+	t := e.(*Ent)
+	b := t.conf.Behaviour.(*TridentBehaviour)
+	yaw, pitch := t.Rotation().Elem()
+	data := map[string]any{
+		"Pos":         nbtconv.Vec3ToFloat32Slice(t.Position()),
+		"Yaw":         float32(yaw),
+		"Pitch":       float32(pitch),
+		"Motion":      nbtconv.Vec3ToFloat32Slice(t.Velocity()),
+		"Trident":     nbtconv.WriteItem(b.conf.Item, true),
+		"DealtDamage": boolByte(b.projectile.entityCollided),
+		"player":      uint8(1),
+		"isCreative":  boolByte(b.projectile.conf.PickupItem.Empty()),
+	}
+	if b.projectile.collided {
+		data["StuckToBlockPos"] = nbtconv.PosToInt32Slice(b.projectile.collisionPos)
+	}
+	return data
+}
@@ -0,0 +1,217 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+)
+
+// TridentBehaviourConfig holds optional parameters for a TridentBehaviour.
+type TridentBehaviourConfig struct {
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is
+	// multiplied with (1-Drag) every tick.
+	Drag float64
+	// Damage is the base damage dealt to an entity hit by the trident. The
+	// Impaling enchantment adds to this damage for entities that are wet.
+	Damage float64
+	// Item is the trident item stack that was thrown. The enchantments of the
+	// stack influence the behaviour of the trident, and it is given back to the
+	// entity that picks the trident up.
+	Item item.Stack
+}
+
+// New creates a TridentBehaviour using the parameters in conf. The owner
+// passed may be nil if the trident does not have one.
+func (conf TridentBehaviourConfig) New(owner world.Entity) *TridentBehaviour {
+	t := &TridentBehaviour{conf: conf}
+	t.projectile = ProjectileBehaviourConfig{
+		Gravity:                conf.Gravity,
+		Drag:                   conf.Drag,
+		Damage:                 -1,
+		Hit:                    t.hit,
+		SurviveBlockCollision:  true,
+		SurviveEntityCollision: true,
+		PickupItem:             conf.Item,
+	}.New(owner)
+	return t
+}
+
+// TridentBehaviour implements the behaviour of a thrown trident. It is built
+// on ProjectileBehaviour, adding the Loyalty, Channeling and Impaling
+// enchantments of the trident thrown.
+type TridentBehaviour struct {
+	conf       TridentBehaviourConfig
+	projectile *ProjectileBehaviour
+
+	returning bool
+}
+
+// Item returns the trident item stack that was thrown.
+func (t *TridentBehaviour) Item() item.Stack {
+	return t.conf.Item
+}
+
+// Owner returns the owner of the trident.
+func (t *TridentBehaviour) Owner() world.Entity {
+	return t.projectile.Owner()
+}
+
+// Returning returns true if the trident is returning to its owner as a result
+// of the Loyalty enchantment.
+func (t *TridentBehaviour) Returning() bool {
+	return t.returning
+}
+
+// Explode adds velocity to a trident to blast it away from the explosion's
+// source.
+func (t *TridentBehaviour) Explode(e *Ent, src mgl64.Vec3, impact float64, conf block.ExplosionConfig) {
+	if !t.returning {
+		t.projectile.Explode(e, src, impact, conf)
+	}
+}
+
+// Tick runs the tick-based behaviour of a TridentBehaviour and returns the
+// Movement within the tick. Once a trident enchanted with Loyalty has hit its
+// target, it starts returning to its owner.
+func (t *TridentBehaviour) Tick(e *Ent) *Movement {
+	if t.returning && !t.projectile.close {
+		return t.tickReturning(e)
+	}
+	m := t.projectile.Tick(e)
+	if t.projectile.close || t.loyalty() == 0 || !t.ownerValid(e) {
+		return m
+	}
+	if t.projectile.entityCollided || (t.projectile.collided && t.projectile.ageCollided > 4) || e.Position()[1] < float64(e.World().Range()[0]) {
+		t.returning = true
+		e.World().PlaySound(e.Position(), sound.TridentReturn{})
+		for _, v := range e.World().Viewers(e.Position()) {
+			v.ViewEntityState(e)
+		}
+	}
+	return m
+}
+
+// tickReturning moves the trident towards its owner, ignoring any blocks in
+// the way, until it reaches the owner and is collected.
+func (t *TridentBehaviour) tickReturning(e *Ent) *Movement {
+	w, owner := e.World(), t.projectile.owner
+	if !t.ownerValid(e) {
+		// The owner is no longer around, so the trident will just drop down.
+		t.returning, t.projectile.collided, t.projectile.entityCollided = false, false, true
+		for _, v := range w.Viewers(e.Position()) {
+			v.ViewEntityState(e)
+		}
+		return t.projectile.Tick(e)
+	}
+	level := float64(t.loyalty())
+
+	e.mu.Lock()
+	pos, vel := e.pos, e.vel
+	dir := EyePosition(owner).Sub(pos)
+	pos[1] += dir[1] * 0.015 * level
+	vel = vel.Mul(0.95)
+	if dir.Len() > 1e-4 {
+		// Normalising a zero vector results in NaN, so the trident is only pulled towards its owner if it is not
+		// already at its eye position.
+		vel = vel.Add(dir.Normalize().Mul((enchantment.Loyalty{}).ReturnSpeed(t.loyalty())))
+	}
+	end := pos.Add(vel)
+	rot := cube.Rotation{
+		mgl64.RadToDeg(math.Atan2(vel[0], vel[2])),
+		mgl64.RadToDeg(math.Atan2(vel[1], math.Hypot(vel[0], vel[2]))),
+	}
+	m := &Movement{v: w.Viewers(end), e: e, pos: end, vel: vel, dpos: end.Sub(e.pos), dvel: vel.Sub(e.vel), rot: rot}
+	e.pos, e.vel = end, vel
+	e.mu.Unlock()
+
+	box := e.Type().BBox(e).Translate(end)
+	if !owner.Type().BBox(owner).Translate(owner.Position()).Grow(1).IntersectsWith(box) {
+		return m
+	}
+	collector, ok := owner.(Collector)
+	if !ok {
+		return m
+	}
+	if pickup := t.projectile.conf.PickupItem; !pickup.Empty() && collector.Collect(pickup) == 0 {
+		// The owner could not collect the trident, so it keeps hovering near
+		// the owner.
+		return m
+	}
+	for _, viewer := range w.Viewers(end) {
+		viewer.ViewEntityAction(e, PickedUpAction{Collector: collector})
+	}
+	t.projectile.close = true
+	return m
+}
+
+// hit is called when the trident hits a target. It hurts entities hit,
+// summons lightning if enchanted with Channeling and plays the appropriate
+// sounds.
+func (t *TridentBehaviour) hit(e *Ent, target trace.Result) {
+	w := e.World()
+	r, ok := target.(trace.EntityResult)
+	if !ok {
+		w.PlaySound(target.Position(), sound.TridentHitGround{})
+		return
+	}
+	l, ok := r.Entity().(Living)
+	if !ok {
+		return
+	}
+	dmg := t.conf.Damage
+	if i, ok := t.conf.Item.Enchantment(enchantment.Impaling{}); ok && Wet(l) {
+		dmg += (enchantment.Impaling{}).Addend(i.Level())
+	}
+	if _, vulnerable := l.Hurt(dmg, ProjectileDamageSource{Projectile: e, Owner: t.projectile.owner}); vulnerable {
+		l.KnockBack(e.Position(), 0.45, 0.3608)
+	}
+	w.PlaySound(target.Position(), sound.TridentHit{})
+
+	pos := l.Position()
+	if _, ok := t.conf.Item.Enchantment(enchantment.Channeling{}); ok && w.ThunderingAt(cube.PosFromVec3(pos)) {
+		w.AddEntity(NewLightning(pos))
+		w.PlaySound(pos, sound.TridentThunder{})
+	}
+}
+
+// loyalty returns the level of the Loyalty enchantment of the trident, or 0
+// if it does not have the enchantment.
+func (t *TridentBehaviour) loyalty() int {
+	if l, ok := t.conf.Item.Enchantment(enchantment.Loyalty{}); ok {
+		return l.Level()
+	}
+	return 0
+}
+
+// ownerValid checks if the owner of the trident is still alive and in the same
+// world as the trident, so that the trident can return to it.
+func (t *TridentBehaviour) ownerValid(e *Ent) bool {
+	owner := t.projectile.owner
+	if owner == nil || owner.World() != e.World() {
+		return false
+	}
+	if l, ok := owner.(Living); ok && l.Dead() {
+		return false
+	}
+	return true
+}
+
+// Wet checks if the entity passed is in water or standing in the rain. Wet
+// entities take additional damage from tridents enchanted with Impaling.
+func Wet(e world.Entity) bool {
+	w, pos := e.World(), cube.PosFromVec3(e.Position())
+	if l, ok := w.Liquid(pos); ok {
+		if _, ok := l.(block.Water); ok {
+			return true
+		}
+	}
+	return w.RainingAt(pos)
+}
@@ -0,0 +1,124 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math/rand"
+	"sync"
+)
+
+// Vehicle represents the Behaviour of a vehicle entity, such as a boat or a minecart. Vehicles may be ridden by
+// other entities and break after taking enough damage in a short time.
+type Vehicle interface {
+	Rideable
+	// Attack deals damage to the vehicle entity passed. The vehicle breaks if it takes too much damage in a short
+	// time, or immediately if attacked by an entity in a game mode with a creative inventory.
+	Attack(e *Ent, damage float64, src world.DamageSource)
+}
+
+// Drivable represents a Vehicle of which the movement is controlled by the driver riding it, such as a boat.
+type Drivable interface {
+	Vehicle
+	// Drive moves the vehicle entity to the position and rotation passed, as a result of it being driven by its
+	// driver.
+	Drive(e *Ent, pos mgl64.Vec3, rot cube.Rotation)
+}
+
+// vehicle implements the behaviour shared by all vehicles: Managing passengers and breaking after taking
+// damage.
+type vehicle struct {
+	passengers
+
+	hurtMu                   sync.Mutex
+	damage                   float64
+	hurtTicks, hurtDirection int32
+
+	// drops returns the items dropped by the vehicle when it is broken.
+	drops func(e *Ent) []item.Stack
+}
+
+// HurtTime returns the amount of ticks that the vehicle is displayed as hurt for, as a result of being attacked.
+func (v *vehicle) HurtTime() int32 {
+	v.hurtMu.Lock()
+	defer v.hurtMu.Unlock()
+	return v.hurtTicks
+}
+
+// HurtDirection returns the direction that the vehicle shakes in while hurt. It is either 1 or -1 and flips
+// every time the vehicle is attacked.
+func (v *vehicle) HurtDirection() int32 {
+	v.hurtMu.Lock()
+	defer v.hurtMu.Unlock()
+	return v.hurtDirection
+}
+
+// Attack ...
+func (v *vehicle) Attack(e *Ent, damage float64, src world.DamageSource) {
+	creative := false
+	if s, ok := src.(AttackDamageSource); ok {
+		if g, ok := s.Attacker.(interface{ GameMode() world.GameMode }); ok {
+			creative = g.GameMode().CreativeInventory()
+		}
+	}
+	v.hurtMu.Lock()
+	v.hurtTicks, v.hurtDirection = 10, -v.hurtDirection
+	v.damage += damage * 10
+	broken := creative || v.damage > 40
+	v.hurtMu.Unlock()
+
+	if broken {
+		v.breakVehicle(e, !creative)
+		return
+	}
+	for _, viewer := range e.World().Viewers(e.Position()) {
+		viewer.ViewEntityState(e)
+	}
+}
+
+// breakVehicle breaks the vehicle, making all passengers dismount and dropping the items of the vehicle if drop
+// is true.
+func (v *vehicle) breakVehicle(e *Ent, drop bool) {
+	v.dismountAll()
+	w, pos := e.World(), e.Position()
+	if drop && v.drops != nil {
+		for _, s := range v.drops(e) {
+			it := NewItem(s, pos)
+			it.vel = mgl64.Vec3{rand.Float64()*0.2 - 0.1, 0.2, rand.Float64()*0.2 - 0.1}
+			w.AddEntity(it)
+		}
+	}
+	_ = e.Close()
+}
+
+// tickVehicle ticks the state of the vehicle, recovering from damage and removing passengers that are no longer
+// in the same world as the vehicle.
+func (v *vehicle) tickVehicle(e *Ent) {
+	v.hurtMu.Lock()
+	if v.damage > 0 {
+		v.damage--
+	}
+	recovered := false
+	if v.hurtTicks > 0 {
+		v.hurtTicks--
+		recovered = v.hurtTicks == 0
+	}
+	v.hurtMu.Unlock()
+
+	w := e.World()
+	if recovered {
+		for _, viewer := range w.Viewers(e.Position()) {
+			viewer.ViewEntityState(e)
+		}
+	}
+	for _, p := range v.Passengers() {
+		if p.World() != w {
+			if r, ok := p.(Rider); ok {
+				r.Dismount()
+				continue
+			}
+			v.RemovePassenger(p)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item/trade"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// NewVillager creates a villager entity with the VillagerProfession passed. Villagers without a profession
+// obtain one by claiming a job site block close to them, after which they may be traded with.
+func NewVillager(pos mgl64.Vec3, profession VillagerProfession) *Ent {
+	config := villagerConf
+	config.Profession = profession
+	return Config{Behaviour: config.New()}.New(VillagerType{}, pos)
+}
+
+var villagerConf = VillagerBehaviourConfig{
+	Gravity: 0.08,
+	Drag:    0.02,
+}
+
+// VillagerType is a world.EntityType implementation for villagers.
+type VillagerType struct{}
+
+func (VillagerType) EncodeEntity() string { return "minecraft:villager_v2" }
+func (VillagerType) BBox(world.Entity) cube.BBox {
+	return cube.Box(-0.3, 0, -0.3, 0.3, 1.95, 0.3)
+}
+
+func (VillagerType) DecodeNBT(m map[string]any) world.Entity {
+	profession := NoProfession()
+	for _, p := range VillagerProfessions() {
+		if int32(p.Uint8()) == nbtconv.Int32(m, "Variant") {
+			profession = p
+		}
+	}
+	v := NewVillager(nbtconv.Vec3(m, "Pos"), profession)
+	v.vel = nbtconv.Vec3(m, "Motion")
+	v.rot = cube.Rotation{float64(nbtconv.Float32(m, "Yaw")), float64(nbtconv.Float32(m, "Pitch"))}
+
+	b := v.Behaviour().(*VillagerBehaviour)
+	b.experience = int(nbtconv.Int32(m, "TradeExperience"))
+	if _, ok := m["JobSite"]; ok {
+		b.jobSite, b.employed = nbtconv.Pos(m, "JobSite"), true
+	}
+	if offers := nbtconv.Slice(m, "Offers"); len(offers) > 0 {
+		b.offers = b.offers[:0]
+		for _, o := range offers {
+			if data, ok := o.(map[string]any); ok {
+				b.offers = append(b.offers, decodeOffer(data))
+			}
+		}
+	}
+	return v
+}
+
+func (VillagerType) EncodeNBT(e world.Entity) map[string]any {
+	v := e.(*Ent)
+	b := v.Behaviour().(*VillagerBehaviour)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	offers := make([]any, 0, len(b.offers))
+	for _, o := range b.offers {
+		offers = append(offers, encodeOffer(o))
+	}
+	m := map[string]any{
+		"Pos":             nbtconv.Vec3ToFloat32Slice(v.Position()),
+		"Yaw":             float32(v.Rotation().Yaw()),
+		"Pitch":           float32(v.Rotation().Pitch()),
+		"Motion":          nbtconv.Vec3ToFloat32Slice(v.Velocity()),
+		"Variant":         int32(b.profession.Uint8()),
+		"TradeExperience": int32(b.experience),
+		"Offers":          offers,
+	}
+	if b.employed {
+		m["JobSite"] = nbtconv.PosToInt32Slice(b.jobSite)
+	}
+	return m
+}
+
+// decodeOffer decodes a trade.Offer from its NBT representation.
+func decodeOffer(m map[string]any) trade.Offer {
+	return trade.Offer{
+		Input:            nbtconv.MapItem(m, "buyA"),
+		SecondInput:      nbtconv.MapItem(m, "buyB"),
+		Output:           nbtconv.MapItem(m, "sell"),
+		Uses:             int(nbtconv.Int32(m, "uses")),
+		MaxUses:          int(nbtconv.Int32(m, "maxUses")),
+		Demand:           int(nbtconv.Int32(m, "demand")),
+		PriceMultiplier:  float64(nbtconv.Float32(m, "priceMultiplierA")),
+		Tier:             int(nbtconv.Int32(m, "tier")),
+		TraderExperience: int(nbtconv.Int32(m, "traderExp")),
+		Experience:       int(nbtconv.Int32(m, "rewardExp")),
+	}
+}
+
+// encodeOffer encodes a trade.Offer to its NBT representation.
+func encodeOffer(o trade.Offer) map[string]any {
+	m := map[string]any{
+		"buyA":             nbtconv.WriteItem(o.Input, true),
+		"sell":             nbtconv.WriteItem(o.Output, true),
+		"uses":             int32(o.Uses),
+		"maxUses":          int32(o.MaxUses),
+		"demand":           int32(o.Demand),
+		"priceMultiplierA": float32(o.PriceMultiplier),
+		"tier":             int32(o.Tier),
+		"traderExp":        int32(o.TraderExperience),
+		"rewardExp":        int32(o.Experience),
+	}
+	if !o.SecondInput.Empty() {
+		m["buyB"] = nbtconv.WriteItem(o.SecondInput, true)
+	}
+	return m
+}
@@ -0,0 +1,252 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item/trade"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// VillagerBehaviourConfig holds optional parameters for a VillagerBehaviour.
+type VillagerBehaviourConfig struct {
+	// Gravity is the amount of Y velocity subtracted every tick.
+	Gravity float64
+	// Drag is used to reduce all axes of the velocity every tick. Velocity is multiplied with (1-Drag) every
+	// tick.
+	Drag float64
+	// Profession is the VillagerProfession that the villager starts with. If left as NoProfession, the villager
+	// obtains a profession by claiming a job site close to it.
+	Profession VillagerProfession
+	// JobSiteRadius is the horizontal distance in blocks within which the villager looks for job sites. If
+	// left as 0, a radius of 8 is used.
+	JobSiteRadius int
+	// RestockInterval is the minimum duration between two restocks of the offers of the villager. Villagers
+	// only restock while they have a job site. If left as 0, villagers restock every 10 minutes.
+	RestockInterval time.Duration
+}
+
+// New creates a VillagerBehaviour using the parameters in conf.
+func (conf VillagerBehaviourConfig) New() *VillagerBehaviour {
+	if conf.JobSiteRadius == 0 {
+		conf.JobSiteRadius = 8
+	}
+	if conf.RestockInterval == 0 {
+		conf.RestockInterval = time.Minute * 10
+	}
+	v := &VillagerBehaviour{conf: conf, profession: conf.Profession, mc: &MovementComputer{
+		Gravity:           conf.Gravity,
+		Drag:              conf.Drag,
+		DragBeforeGravity: true,
+	}}
+	v.unlockOffers(0)
+	return v
+}
+
+// VillagerBehaviour implements the behaviour of villagers. Villagers claim job sites close to them, which
+// determine their VillagerProfession, and offer trades depending on their profession. Trading with a villager
+// gives it experience, which unlocks new offers as it reaches higher tiers.
+type VillagerBehaviour struct {
+	conf VillagerBehaviourConfig
+	mc   *MovementComputer
+
+	mu          sync.Mutex
+	profession  VillagerProfession
+	jobSite     cube.Pos
+	employed    bool
+	experience  int
+	offers      []trade.Offer
+	lastRestock time.Duration
+}
+
+// Profession returns the current VillagerProfession of the villager.
+func (v *VillagerBehaviour) Profession() VillagerProfession {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.profession
+}
+
+// Variant returns the variant of the villager entity, which is its profession.
+func (v *VillagerBehaviour) Variant() int32 {
+	return int32(v.Profession().Uint8())
+}
+
+// JobSite returns the position of the job site claimed by the villager. False is returned if the villager has
+// not claimed a job site.
+func (v *VillagerBehaviour) JobSite() (cube.Pos, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.jobSite, v.employed
+}
+
+// TradeName ...
+func (v *VillagerBehaviour) TradeName() string {
+	return v.Profession().Name()
+}
+
+// Offers ...
+func (v *VillagerBehaviour) Offers() []trade.Offer {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return append([]trade.Offer(nil), v.offers...)
+}
+
+// Experience ...
+func (v *VillagerBehaviour) Experience() int {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.experience
+}
+
+// Trade uses the offer at the index passed, giving the villager experience. New offers are unlocked if the
+// villager reaches a higher tier as a result.
+func (v *VillagerBehaviour) Trade(_ world.Entity, index int) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if index < 0 || index >= len(v.offers) || v.offers[index].Disabled() || v.offers[index].Tier > trade.Tier(v.experience) {
+		return false
+	}
+	v.offers[index].Uses++
+
+	tier := trade.Tier(v.experience)
+	v.experience += v.offers[index].TraderExperience
+	// A single trade may give enough experience to skip tiers, so the offers of every tier reached are unlocked.
+	for t, newTier := tier+1, trade.Tier(v.experience); t <= newTier; t++ {
+		v.unlockOffers(t)
+	}
+	return true
+}
+
+// Tick makes the villager fall and look for job sites, and restocks its offers while it has a job site.
+func (v *VillagerBehaviour) Tick(e *Ent) *Movement {
+	w := e.World()
+	e.mu.Lock()
+	m := v.mc.TickMovement(e, e.pos, e.vel, e.rot)
+	e.pos, e.vel = m.pos, m.vel
+	e.mu.Unlock()
+
+	if e.Age()%(time.Second*2) == 0 && v.tickJob(e, w) {
+		for _, viewer := range w.Viewers(m.pos) {
+			viewer.ViewEntityState(e)
+		}
+	}
+	return m
+}
+
+// tickJob checks if the job site of the villager still exists, looks for a new job site if it has none and
+// restocks its offers. True is returned if the profession of the villager changed.
+func (v *VillagerBehaviour) tickJob(e *Ent, w *world.World) bool {
+	v.mu.Lock()
+	profession, jobSite, employed := v.profession, v.jobSite, v.employed
+	v.mu.Unlock()
+
+	if employed && !profession.JobSite(w.Block(jobSite)) {
+		// The job site was removed. Villagers that have never traded lose their profession with it.
+		v.mu.Lock()
+		v.employed = false
+		changed := v.experience == 0 && v.conf.Profession == NoProfession()
+		if changed {
+			v.profession, v.offers = NoProfession(), nil
+		}
+		v.mu.Unlock()
+		return changed
+	}
+	if employed {
+		v.restock(e.Age())
+		return false
+	}
+	pos, p, ok := v.findJobSite(e, w, profession)
+	if !ok {
+		return false
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.jobSite, v.employed, v.lastRestock = pos, true, e.Age()
+	if v.profession != NoProfession() {
+		return false
+	}
+	v.profession = p
+	v.unlockOffers(0)
+	return true
+}
+
+// findJobSite finds the closest job site within the job site radius of the villager that is not claimed by
+// another villager. If the profession passed is not NoProfession, only job sites of that profession are
+// considered.
+func (v *VillagerBehaviour) findJobSite(e *Ent, w *world.World, profession VillagerProfession) (cube.Pos, VillagerProfession, bool) {
+	r := v.conf.JobSiteRadius
+	centre := cube.PosFromVec3(e.Position())
+
+	claimed := map[cube.Pos]struct{}{}
+	box := cube.Box(-1, -1, -1, 1, 1, 1).Translate(centre.Vec3Centre()).Grow(float64(r * 2))
+	for _, other := range w.EntitiesWithin(box, func(other world.Entity) bool { return other == e }) {
+		if ent, ok := other.(*Ent); ok {
+			if b, ok := ent.Behaviour().(*VillagerBehaviour); ok {
+				if pos, ok := b.JobSite(); ok {
+					claimed[pos] = struct{}{}
+				}
+			}
+		}
+	}
+
+	var (
+		closest    cube.Pos
+		closestP   VillagerProfession
+		found      bool
+		closestDst = math.MaxFloat64
+	)
+	for x := -r; x <= r; x++ {
+		for y := -r / 2; y <= r/2; y++ {
+			for z := -r; z <= r; z++ {
+				pos := centre.Add(cube.Pos{x, y, z})
+				p, ok := professionFromJobSite(w.Block(pos))
+				if !ok || (profession != NoProfession() && p != profession) {
+					continue
+				}
+				if _, ok := claimed[pos]; ok {
+					continue
+				}
+				if dst := pos.Vec3Centre().Sub(e.Position()).Len(); dst < closestDst {
+					closest, closestP, found, closestDst = pos, p, true, dst
+				}
+			}
+		}
+	}
+	return closest, closestP, found
+}
+
+// restock restocks the offers of the villager if enough time has passed since the previous restock and at
+// least one of its offers was used.
+func (v *VillagerBehaviour) restock(age time.Duration) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if age-v.lastRestock < v.conf.RestockInterval {
+		return
+	}
+	used := false
+	for _, o := range v.offers {
+		used = used || o.Uses > 0
+	}
+	if !used {
+		return
+	}
+	v.lastRestock = age
+	for i, o := range v.offers {
+		v.offers[i] = o.Restock()
+	}
+}
+
+// unlockOffers adds two random offers of the tier passed to the offers of the villager. unlockOffers does not
+// lock the mutex of the villager.
+func (v *VillagerBehaviour) unlockOffers(tier int) {
+	pool := append([]trade.Offer(nil), v.profession.offers(tier)...)
+	rand.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+	if len(pool) > 2 {
+		pool = pool[:2]
+	}
+	v.offers = append(v.offers, pool...)
+}
@@ -0,0 +1,255 @@
+package entity
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/item/trade"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// VillagerProfession represents the profession of a villager. The profession of a villager determines the
+// offers that it trades and the job site block that it works at.
+type VillagerProfession struct {
+	villagerProfession
+}
+
+// NoProfession returns the VillagerProfession of a villager that has not yet claimed a job site.
+func NoProfession() VillagerProfession {
+	return VillagerProfession{0}
+}
+
+// Farmer returns the farmer VillagerProfession. Farmers work at composters.
+func Farmer() VillagerProfession {
+	return VillagerProfession{1}
+}
+
+// Fisherman returns the fisherman VillagerProfession. Fishermen work at barrels.
+func Fisherman() VillagerProfession {
+	return VillagerProfession{2}
+}
+
+// Shepherd returns the shepherd VillagerProfession. Shepherds work at looms.
+func Shepherd() VillagerProfession {
+	return VillagerProfession{3}
+}
+
+// Fletcher returns the fletcher VillagerProfession. Fletchers work at fletching tables.
+func Fletcher() VillagerProfession {
+	return VillagerProfession{4}
+}
+
+// Armourer returns the armourer VillagerProfession. Armourers work at blast furnaces.
+func Armourer() VillagerProfession {
+	return VillagerProfession{8}
+}
+
+// WeaponSmith returns the weapon smith VillagerProfession. Weapon smiths work at grindstones.
+func WeaponSmith() VillagerProfession {
+	return VillagerProfession{9}
+}
+
+// ToolSmith returns the tool smith VillagerProfession. Tool smiths work at smithing tables.
+func ToolSmith() VillagerProfession {
+	return VillagerProfession{10}
+}
+
+// Butcher returns the butcher VillagerProfession. Butchers work at smokers.
+func Butcher() VillagerProfession {
+	return VillagerProfession{11}
+}
+
+// Mason returns the mason VillagerProfession. Masons work at stonecutters.
+func Mason() VillagerProfession {
+	return VillagerProfession{13}
+}
+
+// VillagerProfessions returns all VillagerProfessions that a villager may obtain by claiming a job site.
+func VillagerProfessions() []VillagerProfession {
+	return []VillagerProfession{Farmer(), Fisherman(), Shepherd(), Fletcher(), Armourer(), WeaponSmith(), ToolSmith(), Butcher(), Mason()}
+}
+
+// professionFromJobSite returns the VillagerProfession of which the block passed is the job site. False is
+// returned if the block is not a job site.
+func professionFromJobSite(b world.Block) (VillagerProfession, bool) {
+	for _, p := range VillagerProfessions() {
+		if p.JobSite(b) {
+			return p, true
+		}
+	}
+	return VillagerProfession{}, false
+}
+
+type villagerProfession uint8
+
+// Uint8 returns the VillagerProfession as a uint8. The value returned is the variant of the villager entity.
+func (p villagerProfession) Uint8() uint8 {
+	return uint8(p)
+}
+
+// Name returns the name of the VillagerProfession, which is displayed at the top of the trade window.
+func (p villagerProfession) Name() string {
+	switch p {
+	case 0:
+		return "Villager"
+	case 1:
+		return "Farmer"
+	case 2:
+		return "Fisherman"
+	case 3:
+		return "Shepherd"
+	case 4:
+		return "Fletcher"
+	case 8:
+		return "Armourer"
+	case 9:
+		return "Weapon Smith"
+	case 10:
+		return "Tool Smith"
+	case 11:
+		return "Butcher"
+	case 13:
+		return "Mason"
+	}
+	panic("unknown villager profession")
+}
+
+// String ...
+func (p villagerProfession) String() string {
+	return p.Name()
+}
+
+// JobSite checks if the block passed is the job site of the VillagerProfession.
+func (p villagerProfession) JobSite(b world.Block) bool {
+	switch b.(type) {
+	case block.Composter:
+		return p == 1
+	case block.Barrel:
+		return p == 2
+	case block.Loom:
+		return p == 3
+	case block.FletchingTable:
+		return p == 4
+	case block.BlastFurnace:
+		return p == 8
+	case block.Grindstone:
+		return p == 9
+	case block.SmithingTable:
+		return p == 10
+	case block.Smoker:
+		return p == 11
+	case block.Stonecutter:
+		return p == 13
+	}
+	return false
+}
+
+// offers returns the offers that a villager with the VillagerProfession may unlock at the tier passed.
+func (p villagerProfession) offers(tier int) []trade.Offer {
+	if tier < 0 || tier >= 5 {
+		return nil
+	}
+	return villagerOffers[p][tier]
+}
+
+// villagerTraderExperience holds the experience that a villager gains for an offer of each tier being used.
+var villagerTraderExperience = [5]int{2, 10, 20, 30, 30}
+
+// villagerOffers holds the offers that a villager of each profession may unlock at every tier.
+var villagerOffers = map[villagerProfession][5][]trade.Offer{
+	1: {
+		{buy(item.Wheat{}, 20), buy(block.Potato{}, 26), buy(block.Carrot{}, 22), buy(item.Beetroot{}, 15), sell(1, item.Bread{}, 6)},
+		{buy(block.Pumpkin{}, 6), sell(1, item.PumpkinPie{}, 4), sell(1, item.Apple{}, 4)},
+		{sell(3, item.Cookie{}, 18), buy(block.Melon{}, 4)},
+		{sell(1, block.Cake{}, 1)},
+		{sell(3, item.GoldenCarrot{}, 3), sell(4, item.GlisteringMelonSlice{}, 3)},
+	},
+	2: {
+		{buy(item.Coal{}, 10), trade.Offer{Input: emeralds(1), SecondInput: item.NewStack(item.Cod{}, 6), Output: item.NewStack(item.Cod{Cooked: true}, 6), MaxUses: 16, PriceMultiplier: 0.05}},
+		{buy(item.Cod{}, 15), trade.Offer{Input: emeralds(1), SecondInput: item.NewStack(item.Salmon{}, 6), Output: item.NewStack(item.Salmon{Cooked: true}, 6), MaxUses: 16, PriceMultiplier: 0.05}},
+		{buy(item.Salmon{}, 13), sellGear(3, item.FishingRod{})},
+		{buy(item.TropicalFish{}, 6)},
+		{buy(item.Pufferfish{}, 4), sell(1, item.Boat{Type: item.OakBoat()}, 1)},
+	},
+	3: {
+		{buy(block.Wool{Colour: item.ColourWhite()}, 18), buy(block.Wool{Colour: item.ColourBrown()}, 18), sellGear(2, item.Shears{})},
+		{buy(item.Dye{Colour: item.ColourBlack()}, 12), buy(item.Dye{Colour: item.ColourLightBlue()}, 12), sell(1, block.Wool{Colour: item.ColourRed()}, 1), sell(1, block.Carpet{Colour: item.ColourRed()}, 4)},
+		{buy(item.Dye{Colour: item.ColourYellow()}, 12), sell(3, block.Bed{Colour: item.ColourRed()}, 1)},
+		{buy(item.Dye{Colour: item.ColourBrown()}, 12), sell(3, block.Banner{Colour: item.ColourRed()}, 1)},
+		{sell(2, block.Banner{Colour: item.ColourBlue()}, 1)},
+	},
+	4: {
+		{buy(item.Stick{}, 32), sell(1, item.Arrow{}, 16), trade.Offer{Input: emeralds(1), SecondInput: item.NewStack(block.Gravel{}, 10), Output: item.NewStack(item.Flint{}, 10), MaxUses: 12, PriceMultiplier: 0.05}},
+		{buy(item.Flint{}, 26), sellGear(2, item.Bow{})},
+		{sellGear(3, item.Crossbow{})},
+		{buy(item.Feather{}, 24)},
+		{trade.Offer{Input: emeralds(2), SecondInput: item.NewStack(item.Arrow{}, 5), Output: item.NewStack(item.Arrow{Tip: potion.Swiftness()}, 5), MaxUses: 12, PriceMultiplier: 0.05}},
+	},
+	8: {
+		{buy(item.Coal{}, 15), sellGear(5, item.Helmet{Tier: item.ArmourTierIron{}}), sellGear(9, item.Chestplate{Tier: item.ArmourTierIron{}}), sellGear(7, item.Leggings{Tier: item.ArmourTierIron{}}), sellGear(4, item.Boots{Tier: item.ArmourTierIron{}})},
+		{buy(item.IronIngot{}, 4), sellGear(36, item.Chestplate{Tier: item.ArmourTierChain{}})},
+		{sellGear(5, item.Shield{})},
+		{sellGear(19, item.Leggings{Tier: item.ArmourTierDiamond{}}), sellGear(13, item.Boots{Tier: item.ArmourTierDiamond{}})},
+		{sellGear(21, item.Chestplate{Tier: item.ArmourTierDiamond{}}), sellGear(13, item.Helmet{Tier: item.ArmourTierDiamond{}})},
+	},
+	9: {
+		{buy(item.Coal{}, 15), sellGear(3, item.Axe{Tier: item.ToolTierIron})},
+		{buy(item.IronIngot{}, 4), sellGear(2, item.Sword{Tier: item.ToolTierIron})},
+		{buy(item.Flint{}, 24)},
+		{buy(item.Diamond{}, 1), sellGear(17, item.Axe{Tier: item.ToolTierDiamond})},
+		{sellGear(13, item.Sword{Tier: item.ToolTierDiamond})},
+	},
+	10: {
+		{buy(item.Coal{}, 15), sellGear(1, item.Axe{Tier: item.ToolTierStone}), sellGear(1, item.Shovel{Tier: item.ToolTierStone}), sellGear(1, item.Pickaxe{Tier: item.ToolTierStone}), sellGear(1, item.Hoe{Tier: item.ToolTierStone})},
+		{buy(item.IronIngot{}, 4)},
+		{buy(item.Flint{}, 30), sellGear(1, item.Pickaxe{Tier: item.ToolTierIron})},
+		{buy(item.Diamond{}, 1), sellGear(4, item.Hoe{Tier: item.ToolTierDiamond})},
+		{sellGear(18, item.Pickaxe{Tier: item.ToolTierDiamond})},
+	},
+	11: {
+		{buy(item.Chicken{}, 14), buy(item.Porkchop{}, 7), buy(item.Rabbit{}, 4), sell(1, item.RabbitStew{}, 1)},
+		{buy(item.Coal{}, 15), sell(1, item.Porkchop{Cooked: true}, 5), sell(1, item.Chicken{Cooked: true}, 8)},
+		{buy(item.Mutton{}, 7), buy(item.Beef{}, 10)},
+		{buy(block.DriedKelp{}, 10)},
+		{sell(1, item.Beef{Cooked: true}, 5)},
+	},
+	13: {
+		{buy(item.ClayBall{}, 10), sell(1, item.Brick{}, 10)},
+		{buy(block.Stone{}, 20), sell(1, block.StoneBricks{Type: block.ChiseledStoneBricks()}, 4)},
+		{buy(block.Granite{}, 16), buy(block.Andesite{}, 16), buy(block.Diorite{}, 16), sell(1, block.Andesite{Polished: true}, 4)},
+		{buy(item.NetherQuartz{}, 12), sell(1, block.Terracotta{}, 1), sell(1, block.GlazedTerracotta{Colour: item.ColourWhite()}, 1)},
+		{sell(1, block.QuartzPillar{}, 1), sell(1, block.Quartz{}, 1)},
+	},
+}
+
+func init() {
+	for p, tiers := range villagerOffers {
+		for tier, offers := range tiers {
+			for i, o := range offers {
+				o.Tier, o.TraderExperience, o.Experience = tier, villagerTraderExperience[tier], 3+tier
+				villagerOffers[p][tier][i] = o
+			}
+		}
+	}
+}
+
+// emeralds returns a stack of the amount of emeralds passed.
+func emeralds(n int) item.Stack {
+	return item.NewStack(item.Emerald{}, n)
+}
+
+// buy returns an offer in which a villager buys n of the item passed for a single emerald.
+func buy(it world.Item, n int) trade.Offer {
+	return trade.Offer{Input: item.NewStack(it, n), Output: emeralds(1), MaxUses: 16, PriceMultiplier: 0.05}
+}
+
+// sell returns an offer in which a villager sells n of the item passed for an amount of emeralds.
+func sell(price int, it world.Item, n int) trade.Offer {
+	return trade.Offer{Input: emeralds(price), Output: item.NewStack(it, n), MaxUses: 12, PriceMultiplier: 0.05}
+}
+
+// sellGear returns an offer in which a villager sells a single tool or piece of armour for an amount of
+// emeralds. Gear may be bought less often than other items and rises in price more quickly.
+func sellGear(price int, it world.Item) trade.Offer {
+	return trade.Offer{Input: emeralds(price), Output: item.NewStack(it, 1), MaxUses: 3, PriceMultiplier: 0.2}
+}
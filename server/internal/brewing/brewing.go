@@ -0,0 +1,106 @@
+package brewing
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// key identifies an item by its name and metadata value, as returned by world.Item.EncodeItem.
+type key struct {
+	name string
+	meta int16
+}
+
+// mix is a recipe registered using RegisterPotion or RegisterContainerChange.
+type mix struct {
+	// base is the item that is brewed. If container is true, only the name of the base is compared, as the type of
+	// the potion is kept when brewed.
+	base      key
+	container bool
+	// output is the item that the base is turned into.
+	output item.Stack
+}
+
+// mixes holds all registered recipes, indexed by the reagent used to brew them.
+var mixes = map[key][]mix{}
+
+// RegisterPotion registers a potion mixing recipe which turns the base passed into the output when brewed with the
+// reagent passed.
+func RegisterPotion(base, reagent world.Item, output item.Stack) {
+	mixes[keyOf(reagent)] = append(mixes[keyOf(reagent)], mix{base: keyOf(base), output: output.Grow(1 - output.Count())})
+}
+
+// RegisterContainerChange registers a recipe which turns the potion container passed into the output container
+// when brewed with the reagent passed, keeping the type of the potion.
+func RegisterContainerChange(input, reagent, output world.Item) {
+	mixes[keyOf(reagent)] = append(mixes[keyOf(reagent)], mix{base: keyOf(input), container: true, output: item.NewStack(output, 1)})
+}
+
+// Brew attempts to brew the base passed using the reagent passed. If a matching recipe was registered, the result
+// is returned along with true.
+func Brew(base, reagent item.Stack) (item.Stack, bool) {
+	if base.Empty() || reagent.Empty() {
+		return item.Stack{}, false
+	}
+	k := keyOf(base.Item())
+	for _, m := range mixes[keyOf(reagent.Item())] {
+		if m.base.name != k.name {
+			continue
+		}
+		if !m.container {
+			if m.base.meta == k.meta {
+				return m.output, true
+			}
+			continue
+		}
+		if t, ok := potionType(base.Item()); ok {
+			if it, ok := withPotionType(m.output.Item(), t); ok {
+				return item.NewStack(it, 1), true
+			}
+		}
+	}
+	return item.Stack{}, false
+}
+
+// Reagent checks if the item stack passed is used as reagent in any registered recipe.
+func Reagent(s item.Stack) bool {
+	if s.Empty() {
+		return false
+	}
+	_, ok := mixes[keyOf(s.Item())]
+	return ok
+}
+
+// keyOf returns the key of the item passed.
+func keyOf(it world.Item) key {
+	name, meta := it.EncodeItem()
+	return key{name: name, meta: meta}
+}
+
+// potionType returns the potion type of the potion item passed. If the item is not a potion, false is returned.
+func potionType(it world.Item) (potion.Potion, bool) {
+	switch p := it.(type) {
+	case item.Potion:
+		return p.Type, true
+	case item.SplashPotion:
+		return p.Type, true
+	case item.LingeringPotion:
+		return p.Type, true
+	}
+	return potion.Potion{}, false
+}
+
+// withPotionType returns the potion item passed with its potion type changed to the type passed. If the item is not a
+// potion, false is returned.
+func withPotionType(it world.Item, t potion.Potion) (world.Item, bool) {
+	switch it.(type) {
+	case item.Potion:
+		return item.Potion{Type: t}, true
+	case item.SplashPotion:
+		return item.SplashPotion{Type: t}, true
+	case item.LingeringPotion:
+		return item.LingeringPotion{Type: t}, true
+	}
+	return nil, false
+}
@@ -0,0 +1,44 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// Boat is an item that may be placed on water or on land to create a boat entity, which players and other
+// entities are able to ride.
+type Boat struct {
+	// Type is the type of wood that the boat is made of.
+	Type BoatType
+}
+
+// MaxCount ...
+func (Boat) MaxCount() int {
+	return 1
+}
+
+// FuelInfo ...
+func (Boat) FuelInfo() FuelInfo {
+	return newFuelInfo(time.Second * 60)
+}
+
+// UseOnBlock places the boat on the block clicked. If water was clicked, the boat is placed in the water.
+func (b Boat) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, w *world.World, user User, ctx *UseContext) bool {
+	if l, ok := w.Liquid(pos); !ok || l.LiquidType() != "water" {
+		if pos = pos.Side(face); !replaceableWith(w.Block(pos), nil) {
+			return false
+		}
+	}
+	create := w.EntityRegistry().Config().Boat
+	w.AddEntity(create(pos.Vec3Middle(), user.Rotation().Yaw(), b.Type))
+
+	ctx.SubtractFromCount(1)
+	return true
+}
+
+// EncodeItem ...
+func (b Boat) EncodeItem() (name string, meta int16) {
+	return "minecraft:" + b.Type.String() + "_boat", 0
+}
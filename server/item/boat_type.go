@@ -0,0 +1,104 @@
+package item
+
+// BoatType represents the type of wood that a Boat is made of.
+type BoatType struct {
+	boatType
+}
+
+// OakBoat returns the oak BoatType.
+func OakBoat() BoatType {
+	return BoatType{0}
+}
+
+// SpruceBoat returns the spruce BoatType.
+func SpruceBoat() BoatType {
+	return BoatType{1}
+}
+
+// BirchBoat returns the birch BoatType.
+func BirchBoat() BoatType {
+	return BoatType{2}
+}
+
+// JungleBoat returns the jungle BoatType.
+func JungleBoat() BoatType {
+	return BoatType{3}
+}
+
+// AcaciaBoat returns the acacia BoatType.
+func AcaciaBoat() BoatType {
+	return BoatType{4}
+}
+
+// DarkOakBoat returns the dark oak BoatType.
+func DarkOakBoat() BoatType {
+	return BoatType{5}
+}
+
+// MangroveBoat returns the mangrove BoatType.
+func MangroveBoat() BoatType {
+	return BoatType{6}
+}
+
+// CherryBoat returns the cherry BoatType.
+func CherryBoat() BoatType {
+	return BoatType{8}
+}
+
+// BoatTypes returns all BoatTypes.
+func BoatTypes() []BoatType {
+	return []BoatType{OakBoat(), SpruceBoat(), BirchBoat(), JungleBoat(), AcaciaBoat(), DarkOakBoat(), MangroveBoat(), CherryBoat()}
+}
+
+type boatType uint8
+
+// Uint8 returns the BoatType as a uint8. The value returned is the variant of the boat entity.
+func (b boatType) Uint8() uint8 {
+	return uint8(b)
+}
+
+// Name ...
+func (b boatType) Name() string {
+	switch b {
+	case 0:
+		return "Oak Boat"
+	case 1:
+		return "Spruce Boat"
+	case 2:
+		return "Birch Boat"
+	case 3:
+		return "Jungle Boat"
+	case 4:
+		return "Acacia Boat"
+	case 5:
+		return "Dark Oak Boat"
+	case 6:
+		return "Mangrove Boat"
+	case 8:
+		return "Cherry Boat"
+	}
+	panic("unknown boat type")
+}
+
+// String ...
+func (b boatType) String() string {
+	switch b {
+	case 0:
+		return "oak"
+	case 1:
+		return "spruce"
+	case 2:
+		return "birch"
+	case 3:
+		return "jungle"
+	case 4:
+		return "acacia"
+	case 5:
+		return "dark_oak"
+	case 6:
+		return "mangrove"
+	case 8:
+		return "cherry"
+	}
+	panic("unknown boat type")
+}
@@ -0,0 +1,26 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// ChestMinecart is an item that may be placed on rails to create a minecart with a chest in it, which is able
+// to store items.
+type ChestMinecart struct{}
+
+// MaxCount ...
+func (ChestMinecart) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock places the chest minecart on the rail clicked.
+func (ChestMinecart) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, w *world.World, _ User, ctx *UseContext) bool {
+	return placeMinecart(pos, w, w.EntityRegistry().Config().ChestMinecart, ctx)
+}
+
+// EncodeItem ...
+func (ChestMinecart) EncodeItem() (name string, meta int16) {
+	return "minecraft:chest_minecart", 0
+}
@@ -0,0 +1,206 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"time"
+)
+
+// Crossbow is a ranged weapon similar to a bow that uses arrows or fireworks as ammunition. Unlike a bow, a
+// crossbow is charged first, after which it stays loaded until it is shot.
+type Crossbow struct {
+	// Item is the item that the crossbow is loaded with. This is either an Arrow or a Firework. If the crossbow is
+	// not loaded, Item is empty.
+	Item Stack
+}
+
+// crossbowChargeDuration is the duration it takes to charge a crossbow without the quick charge enchantment.
+const crossbowChargeDuration = time.Second * 5 / 4
+
+// Loaded returns true if the crossbow is loaded with an arrow or firework.
+func (c Crossbow) Loaded() bool {
+	return !c.Item.Empty()
+}
+
+// MaxCount always returns 1.
+func (Crossbow) MaxCount() int {
+	return 1
+}
+
+// DurabilityInfo ...
+func (Crossbow) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 464,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// FuelInfo ...
+func (Crossbow) FuelInfo() FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// EnchantmentValue ...
+func (Crossbow) EnchantmentValue() int {
+	return 1
+}
+
+// Charge loads the crossbow if it was charged for long enough. Fireworks held in the off hand are loaded before
+// any arrows in the inventory.
+func (c Crossbow) Charge(releaser Releaser, duration time.Duration, ctx *UseContext) bool {
+	if c.Loaded() {
+		return false
+	}
+	held, left := releaser.HeldItems()
+	if duration < chargeDuration(held) {
+		return false
+	}
+	creative := releaser.GameMode().CreativeInventory()
+
+	ammo := left
+	if _, ok := left.Item().(Firework); !ok {
+		var found bool
+		ammo, found = ctx.FirstFunc(func(stack Stack) bool {
+			_, ok := stack.Item().(Arrow)
+			return ok
+		})
+		if !found {
+			if !creative {
+				return false
+			}
+			ammo = NewStack(Arrow{}, 1)
+		}
+	}
+	c.Item = ammo.Grow(1 - ammo.Count())
+	if !creative {
+		if _, ok := left.Item().(Firework); ok {
+			left = left.Grow(-1)
+		} else {
+			ctx.Consume(c.Item)
+		}
+	}
+	releaser.SetHeldItems(held.WithItem(c), left)
+	releaser.PlaySound(sound.CrossbowLoad{Stage: sound.CrossbowLoadingEnd, QuickCharge: quickCharged(held)})
+	return true
+}
+
+// ContinueCharge plays the sounds of loading the crossbow while it is being charged.
+func (c Crossbow) ContinueCharge(releaser Releaser, duration time.Duration) {
+	if c.Loaded() {
+		return
+	}
+	held, _ := releaser.HeldItems()
+	ticks, total := duration.Milliseconds()/50, chargeDuration(held).Milliseconds()/50
+	switch ticks {
+	case total / 5:
+		releaser.PlaySound(sound.CrossbowLoad{Stage: sound.CrossbowLoadingStart, QuickCharge: quickCharged(held)})
+	case total / 2:
+		releaser.PlaySound(sound.CrossbowLoad{Stage: sound.CrossbowLoadingMiddle, QuickCharge: quickCharged(held)})
+	}
+}
+
+// ReleaseCharge shoots the arrow or firework that the crossbow is loaded with. If the crossbow is enchanted with
+// multishot, two additional projectiles are shot at an angle.
+func (c Crossbow) ReleaseCharge(releaser Releaser, ctx *UseContext) bool {
+	if !c.Loaded() {
+		return false
+	}
+	held, left := releaser.HeldItems()
+	w, creative := releaser.World(), releaser.GameMode().CreativeInventory()
+
+	count, piercing := 1, 0
+	for _, enchant := range held.Enchantments() {
+		if m, ok := enchant.Type().(interface{ ProjectileCount() int }); ok {
+			count = m.ProjectileCount()
+		}
+		if _, ok := enchant.Type().(interface{ PiercedEntities(int) int }); ok {
+			piercing = enchant.Level()
+		}
+	}
+
+	rot := releaser.Rotation()
+	for i := 0; i < count; i++ {
+		// The first projectile is shot straight ahead. Any additional projectiles are shot 10 degrees to either side.
+		r := rot.Add(cube.Rotation{float64((i+1)/2*10) * float64(1-i%2*2)})
+		dir := r.Vec3()
+
+		switch it := c.Item.Item().(type) {
+		case Firework:
+			create := w.EntityRegistry().Config().Firework
+			w.AddEntity(create(eyePosition(releaser), dir.Mul(1.6), r, false, it, releaser, 1, 0))
+		case Arrow:
+			// Only the arrow shot straight ahead may be picked up again, unless the releaser is in creative mode.
+			create := w.EntityRegistry().Config().Arrow
+			projRot := cube.Rotation{-r[0], -r[1]}
+			w.AddEntity(create(eyePosition(releaser), dir.Mul(3.15), projRot, 2, releaser, true, i != 0, i == 0 && !creative, 0, piercing, it.Tip))
+		}
+	}
+	if count > 1 {
+		ctx.DamageItem(3)
+	} else {
+		ctx.DamageItem(1)
+	}
+
+	releaser.SetHeldItems(held.WithItem(Crossbow{}), left)
+	w.PlaySound(releaser.Position(), sound.CrossbowShoot{})
+	return true
+}
+
+// EncodeItem ...
+func (Crossbow) EncodeItem() (name string, meta int16) {
+	return "minecraft:crossbow", 0
+}
+
+// DecodeNBT ...
+func (c Crossbow) DecodeNBT(data map[string]any) any {
+	c.Item = Stack{}
+	charged, ok := data["chargedItem"].(map[string]any)
+	if !ok {
+		return c
+	}
+	name, _ := charged["Name"].(string)
+	meta, _ := charged["Damage"].(int16)
+	it, ok := world.ItemByName(name, meta)
+	if !ok {
+		return c
+	}
+	if nbt, ok := it.(world.NBTer); ok {
+		if tag, ok := charged["tag"].(map[string]any); ok {
+			it = nbt.DecodeNBT(tag).(world.Item)
+		}
+	}
+	c.Item = NewStack(it, 1)
+	return c
+}
+
+// EncodeNBT ...
+func (c Crossbow) EncodeNBT() map[string]any {
+	if !c.Loaded() {
+		return nil
+	}
+	name, meta := c.Item.Item().EncodeItem()
+	charged := map[string]any{"Name": name, "Damage": meta, "Count": byte(1)}
+	if nbt, ok := c.Item.Item().(world.NBTer); ok {
+		if tag := nbt.EncodeNBT(); len(tag) != 0 {
+			charged["tag"] = tag
+		}
+	}
+	return map[string]any{"chargedItem": charged}
+}
+
+// chargeDuration returns the duration it takes to charge the crossbow stack passed, taking the quick charge
+// enchantment into account.
+func chargeDuration(s Stack) time.Duration {
+	for _, enchant := range s.Enchantments() {
+		if q, ok := enchant.Type().(interface{ ChargeDuration(int) time.Duration }); ok {
+			return q.ChargeDuration(enchant.Level())
+		}
+	}
+	return crossbowChargeDuration
+}
+
+// quickCharged checks if the crossbow stack passed is enchanted with quick charge.
+func quickCharged(s Stack) bool {
+	return chargeDuration(s) != crossbowChargeDuration
+}
@@ -0,0 +1,47 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Loyalty is an enchantment for tridents that makes a thrown trident return to its owner after hitting a target.
+type Loyalty struct{}
+
+// Name ...
+func (Loyalty) Name() string {
+	return "Loyalty"
+}
+
+// MaxLevel ...
+func (Loyalty) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (Loyalty) Cost(level int) (int, int) {
+	return 5 + level*7, 50
+}
+
+// Rarity ...
+func (Loyalty) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityUncommon
+}
+
+// ReturnSpeed returns the speed in blocks per tick with which a trident with the level of the enchantment passed
+// returns to its owner.
+func (Loyalty) ReturnSpeed(level int) float64 {
+	return 0.05 * float64(level)
+}
+
+// CompatibleWithEnchantment ...
+func (Loyalty) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	_, riptide := t.(Riptide)
+	return !riptide
+}
+
+// CompatibleWithItem ...
+func (Loyalty) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Trident)
+	return ok
+}
@@ -0,0 +1,47 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// LuckOfTheSea is a fishing rod enchantment that increases the chance of catching treasure, while decreasing the
+// chance of catching junk.
+type LuckOfTheSea struct{}
+
+// Name ...
+func (LuckOfTheSea) Name() string {
+	return "Luck of the Sea"
+}
+
+// MaxLevel ...
+func (LuckOfTheSea) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (LuckOfTheSea) Cost(level int) (int, int) {
+	min := 15 + (level-1)*9
+	return min, min + 50
+}
+
+// Rarity ...
+func (LuckOfTheSea) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// Luck returns the luck added to the loot obtained by fishing with the level of the enchantment passed.
+func (LuckOfTheSea) Luck(level int) int {
+	return level
+}
+
+// CompatibleWithEnchantment ...
+func (LuckOfTheSea) CompatibleWithEnchantment(item.EnchantmentType) bool {
+	return true
+}
+
+// CompatibleWithItem ...
+func (LuckOfTheSea) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.FishingRod)
+	return ok
+}
@@ -0,0 +1,46 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Multishot is an enchantment for crossbows that allow them to shoot three arrows or fireworks for the cost of one.
+type Multishot struct{}
+
+// Name ...
+func (Multishot) Name() string {
+	return "Multishot"
+}
+
+// MaxLevel ...
+func (Multishot) MaxLevel() int {
+	return 1
+}
+
+// Cost ...
+func (Multishot) Cost(int) (int, int) {
+	return 20, 50
+}
+
+// Rarity ...
+func (Multishot) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// ProjectileCount returns the amount of projectiles shot by a crossbow with the enchantment.
+func (Multishot) ProjectileCount() int {
+	return 3
+}
+
+// CompatibleWithEnchantment ...
+func (Multishot) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	_, piercing := t.(Piercing)
+	return !piercing
+}
+
+// CompatibleWithItem ...
+func (Multishot) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Crossbow)
+	return ok
+}
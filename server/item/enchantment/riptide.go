@@ -0,0 +1,49 @@
+package enchantment
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Riptide is a trident enchantment that launches the player forward when the trident is thrown while in water or
+// rain, instead of throwing the trident.
+type Riptide struct{}
+
+// Name ...
+func (Riptide) Name() string {
+	return "Riptide"
+}
+
+// MaxLevel ...
+func (Riptide) MaxLevel() int {
+	return 3
+}
+
+// Cost ...
+func (Riptide) Cost(level int) (int, int) {
+	return 10 + level*7, 50
+}
+
+// Rarity ...
+func (Riptide) Rarity() item.EnchantmentRarity {
+	return item.EnchantmentRarityRare
+}
+
+// LaunchForce returns the velocity with which a player is launched when using a trident with the level of the
+// enchantment passed.
+func (Riptide) LaunchForce(level int) float64 {
+	return 3 * (1 + float64(level)) / 4
+}
+
+// CompatibleWithEnchantment ...
+func (Riptide) CompatibleWithEnchantment(t item.EnchantmentType) bool {
+	_, loyalty := t.(Loyalty)
+	_, channeling := t.(Channeling)
+	return !loyalty && !channeling
+}
+
+// CompatibleWithItem ...
+func (Riptide) CompatibleWithItem(i world.Item) bool {
+	_, ok := i.(item.Trident)
+	return ok
+}
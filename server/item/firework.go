@@ -30,7 +30,7 @@ func (f Firework) Use(w *world.World, user User, ctx *UseContext) bool {
 
 	w.PlaySound(pos, sound.FireworkLaunch{})
 	create := w.EntityRegistry().Config().Firework
-	w.AddEntity(create(pos, user.Rotation(), true, f, user))
+	w.AddEntity(create(pos, mgl64.Vec3{}, user.Rotation(), true, f, user, 1.15, 0.04))
 
 	ctx.SubtractFromCount(1)
 	return true
@@ -40,7 +40,7 @@ func (f Firework) Use(w *world.World, user User, ctx *UseContext) bool {
 func (f Firework) UseOnBlock(blockPos cube.Pos, _ cube.Face, clickPos mgl64.Vec3, w *world.World, user User, ctx *UseContext) bool {
 	pos := blockPos.Vec3().Add(clickPos)
 	create := w.EntityRegistry().Config().Firework
-	w.AddEntity(create(pos, cube.Rotation{rand.Float64() * 360, 90}, false, f, user))
+	w.AddEntity(create(pos, mgl64.Vec3{}, cube.Rotation{rand.Float64() * 360, 90}, false, f, user, 1.15, 0.04))
 	w.PlaySound(pos, sound.FireworkLaunch{})
 
 	ctx.SubtractFromCount(1)
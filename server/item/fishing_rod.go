@@ -0,0 +1,66 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"time"
+)
+
+// FishingRod is a tool used to catch fish, treasure and junk from water. It may also be used to pull entities
+// towards the user.
+type FishingRod struct{}
+
+// Fisher represents a User that is able to cast a fishing hook using a FishingRod.
+type Fisher interface {
+	User
+	// CastFishingHook casts a new fishing hook from the Fisher, using the fishing rod passed. The enchantments of
+	// the rod influence the loot caught with the hook.
+	CastFishingHook(rod Stack)
+	// ReelFishingHook reels in the fishing hook currently cast by the Fisher. It returns the damage that should be
+	// dealt to the fishing rod as a result, and false if the Fisher did not have a fishing hook cast.
+	ReelFishingHook() (damage int, ok bool)
+}
+
+// MaxCount always returns 1.
+func (FishingRod) MaxCount() int {
+	return 1
+}
+
+// DurabilityInfo ...
+func (FishingRod) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 384,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// FuelInfo ...
+func (FishingRod) FuelInfo() FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// EnchantmentValue ...
+func (FishingRod) EnchantmentValue() int {
+	return 1
+}
+
+// Use casts a fishing hook if the user does not yet have one cast, or reels in the fishing hook if it does.
+func (FishingRod) Use(w *world.World, user User, ctx *UseContext) bool {
+	f, ok := user.(Fisher)
+	if !ok {
+		return false
+	}
+	if damage, ok := f.ReelFishingHook(); ok {
+		ctx.DamageItem(damage)
+		return true
+	}
+	held, _ := user.HeldItems()
+	f.CastFishingHook(held)
+	w.PlaySound(user.Position(), sound.ItemThrow{})
+	return true
+}
+
+// EncodeItem ...
+func (FishingRod) EncodeItem() (name string, meta int16) {
+	return "minecraft:fishing_rod", 0
+}
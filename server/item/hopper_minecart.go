@@ -0,0 +1,26 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// HopperMinecart is an item that may be placed on rails to create a minecart with a hopper in it, which
+// collects items above it.
+type HopperMinecart struct{}
+
+// MaxCount ...
+func (HopperMinecart) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock places the hopper minecart on the rail clicked.
+func (HopperMinecart) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, w *world.World, _ User, ctx *UseContext) bool {
+	return placeMinecart(pos, w, w.EntityRegistry().Config().HopperMinecart, ctx)
+}
+
+// EncodeItem ...
+func (HopperMinecart) EncodeItem() (name string, meta int16) {
+	return "minecraft:hopper_minecart", 0
+}
@@ -157,6 +157,19 @@ type Releasable interface {
 	Requirements() []Stack
 }
 
+// Chargeable represents an item that is charged by using it for a while, such as a crossbow. Once charged, using
+// the item again releases the charge.
+type Chargeable interface {
+	// Charge is called when the Releaser stops charging the item after the duration passed. Charge returns true if
+	// the item was charged as a result.
+	Charge(releaser Releaser, duration time.Duration, ctx *UseContext) bool
+	// ContinueCharge is called every tick while the Releaser is charging the item, with the duration passed being
+	// the time the item has been charged for so far.
+	ContinueCharge(releaser Releaser, duration time.Duration)
+	// ReleaseCharge releases the charge of the item. ReleaseCharge returns false if the item was not charged.
+	ReleaseCharge(releaser Releaser, ctx *UseContext) bool
+}
+
 // User represents an entity that is able to use an item in the world, typically entities such as players,
 // which interact with the world using an item.
 type User interface {
@@ -0,0 +1,188 @@
+package loot
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Condition is a condition that must be satisfied for a Pool, Entry or Function of a Table to be used.
+type Condition interface {
+	// Satisfied checks if the Condition is satisfied in the Context passed.
+	Satisfied(ctx *Context) bool
+}
+
+// Conditions is a list of conditions that must all be satisfied. In JSON, Conditions is an array of objects
+// that each have a "condition" field holding the name the Condition was registered with.
+type Conditions []Condition
+
+// Satisfied checks if all Conditions are satisfied in the Context passed.
+func (c Conditions) Satisfied(ctx *Context) bool {
+	for _, cond := range c {
+		if !cond.Satisfied(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// UnmarshalJSON decodes the Conditions from an array of condition objects, using the decode functions of the
+// conditions registered with RegisterCondition.
+func (c *Conditions) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("decode conditions: %w", err)
+	}
+	*c = make(Conditions, 0, len(raw))
+	for _, data := range raw {
+		var h struct {
+			Condition string `json:"condition"`
+		}
+		if err := json.Unmarshal(data, &h); err != nil {
+			return fmt.Errorf("decode condition: %w", err)
+		}
+		h.Condition = strings.TrimPrefix(h.Condition, "minecraft:")
+		conditionsMu.RLock()
+		decode, ok := conditions[h.Condition]
+		conditionsMu.RUnlock()
+		if !ok {
+			return fmt.Errorf("decode condition: unknown condition %q", h.Condition)
+		}
+		cond, err := decode(data)
+		if err != nil {
+			return fmt.Errorf("decode condition %v: %w", h.Condition, err)
+		}
+		*c = append(*c, cond)
+	}
+	return nil
+}
+
+var (
+	conditionsMu sync.RWMutex
+	// conditions holds the decode functions of all registered conditions, indexed by their name.
+	conditions = map[string]func(data []byte) (Condition, error){}
+)
+
+// RegisterCondition registers a Condition under the name passed, so that it may be used in loot tables decoded
+// from JSON. The decode function passed is called with the JSON object of the condition. Registering a
+// condition with a name that is already registered overwrites it.
+func RegisterCondition(name string, decode func(data []byte) (Condition, error)) {
+	conditionsMu.Lock()
+	defer conditionsMu.Unlock()
+	conditions[name] = decode
+}
+
+// jsonCondition returns a decode function that decodes a Condition of the type T from JSON.
+func jsonCondition[T Condition]() func(data []byte) (Condition, error) {
+	return func(data []byte) (Condition, error) {
+		var c T
+		err := json.Unmarshal(data, &c)
+		return c, err
+	}
+}
+
+func init() {
+	RegisterCondition("random_chance", jsonCondition[RandomChance]())
+	RegisterCondition("random_chance_with_looting", jsonCondition[RandomChanceWithLooting]())
+	RegisterCondition("killed_by_player", jsonCondition[KilledByPlayer]())
+	RegisterCondition("killed_by_player_or_pets", jsonCondition[KilledByPlayer]())
+	RegisterCondition("match_tool", jsonCondition[MatchTool]())
+	RegisterCondition("entity_properties", jsonCondition[EntityProperties]())
+}
+
+// RandomChance is a Condition that is satisfied with a fixed chance.
+type RandomChance struct {
+	// Chance is the chance of the condition being satisfied, from 0-1.
+	Chance float64 `json:"chance"`
+}
+
+// Satisfied ...
+func (r RandomChance) Satisfied(ctx *Context) bool {
+	return ctx.Rand.Float64() < r.Chance
+}
+
+// RandomChanceWithLooting is a Condition that is satisfied with a chance that increases with the Looting level
+// of the Context.
+type RandomChanceWithLooting struct {
+	// Chance is the chance of the condition being satisfied without Looting, from 0-1.
+	Chance float64 `json:"chance"`
+	// LootingMultiplier is added to the Chance for every level of Looting.
+	LootingMultiplier float64 `json:"looting_multiplier"`
+}
+
+// Satisfied ...
+func (r RandomChanceWithLooting) Satisfied(ctx *Context) bool {
+	return ctx.Rand.Float64() < r.Chance+float64(ctx.Looting)*r.LootingMultiplier
+}
+
+// KilledByPlayer is a Condition that is satisfied if the entity the loot is generated for was killed by a
+// player.
+type KilledByPlayer struct{}
+
+// Satisfied ...
+func (KilledByPlayer) Satisfied(ctx *Context) bool {
+	return ctx.KilledByPlayer
+}
+
+// MatchTool is a Condition that is satisfied if the tool used matches the item and enchantments specified.
+type MatchTool struct {
+	// Item is the name of the item that the tool must be. If empty, any item matches.
+	Item string `json:"item"`
+	// Enchantments holds the enchantments that the tool must have.
+	Enchantments []ToolEnchantment `json:"enchantments"`
+}
+
+// ToolEnchantment is an enchantment that the tool of a MatchTool condition must have.
+type ToolEnchantment struct {
+	// Enchantment is the name of the enchantment, such as "silk_touch".
+	Enchantment string `json:"enchantment"`
+	// Levels is the range of levels that the enchantment must be in. A RangeMax of 0 means there is no maximum.
+	Levels struct {
+		RangeMin int `json:"range_min"`
+		RangeMax int `json:"range_max"`
+	} `json:"levels"`
+}
+
+// Satisfied ...
+func (m MatchTool) Satisfied(ctx *Context) bool {
+	if ctx.Tool.Empty() {
+		return m.Item == "" && len(m.Enchantments) == 0
+	}
+	if name, _ := ctx.Tool.Item().EncodeItem(); m.Item != "" && name != m.Item {
+		return false
+	}
+	for _, e := range m.Enchantments {
+		t, ok := enchantmentByName(e.Enchantment)
+		if !ok {
+			return false
+		}
+		enchant, ok := ctx.Tool.Enchantment(t)
+		if !ok || enchant.Level() < e.Levels.RangeMin || (e.Levels.RangeMax > 0 && enchant.Level() > e.Levels.RangeMax) {
+			return false
+		}
+	}
+	return true
+}
+
+// EntityProperties is a Condition that is satisfied if the Entity of the Context has the properties specified.
+type EntityProperties struct {
+	// Properties holds the properties that the entity must have.
+	Properties struct {
+		// OnFire, if not nil, specifies if the entity must be on fire or not.
+		OnFire *bool `json:"on_fire"`
+	} `json:"properties"`
+}
+
+// Satisfied ...
+func (e EntityProperties) Satisfied(ctx *Context) bool {
+	if e.Properties.OnFire == nil {
+		return true
+	}
+	var onFire bool
+	if f, ok := ctx.Entity.(interface{ OnFireDuration() time.Duration }); ok {
+		onFire = f.OnFireDuration() > 0
+	}
+	return onFire == *e.Properties.OnFire
+}
@@ -0,0 +1,411 @@
+package loot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Function is a function that modifies the item produced by an Entry of a Table.
+type Function interface {
+	// Apply applies the Function to the item.Stack passed in the Context passed and returns the resulting stack.
+	// An empty stack may be returned to drop the item altogether.
+	Apply(s item.Stack, ctx *Context) item.Stack
+}
+
+// Functions is a list of functions that are applied in order. In JSON, Functions is an array of objects that
+// each have a "function" field holding the name the Function was registered with, and optionally a "conditions"
+// field holding Conditions that must be satisfied for the function to be applied.
+type Functions []Function
+
+// Apply applies all Functions to the item.Stack passed, in order.
+func (f Functions) Apply(s item.Stack, ctx *Context) item.Stack {
+	for _, fn := range f {
+		if s.Empty() {
+			break
+		}
+		s = fn.Apply(s, ctx)
+	}
+	return s
+}
+
+// UnmarshalJSON decodes the Functions from an array of function objects, using the decode functions of the
+// functions registered with RegisterFunction.
+func (f *Functions) UnmarshalJSON(b []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return fmt.Errorf("decode functions: %w", err)
+	}
+	*f = make(Functions, 0, len(raw))
+	for _, data := range raw {
+		var h struct {
+			Function   string     `json:"function"`
+			Conditions Conditions `json:"conditions"`
+		}
+		if err := json.Unmarshal(data, &h); err != nil {
+			return fmt.Errorf("decode function: %w", err)
+		}
+		h.Function = strings.TrimPrefix(h.Function, "minecraft:")
+		functionsMu.RLock()
+		decode, ok := functions[h.Function]
+		functionsMu.RUnlock()
+		if !ok {
+			return fmt.Errorf("decode function: unknown function %q", h.Function)
+		}
+		fn, err := decode(data)
+		if err != nil {
+			return fmt.Errorf("decode function %v: %w", h.Function, err)
+		}
+		if len(h.Conditions) != 0 {
+			fn = conditionalFunction{Function: fn, conditions: h.Conditions}
+		}
+		*f = append(*f, fn)
+	}
+	return nil
+}
+
+// conditionalFunction is a Function that is only applied if its conditions are satisfied.
+type conditionalFunction struct {
+	Function
+	conditions Conditions
+}
+
+// Apply ...
+func (c conditionalFunction) Apply(s item.Stack, ctx *Context) item.Stack {
+	if !c.conditions.Satisfied(ctx) {
+		return s
+	}
+	return c.Function.Apply(s, ctx)
+}
+
+var (
+	functionsMu sync.RWMutex
+	// functions holds the decode functions of all registered functions, indexed by their name.
+	functions = map[string]func(data []byte) (Function, error){}
+)
+
+// RegisterFunction registers a Function under the name passed, so that it may be used in loot tables decoded
+// from JSON. The decode function passed is called with the JSON object of the function. Registering a function
+// with a name that is already registered overwrites it.
+func RegisterFunction(name string, decode func(data []byte) (Function, error)) {
+	functionsMu.Lock()
+	defer functionsMu.Unlock()
+	functions[name] = decode
+}
+
+// jsonFunction returns a decode function that decodes a Function of the type T from JSON.
+func jsonFunction[T Function]() func(data []byte) (Function, error) {
+	return func(data []byte) (Function, error) {
+		var f T
+		err := json.Unmarshal(data, &f)
+		return f, err
+	}
+}
+
+func init() {
+	RegisterFunction("set_count", jsonFunction[SetCount]())
+	RegisterFunction("set_data", jsonFunction[SetData]())
+	RegisterFunction("set_damage", jsonFunction[SetDamage]())
+	RegisterFunction("set_name", jsonFunction[SetName]())
+	RegisterFunction("set_lore", jsonFunction[SetLore]())
+	RegisterFunction("enchant_randomly", jsonFunction[EnchantRandomly]())
+	RegisterFunction("enchant_with_levels", jsonFunction[EnchantWithLevels]())
+	RegisterFunction("specific_enchants", jsonFunction[SpecificEnchants]())
+	RegisterFunction("looting_enchant", jsonFunction[LootingEnchant]())
+	RegisterFunction("explosion_decay", jsonFunction[ExplosionDecay]())
+}
+
+// SetCount is a Function that sets the count of the item to a random number in a Range.
+type SetCount struct {
+	// Count is the range that the count is selected from.
+	Count Range `json:"count"`
+}
+
+// Apply ...
+func (f SetCount) Apply(s item.Stack, ctx *Context) item.Stack {
+	return s.Grow(f.Count.Int(ctx.Rand) - s.Count())
+}
+
+// SetData is a Function that sets the metadata value of the item, changing it into the item registered with the
+// same name and the new metadata value. If no such item is registered, the item is not changed.
+type SetData struct {
+	// Data is the range that the metadata value is selected from.
+	Data Range `json:"data"`
+}
+
+// Apply ...
+func (f SetData) Apply(s item.Stack, ctx *Context) item.Stack {
+	name, _ := s.Item().EncodeItem()
+	if it, ok := world.ItemByName(name, int16(f.Data.Int(ctx.Rand))); ok {
+		return s.WithItem(it)
+	}
+	return s
+}
+
+// SetDamage is a Function that sets the durability of the item to a random fraction of its maximum durability.
+type SetDamage struct {
+	// Damage is the range that the fraction of durability remaining is selected from, where 1 is an undamaged
+	// item and 0 is an item with no durability remaining.
+	Damage Range `json:"damage"`
+}
+
+// Apply ...
+func (f SetDamage) Apply(s item.Stack, ctx *Context) item.Stack {
+	if s.MaxDurability() <= 0 {
+		return s
+	}
+	d := int(math.Round(float64(s.MaxDurability()) * f.Damage.Float(ctx.Rand)))
+	if d < 1 {
+		d = 1
+	}
+	return s.WithDurability(d)
+}
+
+// SetName is a Function that sets the custom name of the item.
+type SetName struct {
+	// Name is the custom name of the item.
+	Name string `json:"name"`
+}
+
+// Apply ...
+func (f SetName) Apply(s item.Stack, _ *Context) item.Stack {
+	return s.WithCustomName(f.Name)
+}
+
+// SetLore is a Function that sets the lore of the item.
+type SetLore struct {
+	// Lore holds the lines of lore of the item.
+	Lore []string `json:"lore"`
+}
+
+// Apply ...
+func (f SetLore) Apply(s item.Stack, _ *Context) item.Stack {
+	return s.WithLore(f.Lore...)
+}
+
+// EnchantRandomly is a Function that adds a single random enchantment compatible with the item, at a random
+// level. Books are turned into enchanted books.
+type EnchantRandomly struct {
+	// Treasure specifies if treasure enchantments, such as Mending, may be selected.
+	Treasure bool `json:"treasure"`
+}
+
+// Apply ...
+func (f EnchantRandomly) Apply(s item.Stack, ctx *Context) item.Stack {
+	available := availableEnchantments(s, f.Treasure)
+	if len(available) == 0 {
+		return s
+	}
+	t := available[ctx.Rand.Intn(len(available))]
+	return enchantedBook(s).WithEnchantments(item.NewEnchantment(t, ctx.Rand.Intn(t.MaxLevel())+1))
+}
+
+// EnchantWithLevels is a Function that enchants the item as if it was enchanted in an enchanting table using
+// the number of levels passed. Books are turned into enchanted books.
+type EnchantWithLevels struct {
+	// Levels is the range that the number of levels used is selected from.
+	Levels Range `json:"levels"`
+	// Treasure specifies if treasure enchantments, such as Mending, may be selected.
+	Treasure bool `json:"treasure"`
+}
+
+// Apply ...
+func (f EnchantWithLevels) Apply(s item.Stack, ctx *Context) item.Stack {
+	value := 1
+	if e, ok := s.Item().(item.Enchantable); ok {
+		value = e.EnchantmentValue()
+	}
+	cost := f.Levels.Int(ctx.Rand) + 1 + ctx.Rand.Intn(value/4+1) + ctx.Rand.Intn(value/4+1)
+	cost = int(math.Round(float64(cost) * (1 + (ctx.Rand.Float64()+ctx.Rand.Float64()-1)*0.15)))
+	if cost < 1 {
+		cost = 1
+	}
+
+	var available []item.Enchantment
+	for _, t := range availableEnchantments(s, f.Treasure) {
+		for lvl := t.MaxLevel(); lvl > 0; lvl-- {
+			if min, max := t.Cost(lvl); cost >= min && cost <= max {
+				available = append(available, item.NewEnchantment(t, lvl))
+				break
+			}
+		}
+	}
+	var selected []item.Enchantment
+	for len(available) > 0 {
+		enchant := weightedEnchantment(ctx, available)
+		selected = append(selected, enchant)
+
+		compatible := available[:0]
+		for _, other := range available {
+			if other.Type() != enchant.Type() && enchant.Type().CompatibleWithEnchantment(other.Type()) {
+				compatible = append(compatible, other)
+			}
+		}
+		available = compatible
+		if ctx.Rand.Intn(50) > cost {
+			break
+		}
+		cost /= 2
+	}
+	if len(selected) == 0 {
+		return s
+	}
+	return enchantedBook(s).WithEnchantments(selected...)
+}
+
+// SpecificEnchants is a Function that adds specific enchantments to the item.
+type SpecificEnchants struct {
+	// Enchants holds the enchantments added to the item.
+	Enchants []SpecificEnchant `json:"enchants"`
+}
+
+// SpecificEnchant is an enchantment added by a SpecificEnchants function. In JSON, it is either the name of the
+// enchantment, in which case a level of 1 is used, or an object with an "id" and "level" field. Levels below 1 are
+// rejected when decoding and raised to 1 when applied.
+type SpecificEnchant struct {
+	// ID is the name of the enchantment, such as "sharpness".
+	ID string `json:"id"`
+	// Level is the range that the level of the enchantment is selected from.
+	Level Range `json:"level"`
+}
+
+// UnmarshalJSON ...
+func (e *SpecificEnchant) UnmarshalJSON(b []byte) error {
+	if b = bytes.TrimSpace(b); len(b) != 0 && b[0] == '"' {
+		e.Level = Exactly(1)
+		return json.Unmarshal(b, &e.ID)
+	}
+	var v struct {
+		ID    string `json:"id"`
+		Level *Range `json:"level"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	e.ID, e.Level = v.ID, Exactly(1)
+	if v.Level != nil {
+		e.Level = *v.Level
+	}
+	if e.Level.Min < 1 || e.Level.Max < 1 {
+		return fmt.Errorf("decode enchantment %v: level must be at least 1, got %v-%v", e.ID, e.Level.Min, e.Level.Max)
+	}
+	return nil
+}
+
+// Apply ...
+func (f SpecificEnchants) Apply(s item.Stack, ctx *Context) item.Stack {
+	enchants := make([]item.Enchantment, 0, len(f.Enchants))
+	for _, e := range f.Enchants {
+		if t, ok := enchantmentByName(e.ID); ok {
+			// item.NewEnchantment panics for levels below 1, which a SpecificEnchant constructed in Go may still
+			// produce.
+			lvl := e.Level.Int(ctx.Rand)
+			if lvl < 1 {
+				lvl = 1
+			}
+			enchants = append(enchants, item.NewEnchantment(t, lvl))
+		}
+	}
+	if len(enchants) == 0 {
+		return s
+	}
+	return enchantedBook(s).WithEnchantments(enchants...)
+}
+
+// LootingEnchant is a Function that increases the count of the item by a random number for every level of
+// Looting in the Context.
+type LootingEnchant struct {
+	// Count is the range that the count added per level of Looting is selected from.
+	Count Range `json:"count"`
+	// Limit is the maximum count of the resulting item. If 0, there is no limit.
+	Limit int `json:"limit"`
+}
+
+// Apply ...
+func (f LootingEnchant) Apply(s item.Stack, ctx *Context) item.Stack {
+	if ctx.Looting <= 0 {
+		return s
+	}
+	s = s.Grow(int(math.Round(f.Count.Float(ctx.Rand) * float64(ctx.Looting))))
+	if f.Limit > 0 && s.Count() > f.Limit {
+		s = s.Grow(f.Limit - s.Count())
+	}
+	return s
+}
+
+// ExplosionDecay is a Function that removes items from the stack if the loot is generated as a result of an
+// explosion. Every item has a chance of 1/ExplosionRadius to survive.
+type ExplosionDecay struct{}
+
+// Apply ...
+func (ExplosionDecay) Apply(s item.Stack, ctx *Context) item.Stack {
+	if ctx.ExplosionRadius <= 0 {
+		return s
+	}
+	var n int
+	for i := 0; i < s.Count(); i++ {
+		if ctx.Rand.Float64() <= 1/ctx.ExplosionRadius {
+			n++
+		}
+	}
+	return s.Grow(n - s.Count())
+}
+
+// availableEnchantments returns all registered enchantment types that are compatible with the item.Stack passed.
+// Books are compatible with any enchantment. Treasure enchantments are only included if treasure is true.
+func availableEnchantments(s item.Stack, treasure bool) []item.EnchantmentType {
+	_, book := s.Item().(item.Book)
+	var available []item.EnchantmentType
+	for _, t := range item.Enchantments() {
+		if tr, ok := t.(interface{ Treasure() bool }); ok && tr.Treasure() && !treasure {
+			continue
+		}
+		if book || t.CompatibleWithItem(s.Item()) {
+			available = append(available, t)
+		}
+	}
+	return available
+}
+
+// enchantedBook turns the stack passed into a stack of enchanted books if it holds books, keeping its count, custom
+// name and lore. Other stacks are returned unchanged.
+func enchantedBook(s item.Stack) item.Stack {
+	if _, ok := s.Item().(item.Book); !ok {
+		return s
+	}
+	return item.NewStack(item.EnchantedBook{}, s.Count()).WithCustomName(s.CustomName()).WithLore(s.Lore()...)
+}
+
+// weightedEnchantment selects a random enchantment from the enchantments passed, favouring enchantments with a
+// rarity that has a higher weight.
+func weightedEnchantment(ctx *Context, enchants []item.Enchantment) item.Enchantment {
+	var total int
+	for _, e := range enchants {
+		total += e.Type().Rarity().Weight()
+	}
+	n := ctx.Rand.Intn(total)
+	for _, e := range enchants {
+		if n -= e.Type().Rarity().Weight(); n < 0 {
+			return e
+		}
+	}
+	return enchants[len(enchants)-1]
+}
+
+// enchantmentByName looks up a registered enchantment type by its name as used in loot tables, such as
+// "silk_touch". Curses may be referred to with or without the "curse_of_" prefix.
+func enchantmentByName(name string) (item.EnchantmentType, bool) {
+	name = strings.ToLower(strings.TrimPrefix(name, "minecraft:"))
+	for _, t := range item.Enchantments() {
+		n := strings.ReplaceAll(strings.ToLower(t.Name()), " ", "_")
+		if n == name || strings.TrimPrefix(n, "curse_of_") == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
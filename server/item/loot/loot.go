@@ -0,0 +1,229 @@
+package loot
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/world"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// Table is a loot table, which produces a random set of items when Generate is called. Loot tables are used for
+// things such as block drops, entity drops, fishing and the contents of generated chests. Tables may be created
+// in Go directly or decoded from the Bedrock Edition loot table JSON format using Decode.
+type Table struct {
+	// Pools holds the pools of the Table. Every pool produces items independently of the others.
+	Pools []Pool `json:"pools"`
+}
+
+// Pool is a single pool of a Table. A pool selects a random entry a number of times, depending on its Rolls.
+type Pool struct {
+	// Rolls is the number of entries selected from the pool.
+	Rolls Range `json:"rolls"`
+	// Conditions must all be satisfied for the pool to be used at all.
+	Conditions Conditions `json:"conditions"`
+	// Entries holds the entries that may be selected from the pool.
+	Entries []Entry `json:"entries"`
+}
+
+// EntryType is the type of Entry in a Pool.
+type EntryType string
+
+const (
+	// EntryTypeItem is an Entry that produces an item, with the Name of the Entry being the name of the item.
+	EntryTypeItem EntryType = "item"
+	// EntryTypeLootTable is an Entry that produces the items of another loot table, with the Name of the Entry
+	// being the name the loot table was registered with.
+	EntryTypeLootTable EntryType = "loot_table"
+	// EntryTypeEmpty is an Entry that produces nothing.
+	EntryTypeEmpty EntryType = "empty"
+)
+
+// Entry is an entry of a Pool that may be selected when rolling the pool.
+type Entry struct {
+	// Type is the type of the entry. It specifies what the Name of the entry refers to.
+	Type EntryType `json:"type"`
+	// Name is the name of the item or loot table of the entry, depending on its Type.
+	Name string `json:"name"`
+	// Weight is the chance of the entry being selected, relative to the weights of other entries in the same
+	// Pool. If left as 0, a weight of 1 is used.
+	Weight int `json:"weight"`
+	// Quality is the amount the Weight of the entry is changed by for every point of Context.Luck.
+	Quality int `json:"quality"`
+	// Conditions must all be satisfied for the entry to be selectable.
+	Conditions Conditions `json:"conditions"`
+	// Functions are applied to the item produced by the entry, in order. Functions are ignored for entries that
+	// are not of the type EntryTypeItem.
+	Functions Functions `json:"functions"`
+}
+
+// Context holds the context in which loot is generated. The fields of a Context are used by the conditions and
+// functions of a Table. All fields are optional.
+type Context struct {
+	// Rand is the source of randomness used to generate loot. If left nil, a new source is created.
+	Rand *rand.Rand
+	// Luck is the luck of the entity that the loot is generated for, such as the level of the Luck of the Sea
+	// enchantment of a fishing rod. Luck influences the weights of entries with a non-zero quality.
+	Luck float64
+	// Looting is the level of the Looting enchantment of the item used to kill an entity.
+	Looting int
+	// Tool is the item used to break a block or kill an entity, if any.
+	Tool item.Stack
+	// Entity is the entity that the loot is generated for, such as a killed entity or the entity that is
+	// fishing.
+	Entity world.Entity
+	// KilledByPlayer specifies if the Entity was killed by a player.
+	KilledByPlayer bool
+	// ExplosionRadius is the radius of the explosion that caused the loot to be generated. If zero, the loot was
+	// not generated as a result of an explosion.
+	ExplosionRadius float64
+
+	depth int
+}
+
+// maxDepth is the maximum depth of loot tables referencing other loot tables. It prevents endless recursion in
+// tables referencing themselves.
+const maxDepth = 16
+
+// Decode decodes a Table from the Bedrock Edition loot table JSON data passed. An error is returned if the data
+// is invalid or if it holds conditions or functions that are not registered.
+func Decode(data []byte) (Table, error) {
+	var t Table
+	if err := json.Unmarshal(data, &t); err != nil {
+		return t, fmt.Errorf("decode loot table: %w", err)
+	}
+	return t, nil
+}
+
+// Generate generates a random set of items from the Table using the Context passed. Stacks with a count that
+// exceeds the max count of their item are split into multiple stacks.
+func (t Table) Generate(ctx Context) []item.Stack {
+	if ctx.Rand == nil {
+		ctx.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	var stacks []item.Stack
+	for _, pool := range t.Pools {
+		stacks = append(stacks, pool.generate(&ctx)...)
+	}
+	return split(stacks)
+}
+
+// Fill generates a random set of items from the Table using the Context passed and places them in random empty
+// slots of the inventory passed. Items that do not fit in the inventory are discarded.
+func (t Table) Fill(inv *inventory.Inventory, ctx Context) {
+	if ctx.Rand == nil {
+		ctx.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	var empty []int
+	for slot := 0; slot < inv.Size(); slot++ {
+		if it, _ := inv.Item(slot); it.Empty() {
+			empty = append(empty, slot)
+		}
+	}
+	ctx.Rand.Shuffle(len(empty), func(i, j int) {
+		empty[i], empty[j] = empty[j], empty[i]
+	})
+	for i, s := range t.Generate(ctx) {
+		if i >= len(empty) {
+			return
+		}
+		_ = inv.SetItem(empty[i], s)
+	}
+}
+
+// generate rolls the Pool using the Context passed and returns the items produced.
+func (p Pool) generate(ctx *Context) []item.Stack {
+	if !p.Conditions.Satisfied(ctx) {
+		return nil
+	}
+	var stacks []item.Stack
+	for i, rolls := 0, p.Rolls.Int(ctx.Rand); i < rolls; i++ {
+		if entry, ok := p.selectEntry(ctx); ok {
+			stacks = append(stacks, entry.generate(ctx)...)
+		}
+	}
+	return stacks
+}
+
+// selectEntry selects a random Entry from the Pool, based on the weights and qualities of the entries. Entries
+// with conditions that are not satisfied and item entries with an item that is not registered are never selected.
+func (p Pool) selectEntry(ctx *Context) (Entry, bool) {
+	entries, weights, total := make([]Entry, 0, len(p.Entries)), make([]int, 0, len(p.Entries)), 0
+	for _, entry := range p.Entries {
+		if entry.Type == EntryTypeItem {
+			if _, ok := world.ItemByName(entry.Name, 0); !ok {
+				// This can be expected to happen, as some loot tables contain items that aren't currently
+				// implemented.
+				continue
+			}
+		}
+		weight := entry.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		if weight = int(math.Floor(float64(weight) + float64(entry.Quality)*ctx.Luck)); weight <= 0 || !entry.Conditions.Satisfied(ctx) {
+			continue
+		}
+		entries, weights, total = append(entries, entry), append(weights, weight), total+weight
+	}
+	if total == 0 {
+		return Entry{}, false
+	}
+	n := ctx.Rand.Intn(total)
+	for i, w := range weights {
+		if n < w {
+			return entries[i], true
+		}
+		n -= w
+	}
+	return Entry{}, false
+}
+
+// generate produces the items of the Entry using the Context passed.
+func (e Entry) generate(ctx *Context) []item.Stack {
+	switch e.Type {
+	case EntryTypeItem:
+		it, ok := world.ItemByName(e.Name, 0)
+		if !ok {
+			return nil
+		}
+		s := e.Functions.Apply(item.NewStack(it, 1), ctx)
+		if s.Empty() {
+			return nil
+		}
+		return []item.Stack{s}
+	case EntryTypeLootTable:
+		t, ok := Lookup(e.Name)
+		if !ok || ctx.depth >= maxDepth {
+			return nil
+		}
+		ctx.depth++
+		defer func() {
+			ctx.depth--
+		}()
+		var stacks []item.Stack
+		for _, pool := range t.Pools {
+			stacks = append(stacks, pool.generate(ctx)...)
+		}
+		return stacks
+	}
+	return nil
+}
+
+// split splits the stacks passed into stacks that do not exceed the max count of their item.
+func split(stacks []item.Stack) []item.Stack {
+	res := make([]item.Stack, 0, len(stacks))
+	for _, s := range stacks {
+		for s.Count() > s.MaxCount() {
+			res = append(res, s.Grow(s.MaxCount()-s.Count()))
+			s = s.Grow(-s.MaxCount())
+		}
+		if !s.Empty() {
+			res = append(res, s)
+		}
+	}
+	return res
+}
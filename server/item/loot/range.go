@@ -0,0 +1,80 @@
+package loot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// Range is a range of numbers used in loot tables, such as the number of rolls of a Pool or the count set by a
+// SetCount function. In JSON, a Range may either be a single number, an object with a "min" and "max" field or
+// an array holding the minimum and maximum.
+type Range struct {
+	Min, Max float64
+}
+
+// Exactly returns a Range that always produces the number passed.
+func Exactly(n float64) Range {
+	return Range{Min: n, Max: n}
+}
+
+// Int returns a random integer in the Range, including both Min and Max.
+func (r Range) Int(rnd *rand.Rand) int {
+	min, max := int(math.Floor(r.Min)), int(math.Floor(r.Max))
+	if max <= min {
+		return min
+	}
+	return min + rnd.Intn(max-min+1)
+}
+
+// Float returns a random floating point number in the Range.
+func (r Range) Float(rnd *rand.Rand) float64 {
+	if r.Max <= r.Min {
+		return r.Min
+	}
+	return r.Min + rnd.Float64()*(r.Max-r.Min)
+}
+
+// UnmarshalJSON decodes the Range from a number, an object with a "min" and "max" field or an array of two
+// numbers.
+func (r *Range) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return fmt.Errorf("decode range: empty value")
+	}
+	switch b[0] {
+	case '{':
+		var v struct {
+			Min *float64 `json:"min"`
+			Max *float64 `json:"max"`
+		}
+		if err := json.Unmarshal(b, &v); err != nil {
+			return fmt.Errorf("decode range: %w", err)
+		}
+		if v.Min != nil {
+			r.Min = *v.Min
+		}
+		r.Max = r.Min
+		if v.Max != nil {
+			r.Max = *v.Max
+		}
+	case '[':
+		var v []float64
+		if err := json.Unmarshal(b, &v); err != nil {
+			return fmt.Errorf("decode range: %w", err)
+		}
+		if len(v) != 2 {
+			return fmt.Errorf("decode range: expected array of 2 numbers, got %v", len(v))
+		}
+		r.Min, r.Max = v[0], v[1]
+	default:
+		var v float64
+		if err := json.Unmarshal(b, &v); err != nil {
+			return fmt.Errorf("decode range: %w", err)
+		}
+		*r = Exactly(v)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package loot
+
+import (
+	"embed"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"io/fs"
+	"path"
+	"strings"
+	"sync"
+)
+
+// FishingTable is the name of the loot table used for the loot caught by fishing.
+const FishingTable = "loot_tables/gameplay/fishing.json"
+
+var (
+	//go:embed loot_tables
+	defaultTables embed.FS
+
+	tablesMu sync.RWMutex
+	// tables holds all registered loot tables, indexed by their name.
+	tables = map[string]Table{}
+	// defaults is used to register the default loot tables once, before any loot tables are registered or looked
+	// up.
+	defaults sync.Once
+)
+
+// Register registers a Table under the name passed, such as "loot_tables/gameplay/fishing.json". Registering a
+// Table with a name that is already registered, for example one of the default loot tables, overwrites it.
+func Register(name string, t Table) {
+	defaults.Do(registerDefaults)
+	register(name, t)
+}
+
+// Lookup looks up the Table registered under the name passed. If found, the Table is returned and the bool is
+// true.
+func Lookup(name string) (Table, bool) {
+	defaults.Do(registerDefaults)
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	t, ok := tables[path.Clean(name)]
+	return t, ok
+}
+
+// BlockTable looks up the Table registered for the block passed. Block loot tables are registered under the name
+// "loot_tables/blocks/<name>.json", where name is the name of the block without the "minecraft:" prefix. If
+// found, the loot table is used for the drops of the block instead of its default drops.
+func BlockTable(b world.Block) (Table, bool) {
+	name, _ := b.EncodeBlock()
+	return Lookup("loot_tables/blocks/" + strings.TrimPrefix(name, "minecraft:") + ".json")
+}
+
+// EntityTable looks up the Table registered for the entity type passed. Entity loot tables are registered under
+// the name "loot_tables/entities/<name>.json", where name is the name of the entity type without the
+// "minecraft:" prefix.
+func EntityTable(t world.EntityType) (Table, bool) {
+	return Lookup("loot_tables/entities/" + strings.TrimPrefix(t.EncodeEntity(), "minecraft:") + ".json")
+}
+
+// Load decodes all JSON files found in the file system passed as loot tables and registers them, overwriting any
+// loot tables previously registered under the same name. The name of each loot table is its path in the file
+// system, so the file system should generally be the root of a behaviour pack, holding a loot_tables directory.
+func Load(fsys fs.FS) error {
+	defaults.Do(registerDefaults)
+	return load(fsys)
+}
+
+// load decodes and registers all JSON files in the file system passed as loot tables.
+func load(fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || path.Ext(p) != ".json" {
+			return nil
+		}
+		data, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return fmt.Errorf("read loot table %v: %w", p, err)
+		}
+		t, err := Decode(data)
+		if err != nil {
+			return fmt.Errorf("load loot table %v: %w", p, err)
+		}
+		register(p, t)
+		return nil
+	})
+}
+
+// register registers a Table under the name passed.
+func register(name string, t Table) {
+	tablesMu.Lock()
+	defer tablesMu.Unlock()
+	tables[path.Clean(name)] = t
+}
+
+// registerDefaults registers the default loot tables embedded in the binary.
+func registerDefaults() {
+	if err := load(defaultTables); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,38 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"strings"
+)
+
+// Minecart is an item that may be placed on rails to create a minecart entity, which players and other
+// entities are able to ride.
+type Minecart struct{}
+
+// MaxCount ...
+func (Minecart) MaxCount() int {
+	return 1
+}
+
+// UseOnBlock places the minecart on the rail clicked.
+func (Minecart) UseOnBlock(pos cube.Pos, _ cube.Face, _ mgl64.Vec3, w *world.World, _ User, ctx *UseContext) bool {
+	return placeMinecart(pos, w, w.EntityRegistry().Config().Minecart, ctx)
+}
+
+// EncodeItem ...
+func (Minecart) EncodeItem() (name string, meta int16) {
+	return "minecraft:minecart", 0
+}
+
+// placeMinecart creates a minecart using the function passed and places it on the rail at the position passed.
+// False is returned if the block at the position is not a rail.
+func placeMinecart(pos cube.Pos, w *world.World, create func(pos mgl64.Vec3) world.Entity, ctx *UseContext) bool {
+	if name, _ := w.Block(pos).EncodeBlock(); !strings.HasSuffix(name, "rail") {
+		return false
+	}
+	w.AddEntity(create(pos.Vec3Middle().Add(mgl64.Vec3{0, 0.0625})))
+	ctx.SubtractFromCount(1)
+	return true
+}
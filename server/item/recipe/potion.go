@@ -0,0 +1,17 @@
+package recipe
+
+import (
+	"github.com/df-mc/dragonfly/server/internal/brewing"
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// Brew attempts to brew the base passed using the reagent passed, looking for a matching Potion or
+// PotionContainerChange recipe. If one was found, the potion produced is returned along with true.
+func Brew(base, reagent item.Stack) (item.Stack, bool) {
+	return brewing.Brew(base, reagent)
+}
+
+// Reagent checks if the item stack passed is used as reagent in any Potion or PotionContainerChange recipe.
+func Reagent(s item.Stack) bool {
+	return brewing.Reagent(s)
+}
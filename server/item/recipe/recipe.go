@@ -2,6 +2,7 @@ package recipe
 
 import (
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
 )
 
 // Recipe is implemented by all recipe types.
@@ -49,6 +50,39 @@ func NewSmithing(base, addition, template, output item.Stack, block string) Smit
 	}}
 }
 
+// Potion is a potion mixing recipe which may be brewed in a brewing stand. It turns a base potion into a different
+// potion using a reagent.
+type Potion struct {
+	recipe
+}
+
+// NewPotion creates a new potion recipe and returns it. The base passed is the potion that is brewed, the reagent the
+// item that is added to it and the output the potion that the base turns into.
+func NewPotion(base, reagent, output item.Stack) Potion {
+	return Potion{recipe: recipe{
+		input:  []item.Stack{base, reagent},
+		output: []item.Stack{output},
+		block:  "brewing_stand",
+	}}
+}
+
+// PotionContainerChange is a recipe which may be brewed in a brewing stand to change the container of a potion, such
+// as turning a potion into a splash potion. The type of the potion is kept when brewed.
+type PotionContainerChange struct {
+	recipe
+}
+
+// NewPotionContainerChange creates a new potion container change recipe and returns it. The input and output passed
+// are the potion items of which the type is ignored, and the reagent is the item added to turn the input into the
+// output.
+func NewPotionContainerChange(input, reagent, output world.Item) PotionContainerChange {
+	return PotionContainerChange{recipe: recipe{
+		input:  []item.Stack{item.NewStack(input, 1), item.NewStack(reagent, 1)},
+		output: []item.Stack{item.NewStack(output, 1)},
+		block:  "brewing_stand",
+	}}
+}
+
 // Shaped is a recipe that has a specific shape that must be used to craft the output of the recipe.
 type Shaped struct {
 	recipe
@@ -1,6 +1,7 @@
 package recipe
 
 import (
+	"github.com/df-mc/dragonfly/server/internal/brewing"
 	"golang.org/x/exp/slices"
 )
 
@@ -15,4 +16,11 @@ func Recipes() []Recipe {
 // Register registers a new recipe.
 func Register(recipe Recipe) {
 	recipes = append(recipes, recipe)
+	// Potion recipes are additionally indexed by their reagent, so that brewing stands can look them up quickly.
+	switch r := recipe.(type) {
+	case Potion:
+		brewing.RegisterPotion(r.input[0].Item(), r.input[1].Item(), r.output[0])
+	case PotionContainerChange:
+		brewing.RegisterContainerChange(r.input[0].Item(), r.input[1].Item(), r.output[0].Item())
+	}
 }
@@ -101,4 +101,6 @@ func init() {
 			priority: uint32(s.Priority),
 		}})
 	}
+
+	registerVanillaPotions()
 }
@@ -0,0 +1,78 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// Shield is a tool used for protecting the user against attacks. While raised, which happens when the user is
+// sneaking, a shield blocks melee and projectile damage coming from in front of the user. Shields may be disabled
+// for a short duration when hit by an axe.
+type Shield struct {
+	// Banner is the banner applied to the shield, such as a block.Banner. The patterns and base colour of the banner
+	// are shown on the shield. If nil, the shield has no banner applied.
+	Banner world.Item
+}
+
+// ShieldDisableDuration is the duration that a shield is disabled for after being hit by an axe.
+const ShieldDisableDuration = time.Second * 5
+
+// MaxCount always returns 1.
+func (Shield) MaxCount() int {
+	return 1
+}
+
+// OffHand ...
+func (Shield) OffHand() bool {
+	return true
+}
+
+// DurabilityInfo ...
+func (Shield) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability: 336,
+		BrokenItem:    simpleItem(Stack{}),
+	}
+}
+
+// RepairableBy ...
+func (Shield) RepairableBy(i Stack) bool {
+	if planks, ok := i.Item().(interface{ RepairsWoodTools() bool }); ok {
+		return planks.RepairsWoodTools()
+	}
+	return false
+}
+
+// FuelInfo ...
+func (Shield) FuelInfo() FuelInfo {
+	return newFuelInfo(time.Second * 15)
+}
+
+// EncodeItem ...
+func (Shield) EncodeItem() (name string, meta int16) {
+	return "minecraft:shield", 0
+}
+
+// DecodeNBT ...
+func (s Shield) DecodeNBT(data map[string]any) any {
+	s.Banner = nil
+	if _, ok := data["Base"]; !ok {
+		return s
+	}
+	if banner, ok := world.ItemByName("minecraft:banner", 0); ok {
+		if nbt, ok := banner.(world.NBTer); ok {
+			s.Banner = nbt.DecodeNBT(data).(world.Item)
+		}
+	}
+	return s
+}
+
+// EncodeNBT ...
+func (s Shield) EncodeNBT() map[string]any {
+	nbt, ok := s.Banner.(world.NBTer)
+	if !ok {
+		return nil
+	}
+	data := nbt.EncodeNBT()
+	return map[string]any{"Base": data["Base"], "Patterns": data["Patterns"], "Type": data["Type"]}
+}
@@ -171,6 +171,16 @@ func (s Stack) AttackDamage() float64 {
 	return 1.0
 }
 
+// WithItem returns a copy of the Stack with the item type passed. All other properties of the Stack, such as its
+// count, damage, custom name, lore and enchantments, are retained.
+func (s Stack) WithItem(t world.Item) Stack {
+	if t == nil {
+		panic("cannot have a stack with item type nil")
+	}
+	s.item = t
+	return s
+}
+
 // WithCustomName returns a copy of the Stack with the custom name passed. The custom name is formatted
 // according to the rules of fmt.Sprintln.
 func (s Stack) WithCustomName(a ...any) Stack {
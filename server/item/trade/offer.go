@@ -0,0 +1,63 @@
+package trade
+
+import (
+	"github.com/df-mc/dragonfly/server/item"
+	"math"
+)
+
+// Offer is a single trade offered by a Trader. A customer pays the Input and, optionally, the SecondInput to
+// receive the Output.
+type Offer struct {
+	// Input is the first item that must be paid for the Output. Its count is the base price of the offer, which
+	// may be raised by the Demand for the offer.
+	Input item.Stack
+	// SecondInput is the second item that must be paid for the Output. It may be left empty if the offer only
+	// requires a single input.
+	SecondInput item.Stack
+	// Output is the item received by the customer in exchange for the inputs.
+	Output item.Stack
+
+	// Uses is the amount of times that the offer has been used since the Trader last restocked.
+	Uses int
+	// MaxUses is the amount of times that the offer may be used before the Trader has to restock. If MaxUses is
+	// 0, the offer may be used an unlimited amount of times.
+	MaxUses int
+	// Demand is the demand for the offer. A positive demand raises the price of the Input by PriceMultiplier
+	// for every point of demand.
+	Demand int
+	// PriceMultiplier is the fraction of the base price of the Input that is added to the price for every
+	// point of Demand.
+	PriceMultiplier float64
+
+	// Tier is the tier of the Trader at which the offer is unlocked, ranging from 0 to 4.
+	Tier int
+	// TraderExperience is the experience that the Trader gains when the offer is used.
+	TraderExperience int
+	// Experience is the amount of experience awarded to the customer when the offer is used.
+	Experience int
+}
+
+// Disabled checks if the offer has been used up and may not be used until the Trader restocks.
+func (o Offer) Disabled() bool {
+	return o.MaxUses > 0 && o.Uses >= o.MaxUses
+}
+
+// Price returns the Input of the offer with its count adjusted for the Demand of the offer. The price is never
+// lower than 1 and never higher than the max count of the Input.
+func (o Offer) Price() item.Stack {
+	if o.Input.Empty() {
+		return o.Input
+	}
+	base := o.Input.Count()
+	count := base + int(math.Max(0, math.Floor(float64(base*o.Demand)*o.PriceMultiplier)))
+	count = int(math.Max(1, math.Min(float64(count), float64(o.Input.MaxCount()))))
+	return o.Input.Grow(count - base)
+}
+
+// Restock returns the offer with its uses reset. The Demand of the offer is updated depending on how often it
+// was used since the previous restock.
+func (o Offer) Restock() Offer {
+	o.Demand = int(math.Max(0, float64(o.Demand+o.Uses-(o.MaxUses-o.Uses))))
+	o.Uses = 0
+	return o
+}
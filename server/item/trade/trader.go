@@ -0,0 +1,103 @@
+package trade
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"sync"
+)
+
+// Trader represents something that customers are able to trade with, such as a villager. The offers of a
+// Trader are shown in a trade window.
+type Trader interface {
+	// TradeName returns the name of the Trader displayed at the top of the trade window.
+	TradeName() string
+	// Offers returns the offers of the Trader. Offers with a Tier higher than the tier of the Trader are shown
+	// as locked in the trade window.
+	Offers() []Offer
+	// Experience returns the trading experience of the Trader, which determines its tier.
+	Experience() int
+	// Trade is called when the customer passed uses the offer at the index passed. The customer has already
+	// been verified to have paid the price of the offer. If false is returned, the trade is cancelled.
+	Trade(customer world.Entity, index int) bool
+}
+
+// tierExperience holds the amount of experience that a Trader needs to reach each tier.
+var tierExperience = [...]int{0, 10, 70, 150, 250}
+
+// Tier returns the tier, ranging from 0 to 4, that a Trader with the experience passed has.
+func Tier(experience int) int {
+	for tier := len(tierExperience) - 1; tier > 0; tier-- {
+		if experience >= tierExperience[tier] {
+			return tier
+		}
+	}
+	return 0
+}
+
+// TierExperience returns the amount of experience that a Trader needs to reach the tier passed. Tiers outside
+// the range 0-4 are clamped to it.
+func TierExperience(tier int) int {
+	if tier < 0 {
+		tier = 0
+	} else if tier >= len(tierExperience) {
+		tier = len(tierExperience) - 1
+	}
+	return tierExperience[tier]
+}
+
+// Custom is a Trader with a fixed list of offers that is not backed by an entity. It may be used to open a
+// trade window with arbitrary offers, for example for a shop.
+type Custom struct {
+	name string
+	f    func(customer world.Entity, o Offer) bool
+
+	mu     sync.Mutex
+	offers []Offer
+}
+
+// NewCustom creates a Custom Trader with the name and offers passed. The tiers of the offers passed are ignored:
+// all offers are unlocked. f is called every time a customer uses an offer and may return false to cancel the
+// trade. f may be nil.
+func NewCustom(name string, offers []Offer, f func(customer world.Entity, o Offer) bool) *Custom {
+	c := &Custom{name: name, f: f, offers: make([]Offer, len(offers))}
+	for i, o := range offers {
+		o.Tier = 0
+		c.offers[i] = o
+	}
+	return c
+}
+
+// TradeName ...
+func (c *Custom) TradeName() string {
+	return c.name
+}
+
+// Offers ...
+func (c *Custom) Offers() []Offer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Offer(nil), c.offers...)
+}
+
+// Experience always returns 0.
+func (c *Custom) Experience() int {
+	return 0
+}
+
+// Trade ...
+func (c *Custom) Trade(customer world.Entity, index int) bool {
+	c.mu.Lock()
+	if index < 0 || index >= len(c.offers) || c.offers[index].Disabled() {
+		c.mu.Unlock()
+		return false
+	}
+	o := c.offers[index]
+	c.mu.Unlock()
+
+	if c.f != nil && !c.f(customer, o) {
+		return false
+	}
+	c.mu.Lock()
+	c.offers[index].Uses++
+	c.mu.Unlock()
+	return true
+}
@@ -0,0 +1,107 @@
+package item
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+	"time"
+)
+
+// Trident is a weapon that may be used both in melee and as a ranged weapon by throwing it. Depending on its
+// enchantments, a thrown trident may return to its thrower, summon lightning or, when used in water or rain,
+// launch its user forward instead.
+type Trident struct{}
+
+// MaxCount always returns 1.
+func (Trident) MaxCount() int {
+	return 1
+}
+
+// AttackDamage ...
+func (Trident) AttackDamage() float64 {
+	return 8
+}
+
+// DurabilityInfo ...
+func (Trident) DurabilityInfo() DurabilityInfo {
+	return DurabilityInfo{
+		MaxDurability:    251,
+		BrokenItem:       simpleItem(Stack{}),
+		AttackDurability: 1,
+		BreakDurability:  2,
+	}
+}
+
+// EnchantmentValue ...
+func (Trident) EnchantmentValue() int {
+	return 1
+}
+
+// Release throws the trident if it was held for at least half a second. If the trident is enchanted with riptide,
+// the releaser is launched forward instead, which is only possible in water or rain.
+func (Trident) Release(releaser Releaser, duration time.Duration, ctx *UseContext) {
+	if duration < time.Second/2 {
+		// The trident must be held for at least ten ticks.
+		return
+	}
+	held, _ := releaser.HeldItems()
+	w, creative := releaser.World(), releaser.GameMode().CreativeInventory()
+
+	for _, enchant := range held.Enchantments() {
+		r, ok := enchant.Type().(interface{ LaunchForce(int) float64 })
+		if !ok {
+			continue
+		}
+		pos := cube.PosFromVec3(releaser.Position())
+		if !w.RainingAt(pos) && !inWater(w, pos) {
+			// Riptide tridents can only be used in water or rain and are never thrown.
+			return
+		}
+		if v, ok := releaser.(interface{ SetVelocity(mgl64.Vec3) }); ok {
+			v.SetVelocity(releaser.Rotation().Vec3().Mul(r.LaunchForce(enchant.Level())))
+		}
+		ctx.DamageItem(1)
+		w.PlaySound(releaser.Position(), sound.TridentRiptide{Level: enchant.Level()})
+		return
+	}
+
+	trident := held.Grow(1 - held.Count())
+	if !creative {
+		d := 1
+		for _, enchant := range held.Enchantments() {
+			if u, ok := enchant.Type().(interface {
+				Reduce(world.Item, int, int) int
+			}); ok {
+				d = u.Reduce(Trident{}, enchant.Level(), d)
+			}
+		}
+		if trident = trident.Damage(d); trident.Empty() {
+			// The trident broke as a result of throwing it.
+			ctx.DamageItem(1)
+			return
+		}
+		ctx.SubtractFromCount(1)
+	}
+
+	rot := releaser.Rotation()
+	create := w.EntityRegistry().Config().Trident
+	w.AddEntity(create(eyePosition(releaser), rot.Vec3().Mul(2.5), cube.Rotation{-rot[0], -rot[1]}, trident, releaser, !creative))
+	w.PlaySound(releaser.Position(), sound.TridentThrow{})
+}
+
+// Requirements returns no requirements, as a trident may always be released.
+func (Trident) Requirements() []Stack {
+	return nil
+}
+
+// EncodeItem ...
+func (Trident) EncodeItem() (name string, meta int16) {
+	return "minecraft:trident", 0
+}
+
+// inWater checks if the block at the position passed in the world holds water.
+func inWater(w *world.World, pos cube.Pos) bool {
+	l, ok := w.Liquid(pos)
+	return ok && l.LiquidType() == "water"
+}
@@ -29,31 +29,65 @@ func (uc UserConfig) listenerFunc(conf Config) (Listener, error) {
 		Biomes:                 biomes(),
 		TexturePacksRequired:   conf.ResourcesRequired,
 	}
-	l, err := cfg.Listen("raknet", uc.Network.Address)
+	var p *proxy
+	if conf.Proxy != nil {
+		var err error
+		if p, err = newProxy(*conf.Proxy); err != nil {
+			return nil, fmt.Errorf("create proxy: %w", err)
+		}
+		cfg.PacketFunc = p.packetFunc
+	}
+	network := "raknet"
+	if conf.queryResponder != nil {
+		network = conf.queryResponder.Bind(uc.Network.Address)
+	}
+	l, err := cfg.Listen(network, uc.Network.Address)
 	if err != nil {
 		return nil, fmt.Errorf("create minecraft listener: %w", err)
 	}
 	conf.Log.Infof("Server running on %v.\n", l.Addr())
-	return listener{l}, nil
+	return listener{Listener: l, proxy: p, log: conf.Log}, nil
 }
 
 // listener is a Listener implementation that wraps around a minecraft.Listener so that it can be listened on by
 // Server.
 type listener struct {
 	*minecraft.Listener
+	// proxy is non-nil if Config.Proxy is set. It verifies and applies the
+	// identities forwarded by proxies for every connection accepted.
+	proxy *proxy
+	log   Logger
 }
 
 // Accept blocks until the next connection is established and returns it. An error is returned if the Listener was
 // closed using Close.
 func (l listener) Accept() (session.Conn, error) {
-	conn, err := l.Listener.Accept()
-	if err != nil {
-		return nil, err
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		conn := c.(*minecraft.Conn)
+		if l.proxy == nil {
+			return conn, nil
+		}
+		pc, err := l.proxy.forward(conn)
+		if err != nil {
+			// The connection did not come from a trusted proxy or did not have a valid forwarded identity: Refuse
+			// the connection and proceed to the next one.
+			l.log.Warnf("refused connection from %v: %v", conn.RemoteAddr(), err)
+			_ = l.Listener.Disconnect(conn, "Failed to verify your connection.")
+			continue
+		}
+		l.log.Debugf("connection from proxy %v forwarded for %v (XUID %q)", conn.RemoteAddr(), pc.RemoteAddr(), pc.IdentityData().XUID)
+		return pc, nil
 	}
-	return conn.(session.Conn), err
 }
 
 // Disconnect disconnects a connection from the Listener with a reason.
 func (l listener) Disconnect(conn session.Conn, reason string) error {
+	if pc, ok := conn.(*proxiedConn); ok {
+		return l.Listener.Disconnect(pc.Conn, reason)
+	}
 	return l.Listener.Disconnect(conn.(*minecraft.Conn), reason)
 }
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// MetricsConfig holds settings for the metrics exporter of a Server. Metrics
+// are exported over HTTP in the Prometheus text exposition format.
+type MetricsConfig struct {
+	// Address is the TCP address that the HTTP server exporting metrics
+	// listens on, such as ":9100".
+	Address string
+	// Path is the HTTP path under which metrics are exported. If empty,
+	// "/metrics" is used.
+	Path string
+}
+
+// startMetrics starts the HTTP server exporting metrics if enabled in the
+// Config.
+func (srv *Server) startMetrics() {
+	if srv.conf.Metrics == nil {
+		return
+	}
+	path := srv.conf.Metrics.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	l, err := net.Listen("tcp", srv.conf.Metrics.Address)
+	if err != nil {
+		srv.conf.Log.Fatalf("create metrics listener: %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(path, srv.MetricsHandler())
+	srv.metrics = &http.Server{Handler: mux}
+
+	srv.conf.Log.Infof("Metrics exported on http://%v%v.", l.Addr(), path)
+	go func() {
+		if err := srv.metrics.Serve(l); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			srv.conf.Log.Errorf("metrics: %v", err)
+		}
+	}()
+}
+
+// MetricsHandler returns an http.Handler that responds with the metrics of the
+// Server in the Prometheus text exposition format. It may be used to export
+// metrics on an existing HTTP server, as an alternative to Config.Metrics.
+//
+// Metrics are exported per world (with world and dimension labels) for the
+// overworld, nether and end, and per player session (with a player label).
+func (srv *Server) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		buf := bufio.NewWriter(w)
+		srv.writeMetrics(buf)
+		_ = buf.Flush()
+	})
+}
+
+// writeMetrics writes all metrics of the Server to the writer passed.
+func (srv *Server) writeMetrics(w *bufio.Writer) {
+	players := srv.Players()
+	m := metricWriter{w: w}
+	m.family("dragonfly_players_online", "gauge", "Amount of players online.", sample{v: float64(len(players))})
+	m.family("dragonfly_players_max", "gauge", "Maximum amount of players allowed online.", sample{v: float64(srv.MaxPlayerCount())})
+
+	worlds := []*world.World{srv.world, srv.nether, srv.end}
+	stats := make([]world.Stats, len(worlds))
+	for i, w := range worlds {
+		stats[i] = w.Stats()
+	}
+	worldSamples := func(f func(s world.Stats) float64) []sample {
+		samples := make([]sample, len(worlds))
+		for i, w := range worlds {
+			samples[i] = sample{labels: []string{"world", w.Name(), "dimension", fmt.Sprint(w.Dimension())}, v: f(stats[i])}
+		}
+		return samples
+	}
+	m.family("dragonfly_world_ticks_total", "counter", "Total amount of ticks performed.", worldSamples(func(s world.Stats) float64 {
+		return float64(s.Ticks)
+	})...)
+	m.family("dragonfly_world_tick_duration_seconds_total", "counter", "Total time spent ticking.", worldSamples(func(s world.Stats) float64 {
+		return s.TickDuration.Seconds()
+	})...)
+	m.family("dragonfly_world_last_tick_duration_seconds", "gauge", "Duration of the most recent tick.", worldSamples(func(s world.Stats) float64 {
+		return s.LastTickDuration.Seconds()
+	})...)
+	m.family("dragonfly_world_tps", "gauge", "Average ticks per second over the last 5 seconds.", worldSamples(func(s world.Stats) float64 {
+		return s.TPS
+	})...)
+	m.family("dragonfly_world_loaded_chunks", "gauge", "Amount of chunks currently loaded.", worldSamples(func(s world.Stats) float64 {
+		return float64(s.LoadedChunks)
+	})...)
+	m.family("dragonfly_world_entities", "gauge", "Amount of entities currently in the world.", worldSamples(func(s world.Stats) float64 {
+		return float64(s.Entities)
+	})...)
+	m.family("dragonfly_world_viewers", "gauge", "Amount of chunk loaders currently in the world.", worldSamples(func(s world.Stats) float64 {
+		return float64(s.Viewers)
+	})...)
+	m.family("dragonfly_world_chunks_sent_total", "counter", "Total amount of chunks sent to viewers.", worldSamples(func(s world.Stats) float64 {
+		return float64(s.ChunksSent)
+	})...)
+
+	netStats := make([]session.Stats, len(players))
+	var hits, misses float64
+	for i, p := range players {
+		netStats[i] = p.NetworkStats()
+		hits, misses = hits+float64(netStats[i].BlobHits), misses+float64(netStats[i].BlobMisses)
+	}
+	playerSamples := func(f func(p *player.Player, s session.Stats) float64) []sample {
+		samples := make([]sample, len(players))
+		for i, p := range players {
+			samples[i] = sample{labels: []string{"player", p.Name()}, v: f(p, netStats[i])}
+		}
+		return samples
+	}
+	m.family("dragonfly_session_packets_received_total", "counter", "Total amount of packets received from the client.", playerSamples(func(_ *player.Player, s session.Stats) float64 {
+		return float64(s.PacketsReceived)
+	})...)
+	m.family("dragonfly_session_packets_sent_total", "counter", "Total amount of packets sent to the client.", playerSamples(func(_ *player.Player, s session.Stats) float64 {
+		return float64(s.PacketsSent)
+	})...)
+	m.family("dragonfly_session_latency_seconds", "gauge", "Latency of the connection of the client.", playerSamples(func(p *player.Player, _ session.Stats) float64 {
+		return p.Latency().Seconds()
+	})...)
+	m.family("dragonfly_session_chunk_blobs_sent_total", "counter", "Total amount of chunk blobs sent to the client as a hash.", playerSamples(func(_ *player.Player, s session.Stats) float64 {
+		return float64(s.BlobsSent)
+	})...)
+	m.family("dragonfly_session_chunk_cache_hits_total", "counter", "Total amount of chunk blobs found in the cache of the client.", playerSamples(func(_ *player.Player, s session.Stats) float64 {
+		return float64(s.BlobHits)
+	})...)
+	m.family("dragonfly_session_chunk_cache_misses_total", "counter", "Total amount of chunk blobs missing from the cache of the client.", playerSamples(func(_ *player.Player, s session.Stats) float64 {
+		return float64(s.BlobMisses)
+	})...)
+
+	ratio := 0.0
+	if hits+misses > 0 {
+		ratio = hits / (hits + misses)
+	}
+	m.family("dragonfly_chunk_cache_hit_ratio", "gauge", "Ratio of chunk blobs found in the cache of clients currently online.", sample{v: ratio})
+}
+
+// sample is a single sample of a metric. labels holds label names and values
+// alternately.
+type sample struct {
+	labels []string
+	v      float64
+}
+
+// metricWriter writes metrics in the Prometheus text exposition format.
+type metricWriter struct {
+	w *bufio.Writer
+}
+
+// family writes a metric family with the name, type and help text passed,
+// followed by all samples passed.
+func (m metricWriter) family(name, typ, help string, samples ...sample) {
+	_, _ = fmt.Fprintf(m.w, "# HELP %v %v\n# TYPE %v %v\n", name, help, name, typ)
+	for _, s := range samples {
+		_, _ = m.w.WriteString(name)
+		if len(s.labels) > 0 {
+			_ = m.w.WriteByte('{')
+			for i := 0; i < len(s.labels); i += 2 {
+				if i > 0 {
+					_ = m.w.WriteByte(',')
+				}
+				_, _ = fmt.Fprintf(m.w, "%v=\"%v\"", s.labels[i], labelEscaper.Replace(s.labels[i+1]))
+			}
+			_ = m.w.WriteByte('}')
+		}
+		_ = m.w.WriteByte(' ')
+		_, _ = m.w.WriteString(strconv.FormatFloat(s.v, 'g', -1, 64))
+		_ = m.w.WriteByte('\n')
+	}
+}
+
+// labelEscaper escapes label values as required by the text exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
@@ -1,14 +1,22 @@
 package player
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"time"
 )
 
 // Data is a struct that contains all the data of that player to be passed on to the Provider and saved.
+//
+// Interactions in progress, such as an item being used (eating, charging a bow or crossbow or raising a shield
+// held in the offhand), are deliberately not part of Data. These interactions are driven by the client, which
+// always stops them when it disconnects, so restoring them when the player joins would leave the server
+// thinking an item is in use while the client does not. The items involved, including the offhand item and the
+// projectile a crossbow is loaded with, are stored as part of the Inventory.
 type Data struct {
 	// UUID is the player's unique identifier for their account.
 	UUID uuid.UUID
@@ -56,6 +64,28 @@ type Data struct {
 	FallDistance float64
 	// World is the world the player was last in.
 	World *world.World
+	// SpawnPositions holds the spawn positions of the player in every world that the player has a spawn position
+	// set in, as set using Player.SetSpawnPosition. Providers store spawn positions by the name and dimension of
+	// the world, so that worlds with the same dimension don't overwrite each other's spawn positions.
+	SpawnPositions map[*world.World]cube.Pos
+	// BedSpawns holds the worlds in SpawnPositions in which the spawn position was set by sleeping in a bed. These
+	// spawn positions are removed if the bed is no longer there when the player respawns.
+	BedSpawns map[*world.World]bool
+	// NameTag and ScoreTag are the name tag and score tag displayed above the player. If NameTag is empty, the
+	// name of the player is used as name tag.
+	NameTag, ScoreTag string
+	// Cooldowns holds the remaining cooldowns of items, indexed by the name of the item as returned by
+	// world.Item.EncodeItem.
+	Cooldowns map[string]time.Duration
+	// DeathPosition is the position the player last died at, and DeathDimension the dimension it died in. If
+	// the player has never died, DeathPosition is nil.
+	DeathPosition  *mgl64.Vec3
+	DeathDimension world.Dimension
+	// Flying specifies if the player was flying.
+	Flying bool
+	// CustomData holds the custom data set for the player using Player.SetCustomData, indexed by keys in the
+	// format 'namespace:key'.
+	CustomData map[string][]byte
 }
 
 // InventoryData is a struct that contains all data of the player inventories.
@@ -6,6 +6,7 @@ import (
 	"github.com/df-mc/dragonfly/server/event"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"net"
@@ -18,6 +19,17 @@ type Handler interface {
 	// HandleMove handles the movement of a player. ctx.Cancel() may be called to cancel the movement event.
 	// The new position, yaw and pitch are passed.
 	HandleMove(ctx *event.Context, newPos mgl64.Vec3, newYaw, newPitch float64)
+	// HandleMovementViolation handles movement of a player that failed validation by the server, such as
+	// movement that is faster than expected or movement through blocks. It is only called if movement
+	// validation is enabled using server.Config.MovementValidation. valid is the last valid position of the
+	// player and attempted the position the player tried to move to. ctx.Cancel() may be called to allow the
+	// movement anyway. If not cancelled, the player is teleported back to the valid position.
+	HandleMovementViolation(ctx *event.Context, valid, attempted mgl64.Vec3)
+	// HandleAttackViolation handles an attack of a player on an entity that failed validation by the server,
+	// for example because the entity was out of reach, obstructed by blocks or because the player clicked
+	// too fast. It is only called if combat validation is enabled using server.Config.CombatValidation.
+	// ctx.Cancel() may be called to allow the attack anyway.
+	HandleAttackViolation(ctx *event.Context, e world.Entity, violation session.AttackViolation)
 	// HandleJump handles the player jumping.
 	HandleJump()
 	// HandleTeleport handles the teleportation of a player. ctx.Cancel() may be called to cancel it.
@@ -138,34 +150,36 @@ type NopHandler struct{}
 // Compile time check to make sure NopHandler implements Handler.
 var _ Handler = NopHandler{}
 
-func (NopHandler) HandleItemDrop(*event.Context, world.Entity)                                {}
-func (NopHandler) HandleMove(*event.Context, mgl64.Vec3, float64, float64)                    {}
-func (NopHandler) HandleJump()                                                                {}
-func (NopHandler) HandleTeleport(*event.Context, mgl64.Vec3)                                  {}
-func (NopHandler) HandleChangeWorld(*world.World, *world.World)                               {}
-func (NopHandler) HandleToggleSprint(*event.Context, bool)                                    {}
-func (NopHandler) HandleToggleSneak(*event.Context, bool)                                     {}
-func (NopHandler) HandleCommandExecution(*event.Context, cmd.Command, []string)               {}
-func (NopHandler) HandleTransfer(*event.Context, *net.UDPAddr)                                {}
-func (NopHandler) HandleChat(*event.Context, *string)                                         {}
-func (NopHandler) HandleSkinChange(*event.Context, *skin.Skin)                                {}
-func (NopHandler) HandleStartBreak(*event.Context, cube.Pos)                                  {}
-func (NopHandler) HandleBlockBreak(*event.Context, cube.Pos, *[]item.Stack, *int)             {}
-func (NopHandler) HandleBlockPlace(*event.Context, cube.Pos, world.Block)                     {}
-func (NopHandler) HandleBlockPick(*event.Context, cube.Pos, world.Block)                      {}
-func (NopHandler) HandleSignEdit(*event.Context, bool, string, string)                        {}
-func (NopHandler) HandleItemPickup(*event.Context, item.Stack)                                {}
-func (NopHandler) HandleItemUse(*event.Context)                                               {}
-func (NopHandler) HandleItemUseOnBlock(*event.Context, cube.Pos, cube.Face, mgl64.Vec3)       {}
-func (NopHandler) HandleItemUseOnEntity(*event.Context, world.Entity)                         {}
-func (NopHandler) HandleItemConsume(*event.Context, item.Stack)                               {}
-func (NopHandler) HandleItemDamage(*event.Context, item.Stack, int)                           {}
-func (NopHandler) HandleAttackEntity(*event.Context, world.Entity, *float64, *float64, *bool) {}
-func (NopHandler) HandleExperienceGain(*event.Context, *int)                                  {}
-func (NopHandler) HandlePunchAir(*event.Context)                                              {}
-func (NopHandler) HandleHurt(*event.Context, *float64, *time.Duration, world.DamageSource)    {}
-func (NopHandler) HandleHeal(*event.Context, *float64, world.HealingSource)                   {}
-func (NopHandler) HandleFoodLoss(*event.Context, int, *int)                                   {}
-func (NopHandler) HandleDeath(world.DamageSource, *bool)                                      {}
-func (NopHandler) HandleRespawn(*mgl64.Vec3, **world.World)                                   {}
-func (NopHandler) HandleQuit()                                                                {}
+func (NopHandler) HandleItemDrop(*event.Context, world.Entity)                                 {}
+func (NopHandler) HandleMove(*event.Context, mgl64.Vec3, float64, float64)                     {}
+func (NopHandler) HandleMovementViolation(*event.Context, mgl64.Vec3, mgl64.Vec3)              {}
+func (NopHandler) HandleAttackViolation(*event.Context, world.Entity, session.AttackViolation) {}
+func (NopHandler) HandleJump()                                                                 {}
+func (NopHandler) HandleTeleport(*event.Context, mgl64.Vec3)                                   {}
+func (NopHandler) HandleChangeWorld(*world.World, *world.World)                                {}
+func (NopHandler) HandleToggleSprint(*event.Context, bool)                                     {}
+func (NopHandler) HandleToggleSneak(*event.Context, bool)                                      {}
+func (NopHandler) HandleCommandExecution(*event.Context, cmd.Command, []string)                {}
+func (NopHandler) HandleTransfer(*event.Context, *net.UDPAddr)                                 {}
+func (NopHandler) HandleChat(*event.Context, *string)                                          {}
+func (NopHandler) HandleSkinChange(*event.Context, *skin.Skin)                                 {}
+func (NopHandler) HandleStartBreak(*event.Context, cube.Pos)                                   {}
+func (NopHandler) HandleBlockBreak(*event.Context, cube.Pos, *[]item.Stack, *int)              {}
+func (NopHandler) HandleBlockPlace(*event.Context, cube.Pos, world.Block)                      {}
+func (NopHandler) HandleBlockPick(*event.Context, cube.Pos, world.Block)                       {}
+func (NopHandler) HandleSignEdit(*event.Context, bool, string, string)                         {}
+func (NopHandler) HandleItemPickup(*event.Context, item.Stack)                                 {}
+func (NopHandler) HandleItemUse(*event.Context)                                                {}
+func (NopHandler) HandleItemUseOnBlock(*event.Context, cube.Pos, cube.Face, mgl64.Vec3)        {}
+func (NopHandler) HandleItemUseOnEntity(*event.Context, world.Entity)                          {}
+func (NopHandler) HandleItemConsume(*event.Context, item.Stack)                                {}
+func (NopHandler) HandleItemDamage(*event.Context, item.Stack, int)                            {}
+func (NopHandler) HandleAttackEntity(*event.Context, world.Entity, *float64, *float64, *bool)  {}
+func (NopHandler) HandleExperienceGain(*event.Context, *int)                                   {}
+func (NopHandler) HandlePunchAir(*event.Context)                                               {}
+func (NopHandler) HandleHurt(*event.Context, *float64, *time.Duration, world.DamageSource)     {}
+func (NopHandler) HandleHeal(*event.Context, *float64, world.HealingSource)                    {}
+func (NopHandler) HandleFoodLoss(*event.Context, int, *int)                                    {}
+func (NopHandler) HandleDeath(world.DamageSource, *bool)                                       {}
+func (NopHandler) HandleRespawn(*mgl64.Vec3, **world.World)                                    {}
+func (NopHandler) HandleQuit()                                                                 {}
@@ -2,6 +2,7 @@ package player
 
 import (
 	"fmt"
+	"image/color"
 	"math"
 	"math/rand"
 	"net"
@@ -21,6 +22,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/trade"
 	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
@@ -33,6 +35,8 @@ import (
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 	"golang.org/x/text/language"
 )
 
@@ -63,8 +67,9 @@ type Player struct {
 	heldSlot                 *atomic.Uint32
 
 	sneaking, sprinting, swimming, gliding, flying,
-	invisible, immobile, onGround, usingItem atomic.Bool
+	invisible, immobile, onGround, usingItem, sleeping, blocking atomic.Bool
 	usingSince atomic.Int64
+	sleepPos   atomic.Value[cube.Pos]
 
 	glideTicks   atomic.Int64
 	fireTicks    atomic.Int64
@@ -91,8 +96,18 @@ type Player struct {
 	deathPos       *mgl64.Vec3
 	deathDimension world.Dimension
 
+	spawnMu   sync.Mutex
+	spawns    map[*world.World]cube.Pos
+	bedSpawns map[*world.World]bool
+
+	customMu sync.Mutex
+	custom   map[string][]byte
+
 	enchantSeed atomic.Int64
 
+	fishingHook atomic.Value[*entity.Ent]
+	riding      atomic.Value[world.Entity]
+
 	mc *entity.MovementComputer
 
 	collidedVertically, collidedHorizontally atomic.Bool
@@ -141,6 +156,9 @@ func New(name string, skin skin.Skin, pos mgl64.Vec3) *Player {
 		immunity:          *atomic.NewValue(time.Now()),
 		pos:               *atomic.NewValue(pos),
 		cooldowns:         make(map[string]time.Time),
+		spawns:            make(map[*world.World]cube.Pos),
+		bedSpawns:         make(map[*world.World]bool),
+		custom:            make(map[string][]byte),
 		mc:                &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
 	}
 	return p
@@ -277,6 +295,12 @@ func (p *Player) Messagef(f string, a ...any) {
 	p.session().SendMessage(fmt.Sprintf(f, a...))
 }
 
+// Messaget sends a message to the player that is translated client-side using the translation key and the
+// parameters passed, such as "tile.bed.noSleep".
+func (p *Player) Messaget(key string, params ...string) {
+	p.session().SendTranslation(key, params...)
+}
+
 // SendPopup sends a formatted popup to the player. The popup is shown above the hotbar of the player and
 // overwrites/is overwritten by the name of the item equipped.
 // The popup is formatted following the rules of fmt.Sprintln without a newline at the end.
@@ -390,6 +414,10 @@ func (p *Player) ExecuteCommand(commandLine string) {
 
 // Transfer transfers the player to a server at the address passed. If the address could not be resolved, an
 // error is returned. If it is returned, the player is closed and transferred to the server.
+// If the server runs behind a proxy, as configured using server.ProxyConfig, the address of another backend
+// server behind the proxy may be passed. The proxy then intercepts the transfer and moves the player to that
+// server without disconnecting it from the proxy, if the proxy supports this, like WaterdogPE. As the address
+// is resolved before it is sent, the proxy must know the backend server by its IP address.
 func (p *Player) Transfer(address string) error {
 	addr, err := net.ResolveUDPAddr("udp", address)
 	if err != nil {
@@ -575,6 +603,11 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	if dmg < 0 {
 		return 0, true
 	}
+	if p.blockDamage(dmg, src) {
+		// Blocked hits still make the player immune, so that the shield isn't worn down by an attack every tick.
+		p.immunity.Store(time.Now().Add(immunity))
+		return 0, false
+	}
 
 	totalDamage := p.FinalDamageFrom(dmg, src)
 	damageLeft := totalDamage
@@ -589,6 +622,7 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 		}
 	}
 	p.addHealth(-damageLeft)
+	p.Wake()
 
 	if src.ReducedByArmour() {
 		p.Exhaust(0.1)
@@ -624,6 +658,87 @@ func (p *Player) Hurt(dmg float64, src world.DamageSource) (float64, bool) {
 	return totalDamage, true
 }
 
+// blockDamage attempts to block the damage passed using the shield held by the player. Only melee and projectile
+// damage dealt from in front of the player may be blocked. If the damage was blocked, the shield is damaged and true
+// is returned. Shields hit by an axe are disabled for item.ShieldDisableDuration.
+func (p *Player) blockDamage(dmg float64, src world.DamageSource) bool {
+	if !p.Blocking() {
+		return false
+	}
+	var origin world.Entity
+	switch s := src.(type) {
+	case entity.AttackDamageSource:
+		origin = s.Attacker
+	case entity.ProjectileDamageSource:
+		origin = s.Projectile
+	}
+	if origin == nil {
+		return false
+	}
+	dir, facing := origin.Position().Sub(p.Position()), p.Rotation().Vec3()
+	if dir[0]*facing[0]+dir[2]*facing[2] <= 0 {
+		// The damage was dealt from behind the player, so it can't be blocked.
+		return false
+	}
+	shield, offHand, ok := p.shield()
+	if !ok {
+		return false
+	}
+	if dmg >= 3 {
+		shield = p.damageItem(shield, 1+int(math.Floor(dmg)))
+		if held, left := p.HeldItems(); offHand {
+			p.SetHeldItems(held, shield)
+		} else {
+			p.SetHeldItems(shield, left)
+		}
+	}
+	p.World().PlaySound(p.Position(), sound.ShieldBlock{})
+
+	if s, ok := src.(entity.AttackDamageSource); ok {
+		if c, ok := s.Attacker.(item.Carrier); ok {
+			held, _ := c.HeldItems()
+			if _, ok := held.Item().(item.Axe); ok {
+				p.SetCooldown(item.Shield{}, item.ShieldDisableDuration)
+				p.updateBlocking()
+				return true
+			}
+		}
+		if l, ok := s.Attacker.(entity.Living); ok {
+			l.KnockBack(p.Position(), 0.5, 0.2)
+		}
+	}
+	return true
+}
+
+// Blocking checks if the player is currently blocking damage using a shield. A player blocks while holding a
+// shield in either hand and sneaking or using it, unless the shield was disabled by an axe.
+func (p *Player) Blocking() bool {
+	return p.blocking.Load()
+}
+
+// updateBlocking updates if the player is blocking using a shield and updates the state of the player for viewers if
+// this changed.
+func (p *Player) updateBlocking() {
+	_, _, ok := p.shield()
+	blocking := ok && (p.Sneaking() || p.UsingItem()) && !p.HasCooldown(item.Shield{})
+	if p.blocking.CAS(!blocking, blocking) {
+		p.updateState()
+	}
+}
+
+// shield returns the shield held by the player, if any. The first bool returned is true if the shield is held in
+// the off hand. The second bool returned is false if the player does not hold a shield.
+func (p *Player) shield() (item.Stack, bool, bool) {
+	held, left := p.HeldItems()
+	if _, ok := held.Item().(item.Shield); ok {
+		return held, false, true
+	}
+	if _, ok := left.Item().(item.Shield); ok {
+		return left, true, true
+	}
+	return item.Stack{}, false, false
+}
+
 // FinalDamageFrom resolves the final damage received by the player if it is attacked by the source passed
 // with the damage passed. FinalDamageFrom takes into account things such as the armour worn and the
 // enchantments on the individual pieces.
@@ -805,6 +920,77 @@ func (p *Player) DeathPosition() (mgl64.Vec3, world.Dimension, bool) {
 	return *p.deathPos, p.deathDimension, true
 }
 
+// SetSpawnPosition sets the spawn position of the player in the world.World passed. The player will respawn at this
+// position when it dies in that world. The spawn position is saved to the world.Provider of the world.World using
+// World.SetPlayerSpawn and is additionally stored in the Data of the player.
+func (p *Player) SetSpawnPosition(w *world.World, pos cube.Pos) {
+	p.setSpawnPosition(w, pos, false)
+}
+
+// setSpawnPosition sets the spawn position of the player in the world.World passed. If bed is true, the spawn
+// position was set by sleeping in a block.Bed and is removed if the bed is no longer there when the player respawns.
+func (p *Player) setSpawnPosition(w *world.World, pos cube.Pos, bed bool) {
+	p.spawnMu.Lock()
+	p.spawns[w] = pos
+	if bed {
+		p.bedSpawns[w] = true
+	} else {
+		delete(p.bedSpawns, w)
+	}
+	p.spawnMu.Unlock()
+	w.SetPlayerSpawn(p.UUID(), pos)
+}
+
+// bedSpawnPosition checks if the spawn position of the player in the world.World passed is the cube.Pos passed and
+// was set by sleeping in a block.Bed.
+func (p *Player) bedSpawnPosition(w *world.World, pos cube.Pos) bool {
+	p.spawnMu.Lock()
+	defer p.spawnMu.Unlock()
+	spawn, ok := p.spawns[w]
+	return ok && spawn == pos && p.bedSpawns[w]
+}
+
+// clearSpawnPosition removes the spawn position of the player in the world.World passed, so that it respawns at the
+// spawn of the world.World.
+func (p *Player) clearSpawnPosition(w *world.World) {
+	p.spawnMu.Lock()
+	delete(p.spawns, w)
+	delete(p.bedSpawns, w)
+	p.spawnMu.Unlock()
+	w.SetPlayerSpawn(p.UUID(), w.Spawn())
+}
+
+// SpawnPosition returns the spawn position of the player in the world.World passed, as set using SetSpawnPosition.
+// If no spawn position was set for the world.World, false is returned.
+func (p *Player) SpawnPosition(w *world.World) (cube.Pos, bool) {
+	p.spawnMu.Lock()
+	defer p.spawnMu.Unlock()
+	pos, ok := p.spawns[w]
+	return pos, ok
+}
+
+// SetCustomData sets custom data for the player under a key in a namespace, such as the name of a plugin. Custom
+// data is stored in the Data of the player, so that it is saved and loaded by the player Provider. The data passed
+// is copied. Passing nil data removes the data under the key.
+func (p *Player) SetCustomData(namespace, key string, data []byte) {
+	p.customMu.Lock()
+	defer p.customMu.Unlock()
+	if data == nil {
+		delete(p.custom, namespace+":"+key)
+		return
+	}
+	p.custom[namespace+":"+key] = slices.Clone(data)
+}
+
+// CustomData returns the custom data set for the player under a key in a namespace using SetCustomData. If no data
+// was set under the key, false is returned. The data returned is a copy and may be modified freely.
+func (p *Player) CustomData(namespace, key string) ([]byte, bool) {
+	p.customMu.Lock()
+	defer p.customMu.Unlock()
+	data, ok := p.custom[namespace+":"+key]
+	return slices.Clone(data), ok
+}
+
 // kill kills the player, clearing its inventories and resetting it to its base state.
 func (p *Player) kill(src world.DamageSource) {
 	for _, viewer := range p.viewers() {
@@ -815,6 +1001,7 @@ func (p *Player) kill(src world.DamageSource) {
 
 	keepInv := false
 	p.Handler().HandleDeath(src, &keepInv)
+	p.Dismount()
 	p.StopSneaking()
 	p.StopSprinting()
 
@@ -883,7 +1070,17 @@ func (p *Player) Respawn() {
 	// We can use the principle here that returning through a portal of a specific dimension inside that dimension will
 	// always bring us back to the overworld.
 	w = w.PortalDestination(w.Dimension())
-	pos := w.PlayerSpawn(p.UUID()).Vec3Middle()
+	spawn := w.PlayerSpawn(p.UUID())
+	pos := spawn.Vec3Middle()
+	if _, ok := w.Block(spawn).(block.Bed); ok {
+		// Players respawning at their bed are placed on top of it.
+		pos = pos.Add(mgl64.Vec3{0, 0.5625})
+	} else if p.bedSpawnPosition(w, spawn) {
+		// The bed that the spawn position was set with was broken or replaced, so the player respawns at the world
+		// spawn and loses its spawn position, like in vanilla.
+		p.clearSpawnPosition(w)
+		pos = w.Spawn().Vec3Middle()
+	}
 
 	p.Handler().HandleRespawn(&pos, &w)
 
@@ -894,6 +1091,51 @@ func (p *Player) Respawn() {
 	p.SetVisible()
 }
 
+// Sleep makes the player sleep in the bed at the cube.Pos passed, which must be the head part of a block.Bed. The
+// spawn position of the player is set to the bed, until the bed is broken. Sleep does nothing if there is no bed at
+// the position, if the bed is already occupied or if the player is already sleeping.
+func (p *Player) Sleep(pos cube.Pos) {
+	w := p.World()
+	if b, ok := w.Block(pos).(block.Bed); !ok || !b.Head {
+		return
+	}
+	if _, occupied := block.BedSleeper(w, pos); occupied || !p.sleeping.CAS(false, true) {
+		return
+	}
+	p.sleepPos.Store(pos)
+	block.SetBedOccupied(w, pos, true)
+
+	p.setSpawnPosition(w, pos, true)
+	p.Messaget("tile.bed.respawnSet")
+
+	p.StopSprinting()
+	p.StopSneaking()
+	p.Teleport(pos.Vec3Middle().Add(mgl64.Vec3{0, 0.5625}))
+	p.updateState()
+}
+
+// Sleeping returns the position of the bed that the player is sleeping in and true, or false if the player is not
+// currently sleeping.
+func (p *Player) Sleeping() (cube.Pos, bool) {
+	return p.sleepPos.Load(), p.sleeping.Load()
+}
+
+// Wake wakes the player up if it was sleeping, making it leave its bed. Wake is called automatically when the
+// player is hurt, when the bed is broken or when the night is skipped.
+func (p *Player) Wake() {
+	if !p.sleeping.CAS(true, false) {
+		return
+	}
+	w, pos := p.World(), p.sleepPos.Load()
+	if _, ok := w.Block(pos).(block.Bed); ok {
+		block.SetBedOccupied(w, pos, false)
+	}
+	for _, v := range p.viewers() {
+		v.ViewEntityAction(p, entity.WakeUpAction{})
+	}
+	p.updateState()
+}
+
 // StartSprinting makes a player start sprinting, increasing the speed of the player by 30% and making
 // particles show up under the feet. The player will only start sprinting if its food level is high enough.
 // If the player is sneaking when calling StartSprinting, it is stopped from sneaking.
@@ -935,8 +1177,14 @@ func (p *Player) StopSprinting() {
 
 // StartSneaking makes a player start sneaking. If the player is already sneaking, StartSneaking will not do
 // anything.
-// If the player is sprinting while StartSneaking is called, the sprinting is stopped.
+// If the player is sprinting while StartSneaking is called, the sprinting is stopped. If the player is riding an
+// entity, it dismounts the entity instead.
 func (p *Player) StartSneaking() {
+	if _, ok := p.Riding(); ok {
+		// Sneaking while riding an entity makes the player dismount it.
+		p.Dismount()
+		return
+	}
 	ctx := event.C()
 	if p.Handler().HandleToggleSneak(ctx, true); ctx.Cancelled() {
 		return
@@ -1256,6 +1504,27 @@ func (p *Player) UseItem() {
 		p.SetCooldown(it, cd.Cooldown())
 	}
 
+	if chargeable, ok := it.(item.Chargeable); ok {
+		useCtx := p.useContext()
+		if !p.usingItem.Load() {
+			if !chargeable.ReleaseCharge(p, useCtx) {
+				// The item was not charged yet, so we start charging it.
+				p.usingSince.Store(time.Now().UnixNano())
+				p.usingItem.Store(true)
+			}
+			p.handleUseContext(useCtx)
+			p.updateState()
+			return
+		}
+		// The client uses the item again once it has finished charging it, so we stop charging here.
+		p.usingItem.Store(false)
+		if chargeable.Charge(p, p.useDuration(), useCtx) {
+			p.session().SendChargeItemComplete()
+		}
+		p.handleUseContext(useCtx)
+		p.updateState()
+		return
+	}
 	if _, ok := it.(item.Releasable); ok {
 		if !p.canRelease() {
 			return
@@ -1317,22 +1586,113 @@ func (p *Player) UseItem() {
 }
 
 // ReleaseItem makes the Player release the item it is currently using. This is only applicable for items that
-// implement the item.Releasable interface.
+// implement the item.Releasable or item.Chargeable interface.
 // If the Player is not currently using any item, ReleaseItem returns immediately.
 // ReleaseItem either aborts the using of the item or finished it, depending on the time that elapsed since
 // the item started being used.
 func (p *Player) ReleaseItem() {
+	i, _ := p.HeldItems()
+	if chargeable, ok := i.Item().(item.Chargeable); ok {
+		if !p.usingItem.CAS(true, false) {
+			return
+		}
+		ctx := p.useContext()
+		if p.GameMode().AllowsInteraction() && chargeable.Charge(p, p.useDuration(), ctx) {
+			p.session().SendChargeItemComplete()
+		}
+		p.handleUseContext(ctx)
+		p.updateState()
+		return
+	}
 	if !p.usingItem.CAS(true, false) || !p.canRelease() || !p.GameMode().AllowsInteraction() {
 		return
 	}
 	ctx := p.useContext()
-	i, _ := p.HeldItems()
 	i.Item().(item.Releasable).Release(p, p.useDuration(), ctx)
 
 	p.handleUseContext(ctx)
 	p.updateState()
 }
 
+// CastFishingHook casts a fishing hook from the eyes of the Player in the direction the Player is looking,
+// using the fishing rod passed. Any fishing hook previously cast by the Player is removed.
+func (p *Player) CastFishingHook(rod item.Stack) {
+	w := p.World()
+	if w == nil {
+		return
+	}
+	if old := p.fishingHook.Load(); old != nil {
+		_ = old.Close()
+	}
+	hook := entity.NewFishingHook(entity.EyePosition(p), p, rod)
+	hook.SetVelocity(p.Rotation().Vec3().Mul(0.8))
+	p.fishingHook.Store(hook)
+	w.AddEntity(hook)
+}
+
+// ReelFishingHook reels in the fishing hook cast by the Player. It returns the damage that should be dealt to
+// the fishing rod of the Player and false if the Player did not have a fishing hook cast.
+func (p *Player) ReelFishingHook() (int, bool) {
+	hook := p.fishingHook.Swap(nil)
+	if hook == nil || hook.World() == nil {
+		return 0, false
+	}
+	return hook.Behaviour().(*entity.FishingHookBehaviour).Reel(hook), true
+}
+
+// Mount makes the player start riding the entity passed, such as a boat or a minecart. If the player is already
+// riding another entity, it dismounts that entity first. False is returned if the entity passed cannot be ridden
+// or if it has no free seats.
+func (p *Player) Mount(e world.Entity) bool {
+	r, ok := entity.RideableOf(e)
+	if !ok || p.Dead() || e == world.Entity(p) {
+		return false
+	}
+	if v, ok := p.Riding(); ok && v == e {
+		return true
+	}
+	p.Dismount()
+	if !r.AddPassenger(p) {
+		return false
+	}
+	p.riding.Store(e)
+	p.StopSneaking()
+	p.StopSprinting()
+
+	driver, _ := entity.Driver(e)
+	for _, v := range p.viewers() {
+		v.ViewEntityAction(p, entity.MountAction{Vehicle: e, Driver: driver == world.Entity(p)})
+	}
+	p.updateState()
+	return true
+}
+
+// Dismount makes the player stop riding the entity it is currently riding. The player is moved on top of the
+// entity. If the player is not riding any entity, Dismount does nothing.
+func (p *Player) Dismount() {
+	e := p.riding.Swap(nil)
+	if e == nil {
+		return
+	}
+	if r, ok := entity.RideableOf(e); ok {
+		r.RemovePassenger(p)
+	}
+	for _, v := range p.viewers() {
+		v.ViewEntityAction(p, entity.DismountAction{Vehicle: e})
+	}
+	p.updateState()
+	if e.World() == p.World() {
+		p.teleport(e.Position().Add(mgl64.Vec3{0, e.Type().BBox(e).Height()}))
+	}
+}
+
+// Riding returns the entity that the player is currently riding. False is returned if the player is not riding
+// any entity.
+func (p *Player) Riding() (world.Entity, bool) {
+	e := p.riding.Load()
+	return e, e != nil
+}
+
 // canRelease returns whether the player can release the item currently held in the main hand.
 func (p *Player) canRelease() bool {
 	held, _ := p.HeldItems()
@@ -1457,10 +1817,12 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 	i, left := p.HeldItems()
 	usable, ok := i.Item().(item.UsableOnEntity)
 	if !ok {
+		p.interactWithEntity(e)
 		return true
 	}
 	useCtx := p.useContext()
 	if !usable.UseOnEntity(e, e.World(), p, useCtx) {
+		p.interactWithEntity(e)
 		return true
 	}
 	p.SwingArm()
@@ -1469,6 +1831,37 @@ func (p *Player) UseItemOnEntity(e world.Entity) bool {
 	return true
 }
 
+// interactWithEntity interacts with the entity passed without using an item on it. Traders, such as villagers,
+// and containers, such as chest minecarts, are opened, while entities that can be ridden are mounted.
+func (p *Player) interactWithEntity(e world.Entity) {
+	ent, ok := e.(*entity.Ent)
+	if !ok {
+		return
+	}
+	if t, ok := ent.Behaviour().(trade.Trader); ok {
+		if len(t.Offers()) > 0 && p.session() != session.Nop {
+			p.session().OpenTrade(ent, t)
+		}
+		return
+	}
+	if c, ok := ent.Behaviour().(entity.Container); ok && c.Inventory() != nil {
+		if p.session() != session.Nop {
+			p.session().OpenEntityContainer(ent, c)
+		}
+		return
+	}
+	p.Mount(e)
+}
+
+// RejectAttack is called when an attack of the player on the entity passed failed validation. The violation
+// is passed to the Handler of the player. RejectAttack returns true if the attack should be rejected, or
+// false if it should be allowed anyway.
+func (p *Player) RejectAttack(e world.Entity, violation session.AttackViolation) bool {
+	ctx := event.C()
+	p.Handler().HandleAttackViolation(ctx, e, violation)
+	return !ctx.Cancelled()
+}
+
 // AttackEntity uses the item held in the main hand of the player to attack the entity passed, provided it is
 // within range of the player.
 // The damage dealt to the entity will depend on the item held by the player and any effects the player may
@@ -1492,6 +1885,12 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	p.SwingArm()
 
 	i, _ := p.HeldItems()
+	if ent, ok := e.(*entity.Ent); ok {
+		if v, ok := ent.Behaviour().(entity.Vehicle); ok {
+			v.Attack(ent, i.AttackDamage(), entity.AttackDamageSource{Attacker: p})
+			return true
+		}
+	}
 	living, ok := e.(entity.Living)
 	if !ok {
 		return false
@@ -1510,6 +1909,9 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 	if s, ok := i.Enchantment(enchantment.Sharpness{}); ok {
 		dmg += (enchantment.Sharpness{}).Addend(s.Level())
 	}
+	if imp, ok := i.Enchantment(enchantment.Impaling{}); ok && entity.Wet(living) {
+		dmg += (enchantment.Impaling{}).Addend(imp.Level())
+	}
 	if critical {
 		dmg *= 1.5
 	}
@@ -1812,13 +2214,13 @@ func (p *Player) drops(held item.Stack, b world.Block) []item.Stack {
 		drops = container.Inventory().Items()
 		if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() {
 			if breakable.BreakInfo().Harvestable(t) {
-				drops = append(drops, breakable.BreakInfo().Drops(t, held.Enchantments())...)
+				drops = append(drops, block.Drops(b, t, held.Enchantments())...)
 			}
 		}
 		container.Inventory().Clear()
 	} else if breakable, ok := b.(block.Breakable); ok && !p.GameMode().CreativeInventory() {
 		if breakable.BreakInfo().Harvestable(t) {
-			drops = breakable.BreakInfo().Drops(t, held.Enchantments())
+			drops = block.Drops(b, t, held.Enchantments())
 		}
 	} else if it, ok := b.(world.Item); ok && !p.GameMode().CreativeInventory() {
 		drops = []item.Stack{item.NewStack(it, 1)}
@@ -1880,12 +2282,14 @@ func (p *Player) PickBlock(pos cube.Pos) {
 }
 
 // Teleport teleports the player to a target position in the world. Unlike Move, it immediately changes the
-// position of the player, rather than showing an animation.
+// position of the player, rather than showing an animation. Unlike Move, Teleport is not limited by the
+// world.Border of the world, so it may be used to move the player outside it.
 func (p *Player) Teleport(pos mgl64.Vec3) {
 	ctx := event.C()
 	if p.Handler().HandleTeleport(ctx, pos); ctx.Cancelled() {
 		return
 	}
+	p.Dismount()
 	p.teleport(pos)
 }
 
@@ -1900,6 +2304,16 @@ func (p *Player) teleport(pos mgl64.Vec3) {
 	p.ResetFallDistance()
 }
 
+// CorrectMovement is called when movement of the player sent by its client failed validation, with valid
+// the last valid position of the player and attempted the position it tried to move to. The movement
+// violation is passed to the Handler of the player. CorrectMovement returns true if the movement should be
+// corrected, or false if it should be allowed anyway.
+func (p *Player) CorrectMovement(valid, attempted mgl64.Vec3) bool {
+	ctx := event.C()
+	p.Handler().HandleMovementViolation(ctx, valid, attempted)
+	return !ctx.Cancelled()
+}
+
 // Move moves the player from one position to another in the world, by adding the delta passed to the current
 // position of the player.
 // Move also rotates the player, adding deltaYaw and deltaPitch to the respective values.
@@ -1921,6 +2335,13 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 		yaw, pitch            = p.Rotation().Elem()
 		res, resYaw, resPitch = pos.Add(deltaPos), yaw + deltaYaw, pitch + deltaPitch
 	)
+	border, corrected := w.Border(), false
+	if border.Contains(pos) && !border.Contains(res) {
+		// The player attempted to move through the world border, so only allow it to move up to the border. The
+		// position is corrected client-side after the movement.
+		res, corrected = border.Clamp(res), true
+		deltaPos = res.Sub(pos)
+	}
 	ctx := event.C()
 	if p.Handler().HandleMove(ctx, res, resYaw, resPitch); ctx.Cancelled() {
 		if p.session() != session.Nop && pos.ApproxEqual(p.Position()) {
@@ -1937,6 +2358,9 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 	p.pos.Store(res)
 	p.yaw.Store(resYaw)
 	p.pitch.Store(resPitch)
+	if corrected {
+		p.session().ViewEntityTeleport(p, res)
+	}
 	if deltaPos.Len() <= 3 {
 		// Only update velocity if the player is not moving too fast to prevent potential OOMs.
 		p.vel.Store(deltaPos)
@@ -2176,6 +2600,14 @@ func (p *Player) OpenBlockContainer(pos cube.Pos) {
 	}
 }
 
+// OpenTrade opens a trade window with the offers of the trade.Trader passed, such as a trade.Custom with offers
+// of a shop. OpenTrade does nothing if the player has no session connected to it.
+func (p *Player) OpenTrade(t trade.Trader) {
+	if p.session() != session.Nop {
+		p.session().OpenTrade(p, t)
+	}
+}
+
 // HideEntity hides a world.Entity from the Player so that it can under no circumstance see it. Hidden entities can be
 // made visible again through a call to ShowEntity.
 func (p *Player) HideEntity(e world.Entity) {
@@ -2203,6 +2635,26 @@ func (p *Player) Latency() time.Duration {
 	return p.session().Latency()
 }
 
+// NetworkStats returns network statistics of the player, such as the amount of packets sent to and received
+// from its client. If the player has no session, empty statistics are returned.
+func (p *Player) NetworkStats() session.Stats {
+	return p.session().Stats()
+}
+
+// UsePacketMiddleware adds session.PacketMiddleware to the session of the player, so that packets sent
+// between the player and the server pass through it. Middleware added this way runs after the middleware
+// set in server.Config.PacketMiddleware. If the Player does not have a session associated with it,
+// UsePacketMiddleware does nothing.
+func (p *Player) UsePacketMiddleware(m ...session.PacketMiddleware) {
+	p.session().UseMiddleware(m...)
+}
+
+// RemovePacketMiddleware removes session.PacketMiddleware previously added using UsePacketMiddleware from
+// the session of the player. The middleware must be comparable, as described in session.PacketMiddleware.
+func (p *Player) RemovePacketMiddleware(m session.PacketMiddleware) {
+	p.session().RemoveMiddleware(m)
+}
+
 // Tick ticks the entity, performing actions such as checking if the player is still breaking a block.
 func (p *Player) Tick(w *world.World, current int64) {
 	if p.Dead() {
@@ -2212,6 +2664,14 @@ func (p *Player) Tick(w *world.World, current int64) {
 		p.Handler().HandleChangeWorld(p.lastTickedWorld, w)
 	}
 	p.lastTickedWorld = w
+	if pos, ok := p.Sleeping(); ok {
+		if b, ok := w.Block(pos).(block.Bed); !ok || !b.Occupied {
+			p.Wake()
+		}
+	}
+	if e, ok := p.Riding(); ok && e.World() != w {
+		p.Dismount()
+	}
 	if _, ok := w.Liquid(cube.PosFromVec3(p.Position())); !ok {
 		p.StopSwimming()
 		if _, ok := p.Armour().Helmet().Item().(item.TurtleShell); ok {
@@ -2242,6 +2702,12 @@ func (p *Player) Tick(w *world.World, current int64) {
 	if !p.AttackImmune() && p.insideOfSolid(w) {
 		p.Hurt(1, entity.SuffocationDamageSource{})
 	}
+	if current%20 == 0 {
+		if dmg := w.Border().Damage(p.Position()); dmg > 0 && p.GameMode().AllowsTakingDamage() {
+			p.Hurt(dmg, entity.BorderDamageSource{})
+		}
+		p.showBorder(w)
+	}
 
 	if p.OnFireDuration() > 0 {
 		p.fireTicks.Sub(1)
@@ -2253,6 +2719,12 @@ func (p *Player) Tick(w *world.World, current int64) {
 		}
 	}
 
+	if p.usingItem.Load() {
+		held, _ := p.HeldItems()
+		if chargeable, ok := held.Item().(item.Chargeable); ok {
+			chargeable.ContinueCharge(p, p.useDuration())
+		}
+	}
 	if current%4 == 0 && p.usingItem.Load() {
 		held, _ := p.HeldItems()
 		if _, ok := held.Item().(item.Consumable); ok {
@@ -2270,6 +2742,7 @@ func (p *Player) Tick(w *world.World, current int64) {
 		}
 	}
 	p.cooldownMu.Unlock()
+	p.updateBlocking()
 
 	if p.session() == session.Nop && !p.Immobile() {
 		m := p.mc.TickMovement(p, p.Position(), p.Velocity(), cube.Rotation{p.yaw.Load(), p.pitch.Load()})
@@ -2282,6 +2755,49 @@ func (p *Player) Tick(w *world.World, current int64) {
 	}
 }
 
+// showBorder shows the world.Border of the world.World passed to the player using particles if the player is close
+// enough to it. Only the player itself is able to see these particles. Bedrock Edition clients have no way of
+// rendering a world border natively, so only a small grid of particles is shown on the edge closest to the player.
+// showBorder is called once every second, which roughly matches the lifetime of the particles shown.
+func (p *Player) showBorder(w *world.World) {
+	b, pos := w.Border(), p.Position()
+	if p.session() == session.Nop || !b.Enabled() || b.Distance(pos) > b.WarningDistance {
+		return
+	}
+	colour := color.RGBA{R: 0x20, G: 0xa0, B: 0xff, A: 0xff}
+	if !b.Contains(pos) {
+		colour = color.RGBA{R: 0xff, G: 0x20, B: 0x20, A: 0xff}
+	}
+	min, max := b.Min(), b.Max()
+
+	// Find the edge closest to the player. The axis along the edge of the border is the X axis if the edge is on
+	// the Z axis and the other way around.
+	axis, value, along, lo, hi := 0, min[0], 2, min[1], max[1]
+	closest := math.Abs(pos[0] - min[0])
+	for _, edge := range [...]struct {
+		axis  int
+		value float64
+	}{{0, max[0]}, {2, min[1]}, {2, max[1]}} {
+		if dist := math.Abs(pos[edge.axis] - edge.value); dist < closest {
+			closest, axis, value = dist, edge.axis, edge.value
+			along, lo, hi = 2, min[1], max[1]
+			if edge.axis == 2 {
+				along, lo, hi = 0, min[0], max[0]
+			}
+		}
+	}
+	for a := math.Floor(pos[along]) - 2; a <= math.Floor(pos[along])+2; a += 2 {
+		if a < lo || a > hi {
+			continue
+		}
+		for y := math.Floor(pos[1]); y <= math.Floor(pos[1])+2; y++ {
+			particlePos := mgl64.Vec3{0, y, 0}
+			particlePos[axis], particlePos[along] = value, a
+			p.session().ViewParticle(particlePos, particle.Dust{Colour: colour})
+		}
+	}
+}
+
 // tickAirSupply tick's the player's air supply, consuming it when underwater, and replenishing it when out of water.
 func (p *Player) tickAirSupply(w *world.World) {
 	if !p.canBreathe(w) {
@@ -2743,6 +3259,8 @@ func (p *Player) Close() error {
 // close closes the player without disconnecting it. It executes code shared by both the closing and the
 // disconnecting of players.
 func (p *Player) close(msg string) {
+	p.Wake()
+	p.Dismount()
 	// If the player is being disconnected while they are dead, we respawn the player
 	// so that the player logic works correctly the next time they join.
 	if p.Dead() && p.session() != nil {
@@ -2797,6 +3315,40 @@ func (p *Player) load(data Data) {
 	for slot, stack := range data.EnderChestInventory {
 		_ = p.enderChest.SetItem(slot, stack)
 	}
+	for w, pos := range data.SpawnPositions {
+		p.spawns[w] = pos
+		if data.BedSpawns[w] {
+			p.bedSpawns[w] = true
+		}
+		if w.PlayerSpawn(p.UUID()) != pos {
+			// The spawn position stored in the world may be outdated, for example if the player data is shared
+			// between multiple servers.
+			w.SetPlayerSpawn(p.UUID(), pos)
+		}
+	}
+	if data.NameTag != "" {
+		p.nameTag.Store(data.NameTag)
+	}
+	p.scoreTag.Store(data.ScoreTag)
+
+	now := time.Now()
+	for name, d := range data.Cooldowns {
+		p.cooldowns[name] = now.Add(d)
+		if it, ok := world.ItemByName(name, 0); ok {
+			p.session().ViewItemCooldown(it, d)
+		}
+	}
+	if data.DeathPosition != nil && data.DeathDimension != nil {
+		pos := *data.DeathPosition
+		p.deathPos, p.deathDimension = &pos, data.DeathDimension
+	}
+	if data.Flying && p.GameMode().AllowsFlying() {
+		p.flying.Store(true)
+		p.session().SendGameMode(p.GameMode())
+	}
+	for k, v := range data.CustomData {
+		p.custom[k] = slices.Clone(v)
+	}
 }
 
 // loadInventory loads all the data associated with the player inventory.
@@ -2814,6 +3366,32 @@ func (p *Player) Data() Data {
 	yaw, pitch := p.Rotation().Elem()
 	offHand, _ := p.offHand.Item(0)
 
+	p.spawnMu.Lock()
+	spawns, bedSpawns := maps.Clone(p.spawns), maps.Clone(p.bedSpawns)
+	p.spawnMu.Unlock()
+
+	now, cooldowns := time.Now(), make(map[string]time.Duration)
+	p.cooldownMu.Lock()
+	for name, t := range p.cooldowns {
+		if remaining := t.Sub(now); remaining > 0 {
+			cooldowns[name] = remaining
+		}
+	}
+	p.cooldownMu.Unlock()
+
+	var deathPos *mgl64.Vec3
+	pos, deathDim, died := p.DeathPosition()
+	if died {
+		deathPos = &pos
+	}
+
+	p.customMu.Lock()
+	custom := make(map[string][]byte, len(p.custom))
+	for k, v := range p.custom {
+		custom[k] = slices.Clone(v)
+	}
+	p.customMu.Unlock()
+
 	p.hunger.mu.RLock()
 	defer p.hunger.mu.RUnlock()
 
@@ -2850,6 +3428,15 @@ func (p *Player) Data() Data {
 		FireTicks:           p.fireTicks.Load(),
 		FallDistance:        p.fallDistance.Load(),
 		World:               p.World(),
+		SpawnPositions:      spawns,
+		BedSpawns:           bedSpawns,
+		NameTag:             p.NameTag(),
+		ScoreTag:            p.ScoreTag(),
+		Cooldowns:           cooldowns,
+		DeathPosition:       deathPos,
+		DeathDimension:      deathDim,
+		Flying:              p.Flying(),
+		CustomData:          custom,
 	}
 }
 
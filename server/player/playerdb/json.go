@@ -1,6 +1,7 @@
 package playerdb
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/world"
@@ -37,14 +38,50 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 		Inventory:           dataToInv(d.Inventory),
 		EnderChestInventory: make([]item.Stack, 27),
 		World:               lookupWorld(dim),
+		SpawnPositions:      make(map[*world.World]cube.Pos, len(d.Spawns)),
+		BedSpawns:           make(map[*world.World]bool),
+		NameTag:             d.NameTag,
+		ScoreTag:            d.ScoreTag,
+		Cooldowns:           make(map[string]time.Duration, len(d.Cooldowns)),
+		Flying:              d.Flying,
+		CustomData:          d.CustomData,
 	}
 	decodeItems(d.EnderChestInventory, data.EnderChestInventory)
+	for _, spawn := range d.Spawns {
+		// Spawn positions are only restored in the worlds returned by lookupWorld. The world name is checked so that
+		// a spawn position in another world with the same dimension doesn't end up in the wrong world.
+		if spawnDim, ok := world.DimensionByID(int(spawn.Dimension)); ok {
+			if w := lookupWorld(spawnDim); w != nil && w.Name() == spawn.World {
+				data.SpawnPositions[w] = spawn.Position
+				if spawn.Bed {
+					data.BedSpawns[w] = true
+				}
+			}
+		}
+	}
+	for name, ms := range d.Cooldowns {
+		data.Cooldowns[name] = time.Duration(ms) * time.Millisecond
+	}
+	if d.DeathPosition != nil {
+		data.DeathPosition = d.DeathPosition
+		data.DeathDimension, _ = world.DimensionByID(int(d.DeathDimension))
+	}
 	return data
 }
 
 func (p *Provider) toJson(d player.Data) jsonData {
 	dim, _ := world.DimensionID(d.World.Dimension())
 	mode, _ := world.GameModeID(d.GameMode)
+	spawns := make([]jsonSpawn, 0, len(d.SpawnPositions))
+	for w, pos := range d.SpawnPositions {
+		id, _ := world.DimensionID(w.Dimension())
+		spawns = append(spawns, jsonSpawn{World: w.Name(), Dimension: uint8(id), Position: pos, Bed: d.BedSpawns[w]})
+	}
+	cooldowns := make(map[string]int64, len(d.Cooldowns))
+	for name, dur := range d.Cooldowns {
+		cooldowns[name] = dur.Milliseconds()
+	}
+	deathDim, _ := world.DimensionID(d.DeathDimension)
 	return jsonData{
 		UUID:                d.UUID.String(),
 		Username:            d.Username,
@@ -70,6 +107,14 @@ func (p *Provider) toJson(d player.Data) jsonData {
 		Inventory:           invToData(d.Inventory),
 		EnderChestInventory: encodeItems(d.EnderChestInventory),
 		Dimension:           uint8(dim),
+		Spawns:              spawns,
+		NameTag:             d.NameTag,
+		ScoreTag:            d.ScoreTag,
+		Cooldowns:           cooldowns,
+		DeathPosition:       d.DeathPosition,
+		DeathDimension:      uint8(deathDim),
+		Flying:              d.Flying,
+		CustomData:          d.CustomData,
 	}
 }
 
@@ -93,6 +138,13 @@ type jsonData struct {
 	FireTicks                        int64
 	FallDistance                     float64
 	Dimension                        uint8
+	Spawns                           []jsonSpawn
+	NameTag, ScoreTag                string
+	Cooldowns                        map[string]int64
+	DeathPosition                    *mgl64.Vec3
+	DeathDimension                   uint8
+	Flying                           bool
+	CustomData                       map[string][]byte
 }
 
 type jsonInventoryData struct {
@@ -116,3 +168,10 @@ type jsonEffect struct {
 	Duration time.Duration
 	Ambient  bool
 }
+
+type jsonSpawn struct {
+	World     string
+	Dimension uint8
+	Position  cube.Pos
+	Bed       bool
+}
@@ -0,0 +1,130 @@
+package playersql
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// inventoryColumns holds the encoded contents of the inventories of a player,
+// as stored in the inventory columns of the players table.
+type inventoryColumns struct {
+	items, helmet, chestplate, leggings, boots, offHand, enderChest []byte
+}
+
+// encodeInventories encodes the inventories of the player.Data passed. An
+// error is returned if any of the items could not be encoded.
+func encodeInventories(d player.Data) (inventoryColumns, error) {
+	var (
+		c   inventoryColumns
+		err error
+	)
+	if c.items, err = encodeItems(d.Inventory.Items); err != nil {
+		return c, fmt.Errorf("encode inventory: %w", err)
+	}
+	for _, it := range [...]struct {
+		dst *[]byte
+		s   item.Stack
+	}{
+		{&c.helmet, d.Inventory.Helmet}, {&c.chestplate, d.Inventory.Chestplate},
+		{&c.leggings, d.Inventory.Leggings}, {&c.boots, d.Inventory.Boots},
+		{&c.offHand, d.Inventory.OffHand},
+	} {
+		if *it.dst, err = encodeItem(it.s); err != nil {
+			return c, fmt.Errorf("encode armour or offhand: %w", err)
+		}
+	}
+	if c.enderChest, err = encodeItems(d.EnderChestInventory); err != nil {
+		return c, fmt.Errorf("encode ender chest: %w", err)
+	}
+	return c, nil
+}
+
+// decode decodes the inventories held by the inventoryColumns into the
+// player.Data passed. An error is returned if any of the items could not be
+// decoded.
+func (c inventoryColumns) decode(d *player.Data) error {
+	var err error
+	if d.Inventory.Items, err = decodeItems(c.items, 36); err != nil {
+		return fmt.Errorf("decode inventory: %w", err)
+	}
+	for _, it := range [...]struct {
+		dst *item.Stack
+		b   []byte
+	}{
+		{&d.Inventory.Helmet, c.helmet}, {&d.Inventory.Chestplate, c.chestplate},
+		{&d.Inventory.Leggings, c.leggings}, {&d.Inventory.Boots, c.boots},
+		{&d.Inventory.OffHand, c.offHand},
+	} {
+		if *it.dst, err = decodeItem(it.b); err != nil {
+			return fmt.Errorf("decode armour or offhand: %w", err)
+		}
+	}
+	if d.EnderChestInventory, err = decodeItems(c.enderChest, 27); err != nil {
+		return fmt.Errorf("decode ender chest: %w", err)
+	}
+	return nil
+}
+
+// encodeItems encodes a slice of item stacks into NBT. Empty stacks are not
+// encoded. The slot of each stack is stored alongside it. nil is returned if
+// all stacks are empty.
+func encodeItems(items []item.Stack) ([]byte, error) {
+	encoded := make([]map[string]any, 0, len(items))
+	for slot, it := range items {
+		if it.Empty() {
+			continue
+		}
+		data := nbtconv.WriteItem(it, true)
+		data["Slot"] = byte(slot)
+		encoded = append(encoded, data)
+	}
+	if len(encoded) == 0 {
+		return nil, nil
+	}
+	return nbt.MarshalEncoding(map[string]any{"Items": encoded}, nbt.LittleEndian)
+}
+
+// decodeItems decodes NBT data produced by encodeItems into a slice of item
+// stacks with a length of n.
+func decodeItems(data []byte, n int) ([]item.Stack, error) {
+	items := make([]item.Stack, n)
+	if len(data) == 0 {
+		return items, nil
+	}
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(bytes.NewBuffer(data), nbt.LittleEndian).Decode(&m); err != nil {
+		return nil, err
+	}
+	for _, v := range nbtconv.Slice(m, "Items") {
+		itemData, _ := v.(map[string]any)
+		if slot := int(nbtconv.Uint8(itemData, "Slot")); slot < n {
+			items[slot] = nbtconv.Item(itemData, nil)
+		}
+	}
+	return items, nil
+}
+
+// encodeItem encodes a single item stack into NBT. nil is returned if the
+// stack is empty.
+func encodeItem(it item.Stack) ([]byte, error) {
+	if it.Empty() {
+		return nil, nil
+	}
+	return nbt.MarshalEncoding(nbtconv.WriteItem(it, true), nbt.LittleEndian)
+}
+
+// decodeItem decodes NBT data produced by encodeItem into an item stack.
+func decodeItem(data []byte) (item.Stack, error) {
+	if len(data) == 0 {
+		return item.Stack{}, nil
+	}
+	var m map[string]any
+	if err := nbt.NewDecoderWithEncoding(bytes.NewBuffer(data), nbt.LittleEndian).Decode(&m); err != nil {
+		return item.Stack{}, err
+	}
+	return nbtconv.Item(m, nil), nil
+}
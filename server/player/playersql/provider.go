@@ -0,0 +1,383 @@
+package playersql
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// Provider is a player data provider that stores data in an SQL database
+// using database/sql. Unlike playerdb.Provider, the fields of player.Data are
+// stored in separate columns, so that the data may be queried by other
+// applications. Items are stored as NBT, encoded the same way as in world
+// saves.
+//
+// Queries use '?' placeholders and the schema only uses common SQL types, so
+// that the Provider works with SQLite and MySQL compatible drivers.
+type Provider struct {
+	db *sql.DB
+}
+
+// NewProvider creates a Provider that stores data in the *sql.DB passed. The
+// schema of the database is migrated to the latest version if needed,
+// creating the tables required if they do not yet exist. An error is returned
+// if the migration failed.
+func NewProvider(db *sql.DB) (*Provider, error) {
+	if err := migrate(db); err != nil {
+		return nil, fmt.Errorf("new provider: %w", err)
+	}
+	return &Provider{db: db}, nil
+}
+
+// playerColumns holds the columns of the players table in the order in which
+// they are written in Save and read in Load.
+const playerColumns = `uuid, username, pos_x, pos_y, pos_z, vel_x, vel_y, vel_z, yaw, pitch, health, max_health,
+	hunger, food_tick, exhaustion, saturation, absorption, enchantment_seed, experience, air_supply, max_air_supply,
+	game_mode, fire_ticks, fall_distance, dimension, main_hand_slot, inventory, helmet, chestplate, leggings, boots,
+	off_hand, ender_chest, name_tag, score_tag, flying, death_x, death_y, death_z, death_dimension`
+
+// playerPlaceholders holds a placeholder for every column in playerColumns.
+var playerPlaceholders = strings.TrimSuffix(strings.Repeat("?, ", strings.Count(playerColumns, ",")+1), ", ")
+
+// Save ...
+func (p *Provider) Save(id uuid.UUID, d player.Data) error {
+	dim, _ := world.DimensionID(d.World.Dimension())
+	mode, _ := world.GameModeID(d.GameMode)
+	// Encode the inventories before starting the transaction, so that the
+	// stored data is left untouched if any of the items can't be encoded.
+	inv, err := encodeInventories(d)
+	if err != nil {
+		return fmt.Errorf("save %v: %w", id, err)
+	}
+
+	tx, err := p.db.Begin()
+	if err != nil {
+		return fmt.Errorf("save %v: %w", id, err)
+	}
+	// Deleting and inserting rows, rather than using an upsert, keeps the
+	// queries compatible with most SQL dialects.
+	if _, err := tx.Exec(`DELETE FROM players WHERE uuid = ?`, id.String()); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("save %v: delete player: %w", id, err)
+	}
+	var deathX, deathY, deathZ sql.NullFloat64
+	var deathDimension sql.NullInt64
+	if d.DeathPosition != nil && d.DeathDimension != nil {
+		deathDim, _ := world.DimensionID(d.DeathDimension)
+		deathX, deathY, deathZ = nullFloat(d.DeathPosition[0]), nullFloat(d.DeathPosition[1]), nullFloat(d.DeathPosition[2])
+		deathDimension = sql.NullInt64{Int64: int64(deathDim), Valid: true}
+	}
+	_, err = tx.Exec(`INSERT INTO players (`+playerColumns+`) VALUES (`+playerPlaceholders+`)`,
+		id.String(), d.Username,
+		d.Position[0], d.Position[1], d.Position[2],
+		d.Velocity[0], d.Velocity[1], d.Velocity[2],
+		d.Yaw, d.Pitch,
+		d.Health, d.MaxHealth,
+		d.Hunger, d.FoodTick,
+		d.ExhaustionLevel, d.SaturationLevel, d.AbsorptionLevel,
+		d.EnchantmentSeed,
+		d.Experience,
+		d.AirSupply, d.MaxAirSupply,
+		mode,
+		d.FireTicks,
+		d.FallDistance,
+		dim,
+		d.Inventory.MainHandSlot,
+		inv.items,
+		inv.helmet, inv.chestplate, inv.leggings, inv.boots,
+		inv.offHand,
+		inv.enderChest,
+		d.NameTag, d.ScoreTag,
+		d.Flying,
+		deathX, deathY, deathZ, deathDimension,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("save %v: insert player: %w", id, err)
+	}
+	for _, f := range [...]func(tx *sql.Tx, id uuid.UUID, d player.Data) error{saveEffects, saveSpawns, saveCooldowns, saveCustomData} {
+		if err := f(tx, id, d); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("save %v: %w", id, err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("save %v: %w", id, err)
+	}
+	return nil
+}
+
+// nullFloat returns a valid sql.NullFloat64 holding f.
+func nullFloat(f float64) sql.NullFloat64 {
+	return sql.NullFloat64{Float64: f, Valid: true}
+}
+
+// saveEffects replaces the effects stored for a player with the effects in
+// the player.Data passed.
+func saveEffects(tx *sql.Tx, id uuid.UUID, d player.Data) error {
+	if _, err := tx.Exec(`DELETE FROM player_effects WHERE uuid = ?`, id.String()); err != nil {
+		return fmt.Errorf("delete effects: %w", err)
+	}
+	for _, e := range d.Effects {
+		effectID, ok := effect.ID(e.Type())
+		if !ok {
+			continue
+		}
+		_, err := tx.Exec(`INSERT INTO player_effects (uuid, effect_id, level, duration_ms, ambient) VALUES (?, ?, ?, ?, ?)`,
+			id.String(), effectID, e.Level(), e.Duration().Milliseconds(), e.Ambient())
+		if err != nil {
+			return fmt.Errorf("insert effect %v: %w", effectID, err)
+		}
+	}
+	return nil
+}
+
+// saveSpawns replaces the spawn positions stored for a player with the spawn
+// positions in the player.Data passed.
+func saveSpawns(tx *sql.Tx, id uuid.UUID, d player.Data) error {
+	if _, err := tx.Exec(`DELETE FROM player_world_spawns WHERE uuid = ?`, id.String()); err != nil {
+		return fmt.Errorf("delete spawns: %w", err)
+	}
+	for w, pos := range d.SpawnPositions {
+		dim, _ := world.DimensionID(w.Dimension())
+		_, err := tx.Exec(`INSERT INTO player_world_spawns (uuid, world, dimension, x, y, z, bed) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			id.String(), w.Name(), dim, pos[0], pos[1], pos[2], d.BedSpawns[w])
+		if err != nil {
+			return fmt.Errorf("insert spawn in world %v (%v): %w", w.Name(), w.Dimension(), err)
+		}
+	}
+	return nil
+}
+
+// saveCooldowns replaces the item cooldowns stored for a player with the
+// cooldowns in the player.Data passed.
+func saveCooldowns(tx *sql.Tx, id uuid.UUID, d player.Data) error {
+	if _, err := tx.Exec(`DELETE FROM player_cooldowns WHERE uuid = ?`, id.String()); err != nil {
+		return fmt.Errorf("delete cooldowns: %w", err)
+	}
+	for name, dur := range d.Cooldowns {
+		_, err := tx.Exec(`INSERT INTO player_cooldowns (uuid, item, remaining_ms) VALUES (?, ?, ?)`,
+			id.String(), name, dur.Milliseconds())
+		if err != nil {
+			return fmt.Errorf("insert cooldown of %v: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// saveCustomData replaces the custom data stored for a player with the custom
+// data in the player.Data passed.
+func saveCustomData(tx *sql.Tx, id uuid.UUID, d player.Data) error {
+	if _, err := tx.Exec(`DELETE FROM player_custom_data WHERE uuid = ?`, id.String()); err != nil {
+		return fmt.Errorf("delete custom data: %w", err)
+	}
+	for k, v := range d.CustomData {
+		_, err := tx.Exec(`INSERT INTO player_custom_data (uuid, data_key, data) VALUES (?, ?, ?)`, id.String(), k, v)
+		if err != nil {
+			return fmt.Errorf("insert custom data %v: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// Load ...
+func (p *Provider) Load(id uuid.UUID, lookupWorld func(world.Dimension) *world.World) (player.Data, error) {
+	var (
+		d                      player.Data
+		uid                    string
+		mode, dim              int
+		inv                    inventoryColumns
+		deathX, deathY, deathZ sql.NullFloat64
+		deathDim               sql.NullInt64
+	)
+	err := p.db.QueryRow(`SELECT `+playerColumns+` FROM players WHERE uuid = ?`, id.String()).Scan(
+		&uid, &d.Username,
+		&d.Position[0], &d.Position[1], &d.Position[2],
+		&d.Velocity[0], &d.Velocity[1], &d.Velocity[2],
+		&d.Yaw, &d.Pitch,
+		&d.Health, &d.MaxHealth,
+		&d.Hunger, &d.FoodTick,
+		&d.ExhaustionLevel, &d.SaturationLevel, &d.AbsorptionLevel,
+		&d.EnchantmentSeed,
+		&d.Experience,
+		&d.AirSupply, &d.MaxAirSupply,
+		&mode,
+		&d.FireTicks,
+		&d.FallDistance,
+		&dim,
+		&d.Inventory.MainHandSlot,
+		&inv.items, &inv.helmet, &inv.chestplate, &inv.leggings, &inv.boots, &inv.offHand, &inv.enderChest,
+		&d.NameTag, &d.ScoreTag,
+		&d.Flying,
+		&deathX, &deathY, &deathZ, &deathDim,
+	)
+	if err != nil {
+		return player.Data{}, fmt.Errorf("load %v: %w", id, err)
+	}
+	d.UUID = id
+	d.GameMode, _ = world.GameModeByID(mode)
+	dimension, _ := world.DimensionByID(dim)
+	d.World = lookupWorld(dimension)
+
+	if err := inv.decode(&d); err != nil {
+		return player.Data{}, fmt.Errorf("load %v: %w", id, err)
+	}
+
+	if deathX.Valid && deathY.Valid && deathZ.Valid && deathDim.Valid {
+		d.DeathPosition = &mgl64.Vec3{deathX.Float64, deathY.Float64, deathZ.Float64}
+		d.DeathDimension, _ = world.DimensionByID(int(deathDim.Int64))
+	}
+	if d.Effects, err = p.loadEffects(id); err != nil {
+		return player.Data{}, fmt.Errorf("load %v: %w", id, err)
+	}
+	if d.SpawnPositions, d.BedSpawns, err = p.loadSpawns(id, lookupWorld); err != nil {
+		return player.Data{}, fmt.Errorf("load %v: %w", id, err)
+	}
+	if d.Cooldowns, err = p.loadCooldowns(id); err != nil {
+		return player.Data{}, fmt.Errorf("load %v: %w", id, err)
+	}
+	if d.CustomData, err = p.loadCustomData(id); err != nil {
+		return player.Data{}, fmt.Errorf("load %v: %w", id, err)
+	}
+	return d, nil
+}
+
+// loadEffects loads the effects stored for the player with the UUID passed.
+func (p *Provider) loadEffects(id uuid.UUID) ([]effect.Effect, error) {
+	rows, err := p.db.Query(`SELECT effect_id, level, duration_ms, ambient FROM player_effects WHERE uuid = ?`, id.String())
+	if err != nil {
+		return nil, fmt.Errorf("query effects: %w", err)
+	}
+	defer rows.Close()
+
+	var effects []effect.Effect
+	for rows.Next() {
+		var (
+			effectID, level int
+			dur             int64
+			ambient         bool
+		)
+		if err := rows.Scan(&effectID, &level, &dur, &ambient); err != nil {
+			return nil, fmt.Errorf("scan effect: %w", err)
+		}
+		t, ok := effect.ByID(effectID)
+		if !ok {
+			continue
+		}
+		switch eff := t.(type) {
+		case effect.LastingType:
+			if ambient {
+				effects = append(effects, effect.NewAmbient(eff, level, time.Duration(dur)*time.Millisecond))
+				continue
+			}
+			effects = append(effects, effect.New(eff, level, time.Duration(dur)*time.Millisecond))
+		default:
+			effects = append(effects, effect.NewInstant(eff, level))
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read effects: %w", err)
+	}
+	return effects, nil
+}
+
+// loadSpawns loads the spawn positions stored for the player with the UUID
+// passed, together with the worlds in which the spawn position was set by a
+// bed. Spawn positions are only loaded for the worlds returned by
+// lookupWorld, whose name must match the name of the world stored.
+func (p *Provider) loadSpawns(id uuid.UUID, lookupWorld func(world.Dimension) *world.World) (map[*world.World]cube.Pos, map[*world.World]bool, error) {
+	rows, err := p.db.Query(`SELECT world, dimension, x, y, z, bed FROM player_world_spawns WHERE uuid = ?`, id.String())
+	if err != nil {
+		return nil, nil, fmt.Errorf("query spawns: %w", err)
+	}
+	defer rows.Close()
+
+	spawns, beds := make(map[*world.World]cube.Pos), make(map[*world.World]bool)
+	for rows.Next() {
+		var (
+			name string
+			dim  int
+			pos  cube.Pos
+			bed  bool
+		)
+		if err := rows.Scan(&name, &dim, &pos[0], &pos[1], &pos[2], &bed); err != nil {
+			return nil, nil, fmt.Errorf("scan spawn: %w", err)
+		}
+		if d, ok := world.DimensionByID(dim); ok {
+			if w := lookupWorld(d); w != nil && w.Name() == name {
+				spawns[w] = pos
+				if bed {
+					beds[w] = true
+				}
+			}
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read spawns: %w", err)
+	}
+	return spawns, beds, nil
+}
+
+// loadCooldowns loads the item cooldowns stored for the player with the UUID
+// passed.
+func (p *Provider) loadCooldowns(id uuid.UUID) (map[string]time.Duration, error) {
+	rows, err := p.db.Query(`SELECT item, remaining_ms FROM player_cooldowns WHERE uuid = ?`, id.String())
+	if err != nil {
+		return nil, fmt.Errorf("query cooldowns: %w", err)
+	}
+	defer rows.Close()
+
+	cooldowns := make(map[string]time.Duration)
+	for rows.Next() {
+		var (
+			name string
+			ms   int64
+		)
+		if err := rows.Scan(&name, &ms); err != nil {
+			return nil, fmt.Errorf("scan cooldown: %w", err)
+		}
+		cooldowns[name] = time.Duration(ms) * time.Millisecond
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read cooldowns: %w", err)
+	}
+	return cooldowns, nil
+}
+
+// loadCustomData loads the custom data stored for the player with the UUID
+// passed.
+func (p *Provider) loadCustomData(id uuid.UUID) (map[string][]byte, error) {
+	rows, err := p.db.Query(`SELECT data_key, data FROM player_custom_data WHERE uuid = ?`, id.String())
+	if err != nil {
+		return nil, fmt.Errorf("query custom data: %w", err)
+	}
+	defer rows.Close()
+
+	custom := make(map[string][]byte)
+	for rows.Next() {
+		var (
+			k string
+			v []byte
+		)
+		if err := rows.Scan(&k, &v); err != nil {
+			return nil, fmt.Errorf("scan custom data: %w", err)
+		}
+		custom[k] = v
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("read custom data: %w", err)
+	}
+	return custom, nil
+}
+
+// Close closes the underlying *sql.DB of the Provider.
+func (p *Provider) Close() error {
+	return p.db.Close()
+}
@@ -0,0 +1,185 @@
+package playersql
+
+import (
+	"database/sql"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/google/uuid"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/sirupsen/logrus"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTestProvider opens an in-memory SQLite database and creates a Provider
+// that stores data in it. The Provider is closed when the test finishes.
+func newTestProvider(t *testing.T) *Provider {
+	t.Helper()
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	// Every connection to :memory: opens a new database, so only a single
+	// connection may be used.
+	db.SetMaxOpenConns(1)
+
+	p, err := NewProvider(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = p.Close()
+	})
+	return p
+}
+
+// newTestWorld creates a world.World with the dimension passed. The world.World
+// is closed when the test finishes.
+func newTestWorld(t *testing.T, dim world.Dimension) *world.World {
+	t.Helper()
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	w := world.Config{Log: log, Dim: dim}.New()
+	t.Cleanup(func() {
+		_ = w.Close()
+	})
+	return w
+}
+
+func TestMigrateIdempotent(t *testing.T) {
+	p := newTestProvider(t)
+	for i := 0; i < 2; i++ {
+		if err := migrate(p.db); err != nil {
+			t.Fatalf("migrate run %v: %v", i+1, err)
+		}
+	}
+	var ver int
+	if err := p.db.QueryRow(`SELECT version FROM schema_version`).Scan(&ver); err != nil {
+		t.Fatal(err)
+	}
+	if ver != len(migrations) {
+		t.Errorf("schema version = %v, want %v", ver, len(migrations))
+	}
+	var rows int
+	if err := p.db.QueryRow(`SELECT COUNT(*) FROM schema_version`).Scan(&rows); err != nil {
+		t.Fatal(err)
+	}
+	if rows != 1 {
+		t.Errorf("schema_version has %v rows, want 1", rows)
+	}
+}
+
+func TestProviderRoundTrip(t *testing.T) {
+	p := newTestProvider(t)
+	overworld, nether := newTestWorld(t, world.Overworld), newTestWorld(t, world.Nether)
+	lookupWorld := func(dim world.Dimension) *world.World {
+		if dim == world.Nether {
+			return nether
+		}
+		return overworld
+	}
+
+	id := uuid.New()
+	inv := make([]item.Stack, 36)
+	inv[0], inv[35] = item.NewStack(item.Apple{}, 12), item.NewStack(item.Diamond{}, 3)
+	ender := make([]item.Stack, 27)
+	ender[4] = item.NewStack(item.Stick{}, 64)
+	deathPos := mgl64.Vec3{10.5, 64, -3.25}
+
+	want := player.Data{
+		UUID:            id,
+		Username:        "Steve",
+		Position:        mgl64.Vec3{1.5, 70, -2.5},
+		Velocity:        mgl64.Vec3{0, -0.08, 0},
+		Yaw:             90,
+		Pitch:           -15,
+		Health:          14,
+		MaxHealth:       20,
+		Hunger:          17,
+		FoodTick:        40,
+		ExhaustionLevel: 1.5,
+		SaturationLevel: 3,
+		AbsorptionLevel: 4,
+		EnchantmentSeed: 123456789,
+		Experience:      250,
+		AirSupply:       200,
+		MaxAirSupply:    300,
+		GameMode:        world.GameModeCreative,
+		Inventory: player.InventoryData{
+			Items:        inv,
+			Helmet:       item.NewStack(item.Helmet{Tier: item.ArmourTierDiamond{}}, 1),
+			OffHand:      item.NewStack(item.Arrow{}, 16),
+			MainHandSlot: 3,
+		},
+		EnderChestInventory: ender,
+		Effects:             []effect.Effect{effect.New(effect.Speed{}, 2, time.Minute)},
+		FireTicks:           20,
+		FallDistance:        2.5,
+		World:               nether,
+		SpawnPositions:      map[*world.World]cube.Pos{overworld: {5, 64, 5}, nether: {-8, 32, 16}},
+		BedSpawns:           map[*world.World]bool{overworld: true},
+		NameTag:             "name",
+		ScoreTag:            "score",
+		Cooldowns:           map[string]time.Duration{"minecraft:ender_pearl": time.Second},
+		DeathPosition:       &deathPos,
+		DeathDimension:      world.Overworld,
+		Flying:              true,
+		CustomData:          map[string][]byte{"plugin:key": {1, 2, 3}},
+	}
+	if err := p.Save(id, want); err != nil {
+		t.Fatal(err)
+	}
+	// Saving a second time must replace the stored data rather than fail on
+	// or duplicate existing rows.
+	if err := p.Save(id, want); err != nil {
+		t.Fatalf("save existing player: %v", err)
+	}
+	got, err := p.Load(id, lookupWorld)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for slot, s := range want.Inventory.Items {
+		if !got.Inventory.Items[slot].Equal(s) {
+			t.Errorf("inventory slot %v = %v, want %v", slot, got.Inventory.Items[slot], s)
+		}
+	}
+	for slot, s := range want.EnderChestInventory {
+		if !got.EnderChestInventory[slot].Equal(s) {
+			t.Errorf("ender chest slot %v = %v, want %v", slot, got.EnderChestInventory[slot], s)
+		}
+	}
+	if !got.Inventory.Helmet.Equal(want.Inventory.Helmet) || !got.Inventory.OffHand.Equal(want.Inventory.OffHand) {
+		t.Errorf("helmet and offhand = (%v, %v), want (%v, %v)", got.Inventory.Helmet, got.Inventory.OffHand, want.Inventory.Helmet, want.Inventory.OffHand)
+	}
+	if len(got.Effects) != 1 || got.Effects[0].Type() != (effect.Speed{}) || got.Effects[0].Level() != 2 || got.Effects[0].Duration() != time.Minute {
+		t.Errorf("effects = %v, want %v", got.Effects, want.Effects)
+	}
+	if got.DeathPosition == nil || *got.DeathPosition != deathPos || got.DeathDimension != world.Overworld {
+		t.Errorf("death position = (%v, %v), want (%v, %v)", got.DeathPosition, got.DeathDimension, deathPos, world.Overworld)
+	}
+
+	// The fields compared above can't be compared using reflect.DeepEqual, so
+	// clear them before comparing the remaining fields.
+	for _, d := range []*player.Data{&got, &want} {
+		d.Inventory.Items, d.EnderChestInventory, d.Inventory.Helmet, d.Inventory.OffHand = nil, nil, item.Stack{}, item.Stack{}
+		d.Effects, d.DeathPosition = nil, nil
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loaded data = %+v, want %+v", got, want)
+	}
+}
+
+func TestProviderLoadUnknown(t *testing.T) {
+	p := newTestProvider(t)
+	if _, err := p.Load(uuid.New(), func(world.Dimension) *world.World { return nil }); err == nil {
+		t.Error("loading an unknown player did not return an error")
+	}
+}
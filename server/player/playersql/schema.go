@@ -0,0 +1,123 @@
+package playersql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// migrations holds the statements executed to migrate the schema of the
+// database from one version to the next. migrations[i] migrates the schema
+// from version i to version i+1. New migrations must only ever be appended:
+// existing migrations must never be changed, as databases may already have
+// been migrated using them.
+var migrations = [][]string{
+	{
+		`CREATE TABLE players (
+			uuid VARCHAR(36) NOT NULL PRIMARY KEY,
+			username VARCHAR(16) NOT NULL,
+			pos_x REAL NOT NULL, pos_y REAL NOT NULL, pos_z REAL NOT NULL,
+			vel_x REAL NOT NULL, vel_y REAL NOT NULL, vel_z REAL NOT NULL,
+			yaw REAL NOT NULL, pitch REAL NOT NULL,
+			health REAL NOT NULL, max_health REAL NOT NULL,
+			hunger INTEGER NOT NULL, food_tick INTEGER NOT NULL,
+			exhaustion REAL NOT NULL, saturation REAL NOT NULL, absorption REAL NOT NULL,
+			enchantment_seed BIGINT NOT NULL,
+			experience INTEGER NOT NULL,
+			air_supply BIGINT NOT NULL, max_air_supply BIGINT NOT NULL,
+			game_mode INTEGER NOT NULL,
+			fire_ticks BIGINT NOT NULL,
+			fall_distance REAL NOT NULL,
+			dimension INTEGER NOT NULL,
+			main_hand_slot INTEGER NOT NULL,
+			inventory BLOB,
+			helmet BLOB, chestplate BLOB, leggings BLOB, boots BLOB,
+			off_hand BLOB,
+			ender_chest BLOB
+		)`,
+		`CREATE TABLE player_effects (
+			uuid VARCHAR(36) NOT NULL,
+			effect_id INTEGER NOT NULL,
+			level INTEGER NOT NULL,
+			duration_ms BIGINT NOT NULL,
+			ambient BOOLEAN NOT NULL,
+			PRIMARY KEY (uuid, effect_id)
+		)`,
+	},
+	{
+		`ALTER TABLE players ADD COLUMN name_tag TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE players ADD COLUMN score_tag TEXT NOT NULL DEFAULT ''`,
+		`ALTER TABLE players ADD COLUMN flying BOOLEAN NOT NULL DEFAULT FALSE`,
+		`ALTER TABLE players ADD COLUMN death_x REAL`,
+		`ALTER TABLE players ADD COLUMN death_y REAL`,
+		`ALTER TABLE players ADD COLUMN death_z REAL`,
+		`ALTER TABLE players ADD COLUMN death_dimension INTEGER`,
+		`CREATE TABLE player_world_spawns (
+			uuid VARCHAR(36) NOT NULL,
+			world VARCHAR(255) NOT NULL,
+			dimension INTEGER NOT NULL,
+			x INTEGER NOT NULL, y INTEGER NOT NULL, z INTEGER NOT NULL,
+			bed BOOLEAN NOT NULL,
+			PRIMARY KEY (uuid, world, dimension)
+		)`,
+		`CREATE TABLE player_cooldowns (
+			uuid VARCHAR(36) NOT NULL,
+			item VARCHAR(255) NOT NULL,
+			remaining_ms BIGINT NOT NULL,
+			PRIMARY KEY (uuid, item)
+		)`,
+		`CREATE TABLE player_custom_data (
+			uuid VARCHAR(36) NOT NULL,
+			data_key VARCHAR(255) NOT NULL,
+			data BLOB NOT NULL,
+			PRIMARY KEY (uuid, data_key)
+		)`,
+	},
+}
+
+// migrate migrates the schema of the database passed to the latest version.
+// Migrations already applied, as recorded in the schema_version table, are
+// skipped.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (version INTEGER NOT NULL)`); err != nil {
+		return fmt.Errorf("create schema_version table: %w", err)
+	}
+	var ver int
+	err := db.QueryRow(`SELECT version FROM schema_version`).Scan(&ver)
+	if errors.Is(err, sql.ErrNoRows) {
+		if _, err := db.Exec(`INSERT INTO schema_version (version) VALUES (0)`); err != nil {
+			return fmt.Errorf("initialise schema version: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("read schema version: %w", err)
+	}
+	if ver > len(migrations) {
+		return fmt.Errorf("schema version %v is newer than the latest supported version %v", ver, len(migrations))
+	}
+	for ; ver < len(migrations); ver++ {
+		if err := applyMigration(db, ver); err != nil {
+			return fmt.Errorf("migrate schema from version %v to %v: %w", ver, ver+1, err)
+		}
+	}
+	return nil
+}
+
+// applyMigration applies the migration from the version passed to the next
+// version in a single transaction.
+func applyMigration(db *sql.DB, ver int) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, stmt := range migrations[ver] {
+		if _, err := tx.Exec(stmt); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if _, err := tx.Exec(`UPDATE schema_version SET version = ?`, ver+1); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
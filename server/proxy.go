@@ -0,0 +1,292 @@
+package server
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"golang.org/x/exp/slices"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProxyConfig holds settings for running a Server behind a proxy such as
+// WaterdogPE. Players joining through a proxy all connect from the address of
+// the proxy and without XBOX Live authentication, so their real IP address
+// and XUID are forwarded by the proxy in the client data of the login, using
+// the Waterdog_IP and Waterdog_XUID claims (the 'login extras' of WaterdogPE).
+// Forwarded identities are only accepted from connections that pass the
+// verification configured, after which they are returned by
+// player.Player.Addr and player.Player.XUID and passed to the Allower.
+//
+// At least one of TrustedAddresses and Secret must be set: Without either,
+// anyone could connect and claim any identity.
+//
+// Players may be moved between backend servers behind the same proxy using
+// player.Player.Transfer, passing the address under which the proxy knows the
+// backend server. A proxy that handles transfers from backend servers, such as
+// WaterdogPE, intercepts the Transfer packet sent and connects the player to
+// that backend server itself, keeping the connection of the player to the
+// proxy.
+type ProxyConfig struct {
+	// TrustedAddresses is a list of IP addresses and CIDR ranges, such as
+	// "10.0.0.0/8", of proxies allowed to connect. Connections from other
+	// addresses are refused. If empty, connections from any address are
+	// accepted, so Secret must be set.
+	TrustedAddresses []string
+	// Secret is a secret shared with the proxy. If non-empty, the client data
+	// of every login must hold a ProxyTimestamp claim with the Unix time in
+	// seconds at which the proxy forwarded the login, and a ProxySignature
+	// claim with the base64 (standard encoding) HMAC-SHA256 of the forwarded
+	// XUID, the forwarded IP, the display name and identity UUID of the
+	// login's identity data and the timestamp in decimal, separated by null
+	// bytes, using Secret as key. Logins with a missing or invalid signature,
+	// or with a timestamp more than SignatureMaxAge away from the current
+	// time, are refused, so that captured logins cannot be replayed later.
+	Secret string
+	// SignatureMaxAge is the maximum difference between the ProxyTimestamp of
+	// a login and the current time. If 0, a maximum age of 30 seconds is used.
+	SignatureMaxAge time.Duration
+}
+
+// Claims of the client data of a login that hold the identity forwarded by a
+// proxy.
+const (
+	forwardedXUIDClaim = "Waterdog_XUID"
+	forwardedIPClaim   = "Waterdog_IP"
+	signatureClaim     = "ProxySignature"
+	timestampClaim     = "ProxyTimestamp"
+)
+
+// proxy verifies and applies identities forwarded by proxies using a
+// ProxyConfig.
+type proxy struct {
+	conf    ProxyConfig
+	trusted []*net.IPNet
+
+	mu sync.Mutex
+	// logins holds the connection requests of logins that have not yet been
+	// accepted, indexed by the address of the connection.
+	logins map[string]pendingLogin
+}
+
+// pendingLogin is a connection request of a login that was not yet accepted.
+type pendingLogin struct {
+	request []byte
+	t       time.Time
+}
+
+// loginTimeout is the duration after which a pending login that was not
+// accepted is discarded.
+const loginTimeout = time.Minute
+
+// newProxy creates a proxy using the ProxyConfig passed. An error is returned
+// if any of the TrustedAddresses is invalid, or if neither TrustedAddresses
+// nor Secret is set.
+func newProxy(conf ProxyConfig) (*proxy, error) {
+	if len(conf.TrustedAddresses) == 0 && conf.Secret == "" {
+		return nil, fmt.Errorf("trusted addresses or secret must be set to verify forwarded identities")
+	}
+	if conf.SignatureMaxAge <= 0 {
+		conf.SignatureMaxAge = time.Second * 30
+	}
+	p := &proxy{conf: conf, logins: map[string]pendingLogin{}}
+	for _, addr := range conf.TrustedAddresses {
+		if !strings.Contains(addr, "/") {
+			if strings.Contains(addr, ":") {
+				addr += "/128"
+			} else {
+				addr += "/32"
+			}
+		}
+		_, n, err := net.ParseCIDR(addr)
+		if err != nil {
+			return nil, fmt.Errorf("parse trusted address: %w", err)
+		}
+		p.trusted = append(p.trusted, n)
+	}
+	return p, nil
+}
+
+// packetFunc may be used as minecraft.ListenConfig.PacketFunc to capture the
+// connection requests of logins.
+func (p *proxy) packetFunc(header packet.Header, payload []byte, src, _ net.Addr) {
+	if header.PacketID != packet.IDLogin {
+		return
+	}
+	defer func() {
+		// Malformed logins cause a panic while decoding. These are rejected by
+		// the minecraft.Listener already, so we can safely ignore them here.
+		_ = recover()
+	}()
+	pk := &packet.Login{}
+	pk.Marshal(protocol.NewReader(bytes.NewBuffer(payload), 0))
+
+	now := time.Now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for addr, l := range p.logins {
+		if now.Sub(l.t) > loginTimeout {
+			delete(p.logins, addr)
+		}
+	}
+	p.logins[src.String()] = pendingLogin{request: slices.Clone(pk.ConnectionRequest), t: now}
+}
+
+// forward verifies the identity forwarded by the proxy of the connection
+// passed and returns a connection with the forwarded address and XUID. An
+// error is returned if the connection is not trusted or if the forwarded
+// identity is missing or invalid.
+func (p *proxy) forward(conn *minecraft.Conn) (*proxiedConn, error) {
+	p.mu.Lock()
+	l, ok := p.logins[conn.RemoteAddr().String()]
+	delete(p.logins, conn.RemoteAddr().String())
+	p.mu.Unlock()
+
+	if !p.trustedAddr(conn.RemoteAddr()) {
+		return nil, fmt.Errorf("connection from untrusted address")
+	}
+	if !ok {
+		return nil, fmt.Errorf("no login found")
+	}
+	claims, err := clientDataClaims(l.request)
+	if err != nil {
+		return nil, fmt.Errorf("parse client data: %w", err)
+	}
+	xuid, _ := claims[forwardedXUIDClaim].(string)
+	ip, _ := claims[forwardedIPClaim].(string)
+	if ip == "" {
+		return nil, fmt.Errorf("no forwarded identity: client data has no %v claim", forwardedIPClaim)
+	}
+	if _, err := strconv.ParseInt(xuid, 10, 64); err != nil && xuid != "" {
+		return nil, fmt.Errorf("forwarded XUID %q is not an int64", xuid)
+	}
+	if p.conf.Secret != "" {
+		timestamp, ok := claims[timestampClaim].(float64)
+		if !ok {
+			return nil, fmt.Errorf("client data has no %v claim", timestampClaim)
+		}
+		issued := time.Unix(int64(timestamp), 0)
+		if age := time.Since(issued); age > p.conf.SignatureMaxAge || age < -p.conf.SignatureMaxAge {
+			return nil, fmt.Errorf("%v claim is %v away from the current time", timestampClaim, age.Round(time.Second))
+		}
+		signature, _ := claims[signatureClaim].(string)
+		if !p.validSignature(xuid, ip, conn.IdentityData(), int64(timestamp), signature) {
+			return nil, fmt.Errorf("invalid %v claim", signatureClaim)
+		}
+	}
+	addr, err := forwardedAddr(ip)
+	if err != nil {
+		return nil, err
+	}
+	identity := conn.IdentityData()
+	identity.XUID = xuid
+	return &proxiedConn{Conn: conn, addr: addr, identity: identity}, nil
+}
+
+// trustedAddr checks if a connection from the address passed may forward
+// identities.
+func (p *proxy) trustedAddr(addr net.Addr) bool {
+	if len(p.trusted) == 0 {
+		return true
+	}
+	udp, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return false
+	}
+	for _, n := range p.trusted {
+		if n.Contains(udp.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// validSignature checks if the signature passed is the valid signature of the
+// forwarded XUID and IP, the display name and identity UUID of the login and
+// the timestamp of the login. Signing the name and identity too prevents a
+// signed login from being reused under a different name.
+func (p *proxy) validSignature(xuid, ip string, identity login.IdentityData, timestamp int64, signature string) bool {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(p.conf.Secret))
+	mac.Write([]byte(strings.Join([]string{xuid, ip, identity.DisplayName, identity.Identity, strconv.FormatInt(timestamp, 10)}, "\x00")))
+	return hmac.Equal(sig, mac.Sum(nil))
+}
+
+// forwardedAddr parses an IP forwarded by a proxy, optionally followed by a
+// port, as a *net.UDPAddr.
+func forwardedAddr(s string) (*net.UDPAddr, error) {
+	host, port := s, 0
+	if h, portStr, err := net.SplitHostPort(s); err == nil {
+		host = h
+		if port, err = strconv.Atoi(portStr); err != nil {
+			return nil, fmt.Errorf("invalid forwarded port %q", portStr)
+		}
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid forwarded IP %q", s)
+	}
+	return &net.UDPAddr{IP: ip, Port: port}, nil
+}
+
+// clientDataClaims returns the claims of the client data token of the
+// connection request of a login. The token is not verified, as it was already
+// verified when the login was accepted.
+func clientDataClaims(request []byte) (map[string]any, error) {
+	buf := bytes.NewBuffer(request)
+	var chainLength int32
+	if err := binary.Read(buf, binary.LittleEndian, &chainLength); err != nil {
+		return nil, fmt.Errorf("read chain length: %w", err)
+	}
+	_ = buf.Next(int(chainLength))
+	var tokenLength int32
+	if err := binary.Read(buf, binary.LittleEndian, &tokenLength); err != nil {
+		return nil, fmt.Errorf("read token length: %w", err)
+	}
+	parts := strings.Split(string(buf.Next(int(tokenLength))), ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("client data token has %v parts, expected 3", len(parts))
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode token payload: %w", err)
+	}
+	claims := map[string]any{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("decode token claims: %w", err)
+	}
+	return claims, nil
+}
+
+// proxiedConn is a *minecraft.Conn of a player that joined through a proxy. It
+// returns the address and XUID forwarded by the proxy.
+type proxiedConn struct {
+	*minecraft.Conn
+	addr     net.Addr
+	identity login.IdentityData
+}
+
+// RemoteAddr returns the address of the player forwarded by the proxy.
+func (c *proxiedConn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// IdentityData returns the login.IdentityData of the connection with the XUID
+// forwarded by the proxy.
+func (c *proxiedConn) IdentityData() login.IdentityData {
+	return c.identity
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/query"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"net"
+)
+
+// QueryConfig holds settings for the query protocol responder of a Server. The
+// query protocol (GameSpy4/UT3) is used by server lists and monitoring tools to
+// obtain information about the server, such as the names of online players.
+type QueryConfig struct {
+	// Address is the UDP address that queries are answered on. If empty,
+	// queries are answered on the port of the listener created by
+	// UserConfig.Config, sharing the port with RakNet. Listeners added to
+	// Config.Listeners manually do not answer queries, so Address must be set
+	// if only custom Listeners are used.
+	Address string
+	// Plugins is a list of names of plugins that are shown to clients sending
+	// a full query.
+	Plugins []string
+}
+
+// createQueryResponder creates the query.Responder of the Server if enabled in
+// the Config. If queries should be answered on the port of the standard
+// listener, the query.Responder is bound to that listener when it is created.
+func (srv *Server) createQueryResponder() {
+	if srv.conf.Query == nil {
+		return
+	}
+	srv.query = query.NewResponder(srv.queryInfo)
+	if srv.conf.Query.Address == "" {
+		srv.conf.queryResponder = srv.query
+	}
+}
+
+// startQuery starts answering queries on the address set in the QueryConfig,
+// if any.
+func (srv *Server) startQuery() {
+	if srv.query == nil || srv.conf.Query.Address == "" {
+		return
+	}
+	conn, err := net.ListenPacket("udp", srv.conf.Query.Address)
+	if err != nil {
+		srv.conf.Log.Fatalf("create query listener: %v", err)
+	}
+	srv.queryConn = conn
+	srv.conf.Log.Infof("Query running on %v.", conn.LocalAddr())
+	go func() {
+		_ = srv.query.Serve(conn)
+	}()
+}
+
+// queryInfo returns the query.Info of the Server, which is sent to clients
+// sending a query.
+func (srv *Server) queryInfo() query.Info {
+	players := srv.Players()
+	names := make([]string, len(players))
+	for i, p := range players {
+		names[i] = p.Name()
+	}
+	return query.Info{
+		ServerName:   srv.conf.Name,
+		GameType:     "SMP",
+		Version:      protocol.CurrentVersion,
+		ServerEngine: "Dragonfly",
+		Plugins:      srv.conf.Query.Plugins,
+		WorldName:    srv.world.Name(),
+		Players:      names,
+		MaxPlayers:   srv.MaxPlayerCount(),
+		Whitelist:    whitelistEnabled(srv.conf.Allower),
+		Address:      srv.queryAddr.Load(),
+	}
+}
+
+// trackListenerAddr stores the address of the Listener passed as the address
+// reported to query clients, if it has a UDP address and no address was
+// stored yet.
+func (srv *Server) trackListenerAddr(l Listener) {
+	if a, ok := l.(interface{ Addr() net.Addr }); ok {
+		if addr, ok := a.Addr().(*net.UDPAddr); ok && srv.queryAddr.Load() == nil {
+			srv.queryAddr.Store(addr)
+		}
+	}
+}
+
+// whitelistEnabled checks if the Allower passed has a whitelist that is
+// enabled, such as an AccessList with its whitelist enabled.
+func whitelistEnabled(a Allower) bool {
+	w, ok := a.(interface{ WhitelistEnabled() bool })
+	return ok && w.WhitelistEnabled()
+}
@@ -0,0 +1,7 @@
+// Package query implements a responder for the UDP query protocol (GameSpy4/UT3) used by Minecraft Bedrock
+// Edition servers. Server lists and monitoring tools use the protocol to obtain information such as the
+// server name, the players online and the plugins of a server.
+//
+// A Responder may either serve queries on a separate net.PacketConn using Responder.Serve, or share the port
+// of a RakNet listener using Responder.Bind.
+package query
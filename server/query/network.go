@@ -0,0 +1,104 @@
+package query
+
+import (
+	"github.com/sandertv/go-raknet"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"net"
+	"sync"
+)
+
+// networkID is the ID under which the minecraft.Network answering query requests is registered.
+const networkID = "raknet+query"
+
+var (
+	// registerOnce registers the minecraft.Network answering query requests the first time Responder.Bind is
+	// called. Networks registered can never be removed, so a single network routes query requests to the
+	// Responder bound to each listener.
+	registerOnce sync.Once
+	// boundMu protects bound.
+	boundMu sync.Mutex
+	// bound holds the Responders bound to the next listeners created on an address, in the order that they
+	// were bound.
+	bound = map[string][]*Responder{}
+)
+
+// Bind binds the Responder to the next listener created on the address passed, so that query requests
+// arriving on the port of that listener are answered by the Responder. Bind returns the ID of a
+// minecraft.Network that must be passed to minecraft.ListenConfig.Listen, together with the same address, to
+// create the listener. The listener otherwise listens using RakNet.
+func (r *Responder) Bind(address string) (network string) {
+	registerOnce.Do(func() {
+		minecraft.RegisterNetwork(networkID, queryNetwork{})
+	})
+	boundMu.Lock()
+	defer boundMu.Unlock()
+	bound[address] = append(bound[address], r)
+	return networkID
+}
+
+// boundResponder removes and returns the Responder bound first to the address passed using Responder.Bind.
+// False is returned if no Responder was bound to the address.
+func boundResponder(address string) (*Responder, bool) {
+	boundMu.Lock()
+	defer boundMu.Unlock()
+	responders := bound[address]
+	if len(responders) == 0 {
+		return nil, false
+	}
+	if len(responders) == 1 {
+		delete(bound, address)
+	} else {
+		bound[address] = responders[1:]
+	}
+	return responders[0], true
+}
+
+// queryNetwork implements minecraft.Network by wrapping around the RakNet network, intercepting query requests
+// before they reach RakNet.
+type queryNetwork struct {
+	minecraft.RakNet
+}
+
+// Listen ...
+func (n queryNetwork) Listen(address string) (minecraft.NetworkListener, error) {
+	r, ok := boundResponder(address)
+	if !ok {
+		return n.RakNet.Listen(address)
+	}
+	return raknet.ListenConfig{UpstreamPacketListener: upstream{r: r}}.Listen(address)
+}
+
+// upstream implements raknet.UpstreamPacketListener to create net.PacketConns that answer query requests
+// using a Responder.
+type upstream struct {
+	r *Responder
+}
+
+// ListenPacket ...
+func (u upstream) ListenPacket(network, address string) (net.PacketConn, error) {
+	conn, err := net.ListenPacket(network, address)
+	if err != nil {
+		return nil, err
+	}
+	return &packetConn{PacketConn: conn, r: u.r}, nil
+}
+
+// packetConn wraps around a net.PacketConn and answers query requests read from it, so that they are never
+// returned by ReadFrom.
+type packetConn struct {
+	net.PacketConn
+	r *Responder
+}
+
+// ReadFrom reads the next packet that is not a query request from the net.PacketConn.
+func (conn *packetConn) ReadFrom(b []byte) (int, net.Addr, error) {
+	for {
+		n, addr, err := conn.PacketConn.ReadFrom(b)
+		if err != nil || !IsQuery(b[:n]) {
+			return n, addr, err
+		}
+		if resp, ok := conn.r.Handle(b[:n], addr); ok {
+			_, _ = conn.PacketConn.WriteTo(resp, addr)
+		}
+	}
+}
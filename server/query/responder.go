@@ -0,0 +1,227 @@
+package query
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"hash/fnv"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Info holds the information about a server that is sent in response to a query.
+type Info struct {
+	// ServerName is the name of the server, as shown in the server list.
+	ServerName string
+	// GameType is the type of the game, which is "SMP" for most servers.
+	GameType string
+	// Version is the Minecraft version of the server, such as "1.19.80".
+	Version string
+	// ServerEngine is the name of the software running the server.
+	ServerEngine string
+	// Plugins is a list of names of the plugins that the server runs.
+	Plugins []string
+	// WorldName is the name of the default world of the server.
+	WorldName string
+	// Players holds the names of all players online.
+	Players []string
+	// MaxPlayers is the maximum amount of players that may be online at the same time.
+	MaxPlayers int
+	// Whitelist specifies if the server has a whitelist enabled.
+	Whitelist bool
+	// Address is the address that players join the server on.
+	Address *net.UDPAddr
+}
+
+// Responder responds to queries using the Info returned by a function. A Responder is safe for concurrent
+// use.
+type Responder struct {
+	info func() Info
+
+	mu                sync.Mutex
+	secret, oldSecret [8]byte
+	rotated           time.Time
+}
+
+// tokenLifetime is the duration after which the secret used to generate challenge tokens is rotated. Tokens
+// generated with the previous secret remain valid until the secret is rotated again.
+const tokenLifetime = time.Second * 30
+
+// NewResponder returns a Responder that responds to queries using the Info returned by the function passed.
+// The function is called for every full or basic query received.
+func NewResponder(info func() Info) *Responder {
+	r := &Responder{info: info}
+	r.rotate()
+	r.oldSecret = r.secret
+	return r
+}
+
+// Serve serves queries received on the net.PacketConn passed until reading from it fails, for example
+// because it is closed. Packets that are not queries are ignored.
+func (r *Responder) Serve(conn net.PacketConn) error {
+	buf := make([]byte, 1500)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		if resp, ok := r.Handle(buf[:n], addr); ok {
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}
+}
+
+// Query packet types as found in the header of a query request.
+const (
+	typeStat      = 0x00
+	typeHandshake = 0x09
+)
+
+// magic is the prefix of every query request.
+var magic = []byte{0xfe, 0xfd}
+
+// IsQuery checks if the packet data passed is a query request.
+func IsQuery(data []byte) bool {
+	return len(data) >= 7 && bytes.HasPrefix(data, magic)
+}
+
+// Handle handles a query request sent by the address passed and returns the response to send back. False is
+// returned if the data was not a valid query, or if it had an invalid challenge token, in which case nothing
+// should be sent back.
+func (r *Responder) Handle(data []byte, addr net.Addr) ([]byte, bool) {
+	if !IsQuery(data) {
+		return nil, false
+	}
+	t, sessionID, payload := data[2], data[3:7], data[7:]
+
+	resp := bytes.NewBuffer(make([]byte, 0, 256))
+	resp.WriteByte(t)
+	resp.Write(sessionID)
+
+	switch t {
+	case typeHandshake:
+		resp.WriteString(strconv.Itoa(int(r.token(addr, false))))
+		resp.WriteByte(0)
+		return resp.Bytes(), true
+	case typeStat:
+		if len(payload) < 4 || !r.validToken(addr, int32(binary.BigEndian.Uint32(payload))) {
+			return nil, false
+		}
+		info := r.info()
+		if len(payload) >= 8 {
+			writeFullStat(resp, info)
+		} else {
+			writeBasicStat(resp, info)
+		}
+		return resp.Bytes(), true
+	}
+	return nil, false
+}
+
+// writeBasicStat writes the response to a basic stat query to the buffer passed.
+func writeBasicStat(buf *bytes.Buffer, info Info) {
+	ip, port := hostAddress(info)
+	for _, s := range []string{info.ServerName, info.GameType, info.WorldName, strconv.Itoa(len(info.Players)), strconv.Itoa(info.MaxPlayers)} {
+		writeString(buf, s)
+	}
+	_ = binary.Write(buf, binary.LittleEndian, uint16(port))
+	writeString(buf, ip)
+}
+
+// writeFullStat writes the response to a full stat query to the buffer passed.
+func writeFullStat(buf *bytes.Buffer, info Info) {
+	ip, port := hostAddress(info)
+	plugins := info.ServerEngine
+	if len(info.Plugins) > 0 {
+		plugins += ": " + strings.Join(info.Plugins, "; ")
+	}
+	whitelist := "off"
+	if info.Whitelist {
+		whitelist = "on"
+	}
+
+	buf.WriteString("splitnum\x00\x80\x00")
+	for _, kv := range [...][2]string{
+		{"hostname", info.ServerName},
+		{"gametype", info.GameType},
+		{"game_id", "MINECRAFTPE"},
+		{"version", info.Version},
+		{"server_engine", info.ServerEngine},
+		{"plugins", plugins},
+		{"map", info.WorldName},
+		{"numplayers", strconv.Itoa(len(info.Players))},
+		{"maxplayers", strconv.Itoa(info.MaxPlayers)},
+		{"whitelist", whitelist},
+		{"hostip", ip},
+		{"hostport", strconv.Itoa(port)},
+	} {
+		writeString(buf, kv[0])
+		writeString(buf, kv[1])
+	}
+	buf.WriteByte(0)
+
+	buf.WriteString("\x01player_\x00\x00")
+	for _, name := range info.Players {
+		writeString(buf, name)
+	}
+	buf.WriteByte(0)
+}
+
+// hostAddress returns the IP and port of the Address in the Info passed. If no address is set, 0.0.0.0 and
+// the default port 19132 are returned.
+func hostAddress(info Info) (string, int) {
+	if info.Address == nil {
+		return "0.0.0.0", 19132
+	}
+	ip := "0.0.0.0"
+	if info.Address.IP != nil && !info.Address.IP.IsUnspecified() {
+		ip = info.Address.IP.String()
+	}
+	return ip, info.Address.Port
+}
+
+// writeString writes a null-terminated string to the buffer passed. Null bytes in the string are removed,
+// because they would end the string early.
+func writeString(buf *bytes.Buffer, s string) {
+	buf.WriteString(strings.ReplaceAll(s, "\x00", ""))
+	buf.WriteByte(0)
+}
+
+// token returns the challenge token for the address passed. If old is true, the token generated using the
+// previous secret is returned.
+func (r *Responder) token(addr net.Addr, old bool) int32 {
+	r.mu.Lock()
+	if time.Since(r.rotated) > tokenLifetime {
+		r.oldSecret = r.secret
+		r.rotate()
+	}
+	secret := r.secret
+	if old {
+		secret = r.oldSecret
+	}
+	r.mu.Unlock()
+
+	h := fnv.New32a()
+	_, _ = h.Write(secret[:])
+	if udp, ok := addr.(*net.UDPAddr); ok {
+		// Only use the IP, so that NAT changing the port between packets doesn't invalidate the token.
+		_, _ = h.Write(udp.IP)
+	} else {
+		_, _ = h.Write([]byte(addr.String()))
+	}
+	return int32(h.Sum32() & 0x7fffffff)
+}
+
+// validToken checks if a challenge token sent by an address is valid.
+func (r *Responder) validToken(addr net.Addr, token int32) bool {
+	return token == r.token(addr, false) || token == r.token(addr, true)
+}
+
+// rotate generates a new secret for challenge tokens. rotate must be called with r.mu held.
+func (r *Responder) rotate() {
+	_, _ = rand.Read(r.secret[:])
+	r.rotated = time.Now()
+}
@@ -0,0 +1,39 @@
+package server
+
+import (
+	"github.com/df-mc/dragonfly/server/rcon"
+)
+
+// RCONConfig holds settings for the RCON remote console of a Server. RCON
+// clients may execute commands on the server after authenticating with a
+// password. Commands are executed with a *rcon.Source as cmd.Source.
+type RCONConfig struct {
+	// Address is the TCP address that the RCON server listens on, such as
+	// ":25575".
+	Address string
+	// Password is the password that RCON clients must authenticate with. It
+	// must not be empty.
+	Password string
+}
+
+// startRCON starts the RCON server if enabled in the Config.
+func (srv *Server) startRCON() {
+	if srv.conf.RCON == nil {
+		return
+	}
+	r, err := rcon.Config{
+		Password: srv.conf.RCON.Password,
+		World:    srv.world,
+		Log:      srv.conf.Log,
+	}.Listen(srv.conf.RCON.Address)
+	if err != nil {
+		srv.conf.Log.Fatalf("create rcon server: %v", err)
+	}
+	srv.rcon = r
+	srv.conf.Log.Infof("RCON running on %v.", r.Addr())
+	go func() {
+		if err := r.Serve(); err != nil {
+			srv.conf.Log.Errorf("rcon: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,4 @@
+// Package rcon implements a remote console using the Source RCON protocol. Clients connect over TCP,
+// authenticate with a password and may then execute commands, receiving the output of the command in
+// response. This allows administering a server from panels and scripts without joining it.
+package rcon
@@ -0,0 +1,65 @@
+package rcon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// Packet types of the Source RCON protocol. Note that typeExecCommand and typeAuthResponse share the same
+// value: The direction of the packet determines its meaning.
+const (
+	typeResponseValue = 0
+	typeExecCommand   = 2
+	typeAuthResponse  = 2
+	typeAuth          = 3
+)
+
+const (
+	// maxRequestSize is the maximum size of a packet sent by a client, excluding the size field itself.
+	maxRequestSize = 4096
+	// maxResponseBody is the maximum size of the body of a single response packet. Longer responses are split
+	// over multiple packets.
+	maxResponseBody = 4096
+)
+
+// packet is a packet sent over an RCON connection.
+type packet struct {
+	id, t int32
+	body  string
+}
+
+// readPacket reads a packet from the io.Reader passed.
+func readPacket(r io.Reader) (packet, error) {
+	var size int32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return packet{}, err
+	}
+	if size < 10 || size > maxRequestSize {
+		return packet{}, fmt.Errorf("invalid packet size %v", size)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return packet{}, err
+	}
+	// The body is followed by two null bytes, one terminating the body and one terminating the packet.
+	body, _, _ := bytes.Cut(data[8:], []byte{0})
+	return packet{
+		id:   int32(binary.LittleEndian.Uint32(data)),
+		t:    int32(binary.LittleEndian.Uint32(data[4:])),
+		body: string(body),
+	}, nil
+}
+
+// writePacket writes a packet to the io.Writer passed.
+func writePacket(w io.Writer, pk packet) error {
+	buf := bytes.NewBuffer(make([]byte, 0, len(pk.body)+14))
+	_ = binary.Write(buf, binary.LittleEndian, int32(len(pk.body)+10))
+	_ = binary.Write(buf, binary.LittleEndian, pk.id)
+	_ = binary.Write(buf, binary.LittleEndian, pk.t)
+	buf.WriteString(pk.body)
+	buf.Write([]byte{0, 0})
+	_, err := w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,202 @@
+package rcon
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/text"
+	"net"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Logger is used to log connections and commands executed over RCON.
+type Logger interface {
+	Infof(format string, v ...any)
+	Debugf(format string, v ...any)
+}
+
+// Config holds settings for an RCON Server.
+type Config struct {
+	// Password is the password that clients must authenticate with before executing commands. Listen returns
+	// an error if Password is empty.
+	Password string
+	// World is the world.World that commands are executed in. It is returned by Source.World and is typically
+	// the overworld of the server.
+	World *world.World
+	// Log is the Logger used to log commands executed over RCON. If nil, nothing is logged.
+	Log Logger
+}
+
+// Server is an RCON server accepting connections on a TCP listener. A Server is created using Config.Listen.
+type Server struct {
+	conf Config
+	l    net.Listener
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// Listen creates a Server listening for TCP connections on the address passed. Server.Serve must be called to
+// start accepting connections.
+func (conf Config) Listen(address string) (*Server, error) {
+	if conf.Password == "" {
+		return nil, fmt.Errorf("rcon: password must not be empty")
+	}
+	if conf.World == nil {
+		return nil, fmt.Errorf("rcon: world must not be nil")
+	}
+	if conf.Log == nil {
+		conf.Log = nopLogger{}
+	}
+	l, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("rcon: listen: %w", err)
+	}
+	return &Server{conf: conf, l: l, conns: map[net.Conn]struct{}{}}, nil
+}
+
+// Addr returns the address that the Server is listening on.
+func (srv *Server) Addr() net.Addr {
+	return srv.l.Addr()
+}
+
+// Serve accepts connections until the Server is closed using Close, after which nil is returned. Every
+// connection is handled on a separate goroutine.
+func (srv *Server) Serve() error {
+	for {
+		conn, err := srv.l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		srv.mu.Lock()
+		if srv.closed {
+			srv.mu.Unlock()
+			_ = conn.Close()
+			return nil
+		}
+		srv.conns[conn] = struct{}{}
+		srv.wg.Add(1)
+		srv.mu.Unlock()
+
+		go srv.handle(conn)
+	}
+}
+
+// Close closes the Server and all connections to it. It waits until all commands that are being executed
+// have finished.
+func (srv *Server) Close() error {
+	srv.mu.Lock()
+	srv.closed = true
+	err := srv.l.Close()
+	for conn := range srv.conns {
+		_ = conn.Close()
+	}
+	srv.mu.Unlock()
+
+	srv.wg.Wait()
+	return err
+}
+
+// handle handles a connection until it is closed, either by the client or because the client sent an invalid
+// packet or password.
+func (srv *Server) handle(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+		srv.mu.Lock()
+		delete(srv.conns, conn)
+		srv.mu.Unlock()
+		srv.wg.Done()
+	}()
+	r := bufio.NewReader(conn)
+	authenticated := false
+	for {
+		pk, err := readPacket(r)
+		if err != nil {
+			return
+		}
+		switch {
+		case pk.t == typeAuth:
+			authenticated = subtle.ConstantTimeCompare([]byte(pk.body), []byte(srv.conf.Password)) == 1
+			// Clients expect an empty response value before the auth response.
+			_ = writePacket(conn, packet{id: pk.id, t: typeResponseValue})
+			if !authenticated {
+				srv.conf.Log.Infof("RCON client %v failed to authenticate.", conn.RemoteAddr())
+				_ = writePacket(conn, packet{id: -1, t: typeAuthResponse})
+				return
+			}
+			srv.conf.Log.Debugf("RCON client %v authenticated.", conn.RemoteAddr())
+			_ = writePacket(conn, packet{id: pk.id, t: typeAuthResponse})
+		case !authenticated:
+			return
+		case pk.t == typeExecCommand:
+			for _, body := range split(srv.execute(pk.body, conn.RemoteAddr())) {
+				if err := writePacket(conn, packet{id: pk.id, t: typeResponseValue, body: body}); err != nil {
+					return
+				}
+			}
+		case pk.t == typeResponseValue:
+			// Clients send an empty response value after a command to find the end of a response split over
+			// multiple packets. It is mirrored back, so that the client knows all responses were received.
+			_ = writePacket(conn, packet{id: pk.id, t: typeResponseValue})
+		}
+	}
+}
+
+// execute executes a command line sent by the client with the address passed and returns the output of the
+// command.
+func (srv *Server) execute(commandLine string, addr net.Addr) string {
+	commandLine = strings.TrimPrefix(strings.TrimSpace(commandLine), "/")
+	if commandLine == "" {
+		return ""
+	}
+	srv.conf.Log.Infof("RCON client %v executed command: /%v", addr, commandLine)
+
+	args := strings.Split(commandLine, " ")
+	command, ok := cmd.ByAlias(args[0])
+	if !ok {
+		return fmt.Sprintf("Unknown command: %v. Please check that the command exists.", args[0])
+	}
+	src := &Source{w: srv.conf.World, addr: addr}
+	command.Execute(strings.Join(args[1:], " "), src)
+
+	var lines []string
+	for _, o := range src.out {
+		for _, m := range o.Messages() {
+			lines = append(lines, text.Clean(m))
+		}
+		for _, err := range o.Errors() {
+			lines = append(lines, text.Clean(err.Error()))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// split splits a response into bodies no longer than maxResponseBody bytes, without splitting UTF-8
+// characters. An empty response results in a single empty body.
+func split(s string) []string {
+	bodies := []string{}
+	for len(s) > maxResponseBody {
+		n := maxResponseBody
+		for n > 0 && !utf8.RuneStart(s[n]) {
+			n--
+		}
+		bodies, s = append(bodies, s[:n]), s[n:]
+	}
+	return append(bodies, s)
+}
+
+// nopLogger is a Logger that discards all messages.
+type nopLogger struct{}
+
+func (nopLogger) Infof(string, ...any)  {}
+func (nopLogger) Debugf(string, ...any) {}
@@ -0,0 +1,47 @@
+package rcon
+
+import (
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"net"
+)
+
+// Source is the cmd.Source of commands executed over RCON. Commands may check if they are executed over RCON
+// by asserting the cmd.Source passed to them to be a *Source.
+type Source struct {
+	w    *world.World
+	addr net.Addr
+	out  []*cmd.Output
+}
+
+// Compile time check to make sure *Source implements cmd.Source and cmd.NamedTarget.
+var (
+	_ cmd.Source      = (*Source)(nil)
+	_ cmd.NamedTarget = (*Source)(nil)
+)
+
+// Name returns "RCON".
+func (s *Source) Name() string {
+	return "RCON"
+}
+
+// Position returns the spawn position of the World of the Source.
+func (s *Source) Position() mgl64.Vec3 {
+	return s.w.Spawn().Vec3Middle()
+}
+
+// World returns the world.World that commands executed over RCON are executed in.
+func (s *Source) World() *world.World {
+	return s.w
+}
+
+// RemoteAddr returns the address of the RCON client that executed the command.
+func (s *Source) RemoteAddr() net.Addr {
+	return s.addr
+}
+
+// SendCommandOutput stores the cmd.Output passed, so that it can be sent back to the RCON client.
+func (s *Source) SendCommandOutput(o *cmd.Output) {
+	s.out = append(s.out, o)
+}
@@ -13,6 +13,8 @@ import (
 	_ "github.com/df-mc/dragonfly/server/item" // Imported for maintaining correct initialisation order.
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/query"
+	"github.com/df-mc/dragonfly/server/rcon"
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl32"
@@ -27,6 +29,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"golang.org/x/exp/maps"
 	"math/rand"
+	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"os/signal"
@@ -51,6 +55,14 @@ type Server struct {
 
 	listeners []Listener
 	incoming  chan *session.Session
+	throttle  *throttle
+
+	query     *query.Responder
+	queryConn net.PacketConn
+	queryAddr atomic.Value[*net.UDPAddr]
+
+	rcon    *rcon.Server
+	metrics *http.Server
 
 	pmu sync.RWMutex
 	// p holds a map of all players currently connected to the server. When they
@@ -87,6 +99,9 @@ func (srv *Server) Listen() {
 
 	srv.conf.Log.Infof("Starting Dragonfly for Minecraft v%v...", protocol.CurrentVersion)
 	srv.startListening()
+	srv.startQuery()
+	srv.startRCON()
+	srv.startMetrics()
 	go srv.wait()
 }
 
@@ -209,6 +224,13 @@ func (srv *Server) close() {
 	srv.conf.Log.Infof("Server shutting down...")
 	defer srv.conf.Log.Infof("Server stopped.")
 
+	if srv.rcon != nil {
+		srv.conf.Log.Debugf("Closing RCON server...")
+		if err := srv.rcon.Close(); err != nil {
+			srv.conf.Log.Errorf("Error closing RCON server: %v", err)
+		}
+	}
+
 	srv.conf.Log.Debugf("Disconnecting players...")
 	for _, p := range srv.Players() {
 		p.Disconnect(text.Colourf("<yellow>%v</yellow>", srv.conf.ShutdownMessage))
@@ -233,6 +255,16 @@ func (srv *Server) close() {
 			srv.conf.Log.Errorf("Error closing listener: %v", err)
 		}
 	}
+	if srv.metrics != nil {
+		if err := srv.metrics.Close(); err != nil {
+			srv.conf.Log.Errorf("Error closing metrics server: %v", err)
+		}
+	}
+	if srv.queryConn != nil {
+		if err := srv.queryConn.Close(); err != nil {
+			srv.conf.Log.Errorf("Error closing query listener: %v", err)
+		}
+	}
 }
 
 // listen makes the Server listen for new connections from the Listener passed.
@@ -260,6 +292,12 @@ func (srv *Server) listen(l Listener) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
+			if msg, ok := srv.throttle.allow(c.RemoteAddr()); !ok {
+				srv.conf.Log.Debugf("connection from %v throttled", c.RemoteAddr())
+				_ = c.WritePacket(&packet.Disconnect{Message: msg})
+				_ = c.Close()
+				return
+			}
 			if msg, ok := srv.conf.Allower.Allow(c.RemoteAddr(), c.IdentityData(), c.ClientData()); !ok {
 				_ = c.WritePacket(&packet.Disconnect{HideDisconnectionScreen: msg == "", Message: msg})
 				_ = c.Close()
@@ -282,6 +320,7 @@ func (srv *Server) startListening() {
 			srv.conf.Log.Fatalf("create listener: %v", err)
 		}
 		srv.listeners = append(srv.listeners, l)
+		srv.trackListenerAddr(l)
 		go srv.listen(l)
 	}
 }
@@ -426,6 +465,13 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, data *player.Da
 		w, gm, pos = data.World, data.GameMode, data.Position
 	}
 	s := session.New(conn, srv.conf.MaxChunkRadius, srv.conf.Log, srv.conf.JoinMessage, srv.conf.QuitMessage)
+	s.UseMiddleware(srv.conf.PacketMiddleware...)
+	if srv.conf.MovementValidation != nil {
+		s.ValidateMovement(*srv.conf.MovementValidation)
+	}
+	if srv.conf.CombatValidation != nil {
+		s.ValidateCombat(*srv.conf.CombatValidation)
+	}
 	p := player.NewWithSession(conn.IdentityData().DisplayName, conn.IdentityData().XUID, id, srv.parseSkin(conn.ClientData()), s, pos, data)
 
 	s.Spawn(p, pos, w, gm, srv.handleSessionClose)
@@ -0,0 +1,390 @@
+package servertest
+
+import (
+	"context"
+	"fmt"
+	"github.com/df-mc/atomic"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"net"
+	"sync"
+	"time"
+)
+
+// Client is a headless client connected to a Listener. It is the client end of an in-memory connection, of
+// which the server end is a Conn. A Client may be scripted to send packets to the server and to assert on
+// packets sent back by the server.
+type Client struct {
+	p    *pipe
+	pool packet.Pool
+	id   login.IdentityData
+
+	deadline atomic.Value[time.Time]
+
+	// mu protects the fields below.
+	mu        sync.Mutex
+	data      packet.StartGame
+	pos       mgl64.Vec3
+	rot       cube.Rotation
+	tick      uint64
+	requestID int32
+	heldSlot  int
+	windows   map[uint32]map[uint32]protocol.ItemInstance
+}
+
+// newClient creates a Client using the client end of the pipe passed.
+func newClient(p *pipe, id login.IdentityData) *Client {
+	return &Client{p: p, pool: packet.NewPool(), id: id, requestID: -1, windows: map[uint32]map[uint32]protocol.ItemInstance{}}
+}
+
+// IdentityData returns the identity data the Client was dialed with.
+func (c *Client) IdentityData() login.IdentityData {
+	return c.id
+}
+
+// Spawn reads packets until the packet.StartGame sent by the server is received and spawns the Client, after
+// which the server.Server finishes accepting the connection. Spawn returns an error if the context is
+// cancelled before the packet.StartGame is received.
+func (c *Client) Spawn(ctx context.Context) error {
+	for {
+		pk, err := c.read(ctx)
+		if err != nil {
+			return fmt.Errorf("spawn: %w", err)
+		}
+		if _, ok := pk.(*packet.StartGame); ok {
+			c.p.spawnOnce.Do(func() {
+				close(c.p.spawn)
+			})
+			return nil
+		}
+	}
+}
+
+// StartGame returns the packet.StartGame received during Spawn.
+func (c *Client) StartGame() packet.StartGame {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.data
+}
+
+// Position returns the position of the Client as last sent in Move, or the spawn position if Move was not
+// yet called. Unlike the position in packet.PlayerAuthInput, Position is at the feet of the player.
+func (c *Client) Position() mgl64.Vec3 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.pos
+}
+
+// SetReadDeadline sets the deadline for calls to ReadPacket. A zero time.Time means ReadPacket will not time
+// out.
+func (c *Client) SetReadDeadline(t time.Time) error {
+	c.deadline.Store(t)
+	return nil
+}
+
+// ReadPacket reads the next packet.Packet written by the server. ReadPacket returns an error if the
+// connection is closed and all packets written before were read.
+func (c *Client) ReadPacket() (packet.Packet, error) {
+	ctx := context.Background()
+	if t := c.deadline.Load(); !t.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, t)
+		defer cancel()
+	}
+	return c.read(ctx)
+}
+
+// read reads the next packet.Packet written by the server until the context passed is cancelled. Packets
+// that the Client keeps track of, such as inventory contents, are handled before being returned.
+func (c *Client) read(ctx context.Context) (packet.Packet, error) {
+	data, err := c.p.toClient.pop(c.p.close, ctx.Done())
+	if err != nil {
+		if err == errDone {
+			err = ctx.Err()
+		}
+		return nil, fmt.Errorf("read packet: %w", err)
+	}
+	pk, err := decode(data, c.pool, c.p.shieldID.Load())
+	if err != nil {
+		return nil, err
+	}
+	c.handle(pk)
+	return pk, nil
+}
+
+// handle updates the state of the Client using a packet.Packet sent by the server.
+func (c *Client) handle(pk packet.Packet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch pk := pk.(type) {
+	case *packet.StartGame:
+		c.data = *pk
+		c.pos = vec32To64(pk.PlayerPosition).Sub(mgl64.Vec3{0, 1.62})
+		c.rot = cube.Rotation{float64(pk.Yaw), float64(pk.Pitch)}
+	case *packet.MovePlayer:
+		if pk.EntityRuntimeID == c.data.EntityRuntimeID {
+			c.pos = vec32To64(pk.Position).Sub(mgl64.Vec3{0, 1.62})
+			c.rot = cube.Rotation{float64(pk.Yaw), float64(pk.Pitch)}
+		}
+	case *packet.InventoryContent:
+		w := map[uint32]protocol.ItemInstance{}
+		for i, it := range pk.Content {
+			w[uint32(i)] = it
+		}
+		c.windows[pk.WindowID] = w
+	case *packet.InventorySlot:
+		c.window(pk.WindowID)[pk.Slot] = pk.NewItem
+	case *packet.MobEquipment:
+		if pk.EntityRuntimeID == c.data.EntityRuntimeID && pk.WindowID == protocol.WindowIDInventory {
+			c.heldSlot = int(pk.HotBarSlot)
+		}
+	case *packet.ItemStackResponse:
+		for _, resp := range pk.Responses {
+			if resp.Status != protocol.ItemStackResponseStatusOK {
+				continue
+			}
+			for _, info := range resp.ContainerInfo {
+				windowID, ok := containerWindow(info.ContainerID)
+				if !ok {
+					continue
+				}
+				w := c.window(windowID)
+				for _, slot := range info.SlotInfo {
+					if slot.Count == 0 {
+						w[uint32(slot.Slot)] = protocol.ItemInstance{}
+						continue
+					}
+					it := w[uint32(slot.Slot)]
+					it.StackNetworkID, it.Stack.Count = slot.StackNetworkID, uint16(slot.Count)
+					w[uint32(slot.Slot)] = it
+				}
+			}
+		}
+	}
+}
+
+// window returns the slots of a window by its ID, creating it if it did not yet exist. window must be called
+// with c.mu held.
+func (c *Client) window(id uint32) map[uint32]protocol.ItemInstance {
+	w, ok := c.windows[id]
+	if !ok {
+		w = map[uint32]protocol.ItemInstance{}
+		c.windows[id] = w
+	}
+	return w
+}
+
+// containerWindow returns the window ID that holds the slots of a container ID found in an
+// packet.ItemStackResponse. False is returned if the Client does not keep track of the container.
+func containerWindow(containerID byte) (uint32, bool) {
+	switch containerID {
+	case protocol.ContainerCombinedHotBarAndInventory, protocol.ContainerHotBar, protocol.ContainerInventory:
+		return protocol.WindowIDInventory, true
+	case protocol.ContainerOffhand:
+		return protocol.WindowIDOffHand, true
+	case protocol.ContainerArmor:
+		return protocol.WindowIDArmour, true
+	}
+	return 0, false
+}
+
+// Slot returns the item in a slot of a window, as last sent by the server through a packet.InventoryContent,
+// packet.InventorySlot or packet.ItemStackResponse. The StackNetworkID of the item returned may be used to
+// refer to the item in an ItemStackRequest. Slot only reflects packets that were read from the Client.
+func (c *Client) Slot(windowID uint32, slot int) protocol.ItemInstance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.windows[windowID][uint32(slot)]
+}
+
+// WritePacket writes a packet.Packet to the server.
+func (c *Client) WritePacket(pk packet.Packet) error {
+	select {
+	case <-c.p.close:
+		return fmt.Errorf("write packet: %w", net.ErrClosed)
+	default:
+	}
+	c.p.toServer.push(encode(pk, c.p.shieldID.Load()))
+	return nil
+}
+
+// Expect reads packets from the Client until a packet of the type T is read, which is returned. Packets of
+// other types read in the meantime are discarded. An error is returned if no such packet was read within the
+// timeout passed.
+func Expect[T packet.Packet](c *Client, timeout time.Duration) (T, error) {
+	pk, err := c.ExpectFunc(timeout, func(pk packet.Packet) bool {
+		_, ok := pk.(T)
+		return ok
+	})
+	if err != nil {
+		var zero T
+		return zero, fmt.Errorf("expect %T: %w", zero, err)
+	}
+	return pk.(T), nil
+}
+
+// ExpectFunc reads packets from the Client until f returns true for a packet read, which is returned.
+// Packets for which f returns false are discarded. An error is returned if no such packet was read within the
+// timeout passed.
+func (c *Client) ExpectFunc(timeout time.Duration, f func(pk packet.Packet) bool) (packet.Packet, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	for {
+		pk, err := c.read(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if f(pk) {
+			return pk, nil
+		}
+	}
+}
+
+// Move sends a packet.PlayerAuthInput to move the Client to the position passed, with the rotation passed.
+// The position is at the feet of the player, as returned by Player.Position.
+func (c *Client) Move(pos mgl64.Vec3, rot cube.Rotation) error {
+	c.mu.Lock()
+	delta := pos.Sub(c.pos)
+	c.pos, c.rot = pos, rot
+	c.tick++
+	pk := &packet.PlayerAuthInput{
+		Pitch:     float32(rot.Pitch()),
+		Yaw:       float32(rot.Yaw()),
+		HeadYaw:   float32(rot.Yaw()),
+		Position:  vec64To32(pos.Add(mgl64.Vec3{0, 1.62})),
+		Delta:     vec64To32(delta),
+		InputMode: packet.InputModeMouse,
+		PlayMode:  packet.PlayModeNormal,
+		Tick:      c.tick,
+	}
+	c.mu.Unlock()
+	return c.WritePacket(pk)
+}
+
+// Tick sends a packet.PlayerAuthInput without changing the position or rotation of the Client, as the
+// client does every tick.
+func (c *Client) Tick() error {
+	c.mu.Lock()
+	pos, rot := c.pos, c.rot
+	c.mu.Unlock()
+	return c.Move(pos, rot)
+}
+
+// SelectSlot sends a packet.MobEquipment to change the held hotbar slot of the Client.
+func (c *Client) SelectSlot(slot int) error {
+	c.mu.Lock()
+	c.heldSlot = slot
+	rid := c.data.EntityRuntimeID
+	c.mu.Unlock()
+	return c.WritePacket(&packet.MobEquipment{
+		EntityRuntimeID: rid,
+		NewItem:         c.Slot(protocol.WindowIDInventory, slot),
+		InventorySlot:   byte(slot),
+		HotBarSlot:      byte(slot),
+		WindowID:        protocol.WindowIDInventory,
+	})
+}
+
+// UseItemOnBlock sends a packet.InventoryTransaction to use the held item on the face of the block at the
+// position passed. clickPos is the position on the block that was clicked, ranging from 0-1 on all axes.
+func (c *Client) UseItemOnBlock(pos cube.Pos, face cube.Face, clickPos mgl64.Vec3) error {
+	return c.useItem(protocol.UseItemActionClickBlock, pos, face, clickPos)
+}
+
+// UseItem sends a packet.InventoryTransaction to use the held item without clicking a block.
+func (c *Client) UseItem() error {
+	return c.useItem(protocol.UseItemActionClickAir, cube.Pos{}, -1, mgl64.Vec3{})
+}
+
+// useItem sends a packet.InventoryTransaction with protocol.UseItemTransactionData.
+func (c *Client) useItem(action uint32, pos cube.Pos, face cube.Face, clickPos mgl64.Vec3) error {
+	c.mu.Lock()
+	slot, eyePos := c.heldSlot, c.pos.Add(mgl64.Vec3{0, 1.62})
+	c.mu.Unlock()
+	return c.WritePacket(&packet.InventoryTransaction{TransactionData: &protocol.UseItemTransactionData{
+		ActionType:      action,
+		BlockPosition:   protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+		BlockFace:       int32(face),
+		HotBarSlot:      int32(slot),
+		HeldItem:        c.Slot(protocol.WindowIDInventory, slot),
+		Position:        vec64To32(eyePos),
+		ClickedPosition: vec64To32(clickPos),
+	}})
+}
+
+// AttackEntity sends a packet.InventoryTransaction to attack the entity with the runtime ID passed.
+func (c *Client) AttackEntity(runtimeID uint64) error {
+	return c.useItemOnEntity(protocol.UseItemOnEntityActionAttack, runtimeID)
+}
+
+// InteractWithEntity sends a packet.InventoryTransaction to interact with the entity with the runtime ID
+// passed using the held item.
+func (c *Client) InteractWithEntity(runtimeID uint64) error {
+	return c.useItemOnEntity(protocol.UseItemOnEntityActionInteract, runtimeID)
+}
+
+// useItemOnEntity sends a packet.InventoryTransaction with protocol.UseItemOnEntityTransactionData.
+func (c *Client) useItemOnEntity(action uint32, runtimeID uint64) error {
+	c.mu.Lock()
+	slot, pos := c.heldSlot, c.pos
+	c.mu.Unlock()
+	return c.WritePacket(&packet.InventoryTransaction{TransactionData: &protocol.UseItemOnEntityTransactionData{
+		TargetEntityRuntimeID: runtimeID,
+		ActionType:            action,
+		HotBarSlot:            int32(slot),
+		HeldItem:              c.Slot(protocol.WindowIDInventory, slot),
+		Position:              vec64To32(pos),
+	}})
+}
+
+// RequestItemStack sends a packet.ItemStackRequest with the actions passed and returns the ID of the request,
+// which the server uses in its packet.ItemStackResponse.
+func (c *Client) RequestItemStack(actions ...protocol.StackRequestAction) (int32, error) {
+	c.mu.Lock()
+	id := c.requestID
+	c.requestID -= 2
+	c.mu.Unlock()
+	return id, c.WritePacket(&packet.ItemStackRequest{Requests: []protocol.ItemStackRequest{{
+		RequestID: id,
+		Actions:   actions,
+	}}})
+}
+
+// Chat sends a chat message to the server using a packet.Text.
+func (c *Client) Chat(msg string) error {
+	return c.WritePacket(&packet.Text{
+		TextType:   packet.TextTypeChat,
+		SourceName: c.id.DisplayName,
+		Message:    msg,
+		XUID:       c.id.XUID,
+	})
+}
+
+// ExecuteCommand sends a packet.CommandRequest to run the command line passed, which must start with a '/'.
+func (c *Client) ExecuteCommand(commandLine string) error {
+	return c.WritePacket(&packet.CommandRequest{
+		CommandLine:   commandLine,
+		CommandOrigin: protocol.CommandOrigin{Origin: protocol.CommandOriginPlayer},
+	})
+}
+
+// Close closes the connection of the Client. The server will handle this as the player disconnecting.
+func (c *Client) Close() error {
+	c.p.closePipe()
+	return nil
+}
+
+// vec32To64 converts a mgl32.Vec3 to a mgl64.Vec3.
+func vec32To64(vec3 mgl32.Vec3) mgl64.Vec3 {
+	return mgl64.Vec3{float64(vec3[0]), float64(vec3[1]), float64(vec3[2])}
+}
+
+// vec64To32 converts a mgl64.Vec3 to a mgl32.Vec3.
+func vec64To32(vec3 mgl64.Vec3) mgl32.Vec3 {
+	return mgl32.Vec3{float32(vec3[0]), float32(vec3[1]), float32(vec3[2])}
+}
@@ -0,0 +1,176 @@
+package servertest
+
+import (
+	"context"
+	"fmt"
+	"github.com/df-mc/atomic"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"net"
+	"sync"
+	"time"
+)
+
+// pipe connects a Conn with a Client. Packets written by one end are encoded and pushed to the queue of the
+// other end.
+type pipe struct {
+	toServer, toClient *queue
+	shieldID           atomic.Int32
+
+	spawn     chan struct{}
+	spawnOnce sync.Once
+	close     chan struct{}
+	closeOnce sync.Once
+}
+
+// newPipe creates a new pipe of which both ends are open.
+func newPipe() *pipe {
+	return &pipe{toServer: newQueue(), toClient: newQueue(), spawn: make(chan struct{}), close: make(chan struct{})}
+}
+
+// closePipe closes both ends of the pipe.
+func (p *pipe) closePipe() {
+	p.closeOnce.Do(func() {
+		close(p.close)
+	})
+}
+
+// Conn is the server end of an in-memory connection with a Client. It implements session.Conn and is returned
+// by Listener.Accept.
+type Conn struct {
+	p      *pipe
+	pool   packet.Pool
+	addr   net.Addr
+	id     login.IdentityData
+	client login.ClientData
+	radius int
+
+	deadline atomic.Value[time.Time]
+}
+
+// Compile time check to make sure *Conn implements session.Conn.
+var _ session.Conn = (*Conn)(nil)
+
+// IdentityData ...
+func (c *Conn) IdentityData() login.IdentityData {
+	return c.id
+}
+
+// ClientData ...
+func (c *Conn) ClientData() login.ClientData {
+	return c.client
+}
+
+// ClientCacheEnabled always returns false: Conns never use the client blob cache.
+func (c *Conn) ClientCacheEnabled() bool {
+	return false
+}
+
+// ChunkRadius returns the chunk radius that the Client was dialed with.
+func (c *Conn) ChunkRadius() int {
+	return c.radius
+}
+
+// Latency always returns 0.
+func (c *Conn) Latency() time.Duration {
+	return 0
+}
+
+// Flush does nothing: Packets written to a Conn are immediately available to the Client.
+func (c *Conn) Flush() error {
+	return nil
+}
+
+// RemoteAddr returns the address of the Client. It is a fake address unique to the Client.
+func (c *Conn) RemoteAddr() net.Addr {
+	return c.addr
+}
+
+// SetReadDeadline sets the deadline for calls to ReadPacket. A zero time.Time means ReadPacket will not time
+// out.
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.deadline.Store(t)
+	return nil
+}
+
+// ReadPacket reads the next packet.Packet written by the Client.
+func (c *Conn) ReadPacket() (packet.Packet, error) {
+	ctx := context.Background()
+	if t := c.deadline.Load(); !t.IsZero() {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithDeadline(ctx, t)
+		defer cancel()
+	}
+	data, err := c.p.toServer.pop(c.p.close, ctx.Done())
+	if err != nil {
+		if err == errDone {
+			err = ctx.Err()
+		}
+		return nil, fmt.Errorf("read packet: %w", err)
+	}
+	return decode(data, c.pool, c.p.shieldID.Load())
+}
+
+// WritePacket writes a packet.Packet to the Client.
+func (c *Conn) WritePacket(pk packet.Packet) error {
+	select {
+	case <-c.p.close:
+		return fmt.Errorf("write packet: %w", net.ErrClosed)
+	default:
+	}
+	c.p.toClient.push(encode(pk, c.p.shieldID.Load()))
+	return nil
+}
+
+// StartGameContext sends the game data passed to the Client in a StartGame packet and blocks until the Client
+// is spawned using Client.Spawn, or until the context is cancelled.
+func (c *Conn) StartGameContext(ctx context.Context, data minecraft.GameData) error {
+	for _, it := range data.Items {
+		if it.Name == "minecraft:shield" {
+			c.p.shieldID.Store(int32(it.RuntimeID))
+		}
+	}
+	_ = c.WritePacket(&packet.StartGame{
+		Difficulty:                   data.Difficulty,
+		EntityUniqueID:               data.EntityUniqueID,
+		EntityRuntimeID:              data.EntityRuntimeID,
+		PlayerGameMode:               data.PlayerGameMode,
+		PlayerPosition:               data.PlayerPosition,
+		Pitch:                        data.Pitch,
+		Yaw:                          data.Yaw,
+		WorldSeed:                    data.WorldSeed,
+		Dimension:                    data.Dimension,
+		WorldSpawn:                   data.WorldSpawn,
+		GameRules:                    data.GameRules,
+		Time:                         data.Time,
+		Blocks:                       data.CustomBlocks,
+		Items:                        data.Items,
+		WorldName:                    data.WorldName,
+		PlayerMovementSettings:       data.PlayerMovementSettings,
+		WorldGameMode:                data.WorldGameMode,
+		ServerAuthoritativeInventory: data.ServerAuthoritativeInventory,
+		PlayerPermissions:            data.PlayerPermissions,
+		Experiments:                  data.Experiments,
+		BaseGameVersion:              data.BaseGameVersion,
+		GameVersion:                  protocol.CurrentVersion,
+	})
+
+	select {
+	case <-c.p.spawn:
+		return nil
+	case <-c.p.close:
+		return fmt.Errorf("start game: %w", net.ErrClosed)
+	case <-ctx.Done():
+		return fmt.Errorf("start game: %w", ctx.Err())
+	}
+}
+
+// Close closes the Conn. The Client is able to read packets written before the Conn was closed, after which
+// reading returns an error.
+func (c *Conn) Close() error {
+	c.p.closePipe()
+	return nil
+}
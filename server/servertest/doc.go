@@ -0,0 +1,21 @@
+// Package servertest implements utilities for testing a server.Server without a network connection.
+//
+// A Listener may be passed to server.Config.Listeners using Listener.Func. Connections to the server are
+// then made using Listener.Dial, which returns a Client that is spawned using Client.Spawn:
+//
+//	l := servertest.NewListener()
+//	conf.Listeners = append(conf.Listeners, l.Func())
+//	srv := conf.New()
+//	srv.Listen()
+//	go srv.Accept(nil)
+//
+//	c, _ := l.Dial("Steve")
+//	if err := c.Spawn(ctx); err != nil {
+//		...
+//	}
+//	c.Chat("Hello world!")
+//	text, err := servertest.Expect[*packet.Text](c, time.Second)
+//
+// All packets sent between a Client and the server are encoded and decoded, as they would be over a real
+// connection.
+package servertest
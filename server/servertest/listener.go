@@ -0,0 +1,120 @@
+package servertest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"github.com/df-mc/atomic"
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/login"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"net"
+	"sync"
+)
+
+// Listener is a server.Listener that accepts in-memory connections made using Listener.Dial or Dialer.Dial.
+// No network connection is ever opened by a Listener.
+type Listener struct {
+	incoming chan *Conn
+	close    chan struct{}
+	once     sync.Once
+
+	port atomic.Int32
+}
+
+// Compile time check to make sure *Listener implements server.Listener.
+var _ server.Listener = (*Listener)(nil)
+
+// NewListener creates a new Listener that is ready to be dialed.
+func NewListener() *Listener {
+	return &Listener{incoming: make(chan *Conn), close: make(chan struct{})}
+}
+
+// Func returns a function that may be added to server.Config.Listeners so that the server.Server listens on
+// the Listener.
+func (l *Listener) Func() func(conf server.Config) (server.Listener, error) {
+	return func(server.Config) (server.Listener, error) {
+		return l, nil
+	}
+}
+
+// Accept blocks until a Client dials the Listener and returns the server end of the connection. An error is
+// returned if the Listener was closed using Close.
+func (l *Listener) Accept() (session.Conn, error) {
+	select {
+	case c := <-l.incoming:
+		return c, nil
+	case <-l.close:
+		return nil, fmt.Errorf("accept: %w", net.ErrClosed)
+	}
+}
+
+// Disconnect writes a packet.Disconnect with the reason passed to the connection and closes it.
+func (l *Listener) Disconnect(conn session.Conn, reason string) error {
+	_ = conn.WritePacket(&packet.Disconnect{Message: reason})
+	return conn.Close()
+}
+
+// Close closes the Listener. Accept and Dial return an error after calling Close.
+func (l *Listener) Close() error {
+	l.once.Do(func() {
+		close(l.close)
+	})
+	return nil
+}
+
+// Dial connects to the Listener with the player name passed, using the default values of a Dialer otherwise.
+func (l *Listener) Dial(name string) (*Client, error) {
+	return Dialer{IdentityData: login.IdentityData{DisplayName: name}}.Dial(l)
+}
+
+// Dialer allows specifying the login data of a Client connecting to a Listener. Fields left empty are filled
+// out with default values.
+type Dialer struct {
+	// IdentityData is the identity data of the Client. If Identity is empty, a random UUID is used. If
+	// DisplayName is empty, "Steve" is used.
+	IdentityData login.IdentityData
+	// ClientData is the client data of the Client. If no skin is set, a blank 64x64 skin is used. If
+	// LanguageCode is empty, "en_US" is used.
+	ClientData login.ClientData
+	// ChunkRadius is the chunk radius requested by the Client. If 0, a chunk radius of 4 is used.
+	ChunkRadius int
+}
+
+// Dial connects a Client to the Listener passed. Dial blocks until the server.Server accepts the connection
+// from the Listener, or returns an error if the Listener is closed. The Client returned must be spawned using
+// Client.Spawn before the server.Server finishes accepting it.
+func (d Dialer) Dial(l *Listener) (*Client, error) {
+	if d.IdentityData.Identity == "" {
+		d.IdentityData.Identity = uuid.New().String()
+	}
+	if d.IdentityData.DisplayName == "" {
+		d.IdentityData.DisplayName = "Steve"
+	}
+	if d.ClientData.SkinData == "" {
+		d.ClientData.SkinData = base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0xff}, 64*64*4))
+		d.ClientData.SkinImageWidth, d.ClientData.SkinImageHeight = 64, 64
+		d.ClientData.SkinID = uuid.New().String()
+	}
+	if d.ClientData.LanguageCode == "" {
+		d.ClientData.LanguageCode = "en_US"
+	}
+	if d.ChunkRadius <= 0 {
+		d.ChunkRadius = 4
+	}
+	if _, err := uuid.Parse(d.IdentityData.Identity); err != nil {
+		return nil, fmt.Errorf("dial: invalid identity: %w", err)
+	}
+
+	p := newPipe()
+	addr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: int(l.port.Add(1))}
+	conn := &Conn{p: p, pool: packet.NewPool(), addr: addr, id: d.IdentityData, client: d.ClientData, radius: d.ChunkRadius}
+	select {
+	case l.incoming <- conn:
+		return newClient(p, d.IdentityData), nil
+	case <-l.close:
+		return nil, fmt.Errorf("dial: %w", net.ErrClosed)
+	}
+}
@@ -0,0 +1,96 @@
+package servertest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"net"
+	"sync"
+)
+
+// queue is an unbounded queue of encoded packets. Writing to a queue never blocks, so that a server writing
+// many packets to a Client that is not reading them does not stall.
+type queue struct {
+	mu     sync.Mutex
+	data   [][]byte
+	notify chan struct{}
+}
+
+// newQueue creates an empty queue.
+func newQueue() *queue {
+	return &queue{notify: make(chan struct{}, 1)}
+}
+
+// push adds encoded packet data to the end of the queue.
+func (q *queue) push(b []byte) {
+	q.mu.Lock()
+	q.data = append(q.data, b)
+	q.mu.Unlock()
+
+	select {
+	case q.notify <- struct{}{}:
+	default:
+	}
+}
+
+// errDone is returned by queue.pop if the done channel passed is closed before data is available.
+var errDone = errors.New("done")
+
+// pop blocks until packet data is available in the queue and returns it. Data still in the queue is returned
+// even if closed is closed, so that packets written before a connection was closed may still be read.
+func (q *queue) pop(closed, done <-chan struct{}) ([]byte, error) {
+	for {
+		q.mu.Lock()
+		if len(q.data) > 0 {
+			b := q.data[0]
+			q.data = q.data[1:]
+			q.mu.Unlock()
+			return b, nil
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.notify:
+		case <-closed:
+			return nil, net.ErrClosed
+		case <-done:
+			return nil, errDone
+		}
+	}
+}
+
+// encode encodes a packet.Packet, including its header, into its binary representation.
+func encode(pk packet.Packet, shieldID int32) []byte {
+	buf := bytes.NewBuffer(nil)
+	h := &packet.Header{PacketID: pk.ID()}
+	_ = h.Write(buf)
+	pk.Marshal(protocol.NewWriter(buf, shieldID))
+	return buf.Bytes()
+}
+
+// decode decodes the binary representation of a packet.Packet produced by encode, using the packet.Pool passed
+// to find the packet by its ID.
+func decode(data []byte, pool packet.Pool, shieldID int32) (pk packet.Packet, err error) {
+	buf := bytes.NewBuffer(data)
+	h := &packet.Header{}
+	if err := h.Read(buf); err != nil {
+		return nil, fmt.Errorf("decode packet header: %w", err)
+	}
+	f, ok := pool[h.PacketID]
+	if !ok {
+		return nil, fmt.Errorf("decode packet: unknown packet ID %v", h.PacketID)
+	}
+	pk = f()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("decode packet %T: %v", pk, r)
+		}
+	}()
+	pk.Marshal(protocol.NewReader(buf, shieldID))
+	if buf.Len() != 0 {
+		return nil, fmt.Errorf("decode packet %T: %v unread bytes left", pk, buf.Len())
+	}
+	return pk, nil
+}
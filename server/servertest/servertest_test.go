@@ -0,0 +1,255 @@
+package servertest_test
+
+import (
+	"context"
+	"github.com/df-mc/dragonfly/server"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/servertest"
+	"github.com/df-mc/dragonfly/server/session"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"github.com/sirupsen/logrus"
+	"io"
+	"testing"
+	"time"
+)
+
+// timeout is the time that a test waits for a packet from the server.
+const timeout = time.Second * 5
+
+// newServer starts a server.Server listening on a servertest.Listener and
+// returns the Listener. Players accepted by the server.Server are sent to the
+// channel returned. The server.Server is closed when the test finishes.
+func newServer(t *testing.T) (*servertest.Listener, <-chan *player.Player) {
+	t.Helper()
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+
+	l := servertest.NewListener()
+	srv := server.Config{
+		Log:                     log,
+		Listeners:               []func(conf server.Config) (server.Listener, error){l.Func()},
+		DisableResourceBuilding: true,
+		MovementValidation:      &session.MovementConfig{},
+	}.New()
+	srv.Listen()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+
+	players := make(chan *player.Player, 1)
+	go func() {
+		for srv.Accept(func(p *player.Player) {
+			players <- p
+		}) {
+		}
+	}()
+	return l, players
+}
+
+// spawn dials the Listener passed and spawns the servertest.Client, returning
+// it together with the player.Player accepted by the server.
+func spawn(t *testing.T, l *servertest.Listener, players <-chan *player.Player) (*servertest.Client, *player.Player) {
+	t.Helper()
+	c, err := l.Dial("Steve")
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := c.Spawn(ctx); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case p := <-players:
+		return c, p
+	case <-time.After(timeout):
+		t.Fatal("player was not accepted by the server")
+	}
+	return nil, nil
+}
+
+// expectSlot reads packets from the Client until the item in a slot of its
+// inventory has the count passed, if it does not have that count already.
+func expectSlot(t *testing.T, c *servertest.Client, slot, count int) {
+	t.Helper()
+	if int(c.Slot(protocol.WindowIDInventory, slot).Stack.Count) == count {
+		return
+	}
+	_, err := c.ExpectFunc(timeout, func(packet.Packet) bool {
+		return int(c.Slot(protocol.WindowIDInventory, slot).Stack.Count) == count
+	})
+	if err != nil {
+		t.Fatalf("slot %v did not get count %v: %v", slot, count, err)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	l, players := newServer(t)
+	c, p := spawn(t, l, players)
+
+	if name := p.Name(); name != "Steve" {
+		t.Errorf("player name = %q, want %q", name, "Steve")
+	}
+	if rid := c.StartGame().EntityRuntimeID; rid == 0 {
+		t.Errorf("StartGame has no entity runtime ID")
+	}
+}
+
+func TestPlayerAuthInput(t *testing.T) {
+	l, players := newServer(t)
+	c, _ := spawn(t, l, players)
+
+	// Movement directly after spawning is only checked for collisions, so
+	// the Client first moves in place to start the simulation of the server.
+	if err := c.Tick(); err != nil {
+		t.Fatal(err)
+	}
+	start := c.Position()
+	// Moving 50 blocks in a single tick is never valid, so the server must
+	// teleport the player back to where it started.
+	if err := c.Move(start.Add(mgl64.Vec3{50}), cube.Rotation{}); err != nil {
+		t.Fatal(err)
+	}
+	pk, err := servertest.Expect[*packet.MovePlayer](c, timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.Mode != packet.MoveModeTeleport && pk.Mode != packet.MoveModeReset {
+		t.Errorf("MovePlayer mode = %v, want teleport or reset", pk.Mode)
+	}
+	if pos := c.Position(); pos.Sub(start).Len() > 1 {
+		t.Errorf("player was moved back to %v, want near %v", pos, start)
+	}
+}
+
+func TestInventoryTransaction(t *testing.T) {
+	l, players := newServer(t)
+	c, p := spawn(t, l, players)
+
+	// Click the top of a block of stone two blocks away from the player to
+	// place dirt on it. Block updates are only sent for chunks that the Client
+	// has received, so wait for the chunk first.
+	ground := cube.PosFromVec3(c.Position()).Add(cube.Pos{2, -1, 0})
+	chunk := protocol.ChunkPos{int32(ground[0] >> 4), int32(ground[2] >> 4)}
+	_, err := c.ExpectFunc(timeout, func(pk packet.Packet) bool {
+		lc, ok := pk.(*packet.LevelChunk)
+		return ok && lc.Position == chunk
+	})
+	if err != nil {
+		t.Fatalf("chunk %v was not sent: %v", chunk, err)
+	}
+	p.World().SetBlock(ground, block.Stone{}, nil)
+
+	_ = p.Inventory().SetItem(0, item.NewStack(block.Dirt{}, 16))
+	expectSlot(t, c, 0, 16)
+	if err := c.UseItemOnBlock(ground, cube.FaceUp, mgl64.Vec3{0.5, 1, 0.5}); err != nil {
+		t.Fatal(err)
+	}
+	placed := ground.Side(cube.FaceUp)
+	_, err = c.ExpectFunc(timeout, func(pk packet.Packet) bool {
+		update, ok := pk.(*packet.UpdateBlock)
+		return ok && update.Position == protocol.BlockPos{int32(placed[0]), int32(placed[1]), int32(placed[2])} &&
+			update.NewBlockRuntimeID == world.BlockRuntimeID(block.Dirt{})
+	})
+	if err != nil {
+		t.Fatalf("no block update for placed dirt: %v", err)
+	}
+	expectSlot(t, c, 0, 15)
+}
+
+func TestItemStackRequest(t *testing.T) {
+	l, players := newServer(t)
+	c, p := spawn(t, l, players)
+
+	_ = p.Inventory().SetItem(0, item.NewStack(block.Dirt{}, 16))
+	expectSlot(t, c, 0, 16)
+
+	// Move half of the stack in slot 0 to slot 1.
+	place := &protocol.PlaceStackRequestAction{}
+	place.Count = 8
+	place.Source = protocol.StackRequestSlotInfo{
+		ContainerID:    protocol.ContainerCombinedHotBarAndInventory,
+		Slot:           0,
+		StackNetworkID: c.Slot(protocol.WindowIDInventory, 0).StackNetworkID,
+	}
+	place.Destination = protocol.StackRequestSlotInfo{
+		ContainerID: protocol.ContainerCombinedHotBarAndInventory,
+		Slot:        1,
+	}
+	id, err := c.RequestItemStack(place)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := servertest.Expect[*packet.ItemStackResponse](c, timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pk.Responses) != 1 {
+		t.Fatalf("ItemStackResponse has %v responses, want 1", len(pk.Responses))
+	}
+	if resp := pk.Responses[0]; resp.RequestID != id || resp.Status != protocol.ItemStackResponseStatusOK {
+		t.Fatalf("response = (ID %v, status %v), want (ID %v, status OK)", resp.RequestID, resp.Status, id)
+	}
+	for slot, want := range []int{8, 8} {
+		if count := int(c.Slot(protocol.WindowIDInventory, slot).Stack.Count); count != want {
+			t.Errorf("slot %v count = %v, want %v", slot, count, want)
+		}
+	}
+}
+
+func TestMovementHover(t *testing.T) {
+	l, players := newServer(t)
+	c, _ := spawn(t, l, players)
+
+	// The player spawns in the air. Staying at the same position, rather than falling, must be corrected over
+	// and over again: The server may not restart its simulation of the movement after every correction.
+	if err := c.Tick(); err != nil {
+		t.Fatal(err)
+	}
+	const ticks = 40
+	pos := c.Position()
+	for i := 0; i < ticks; i++ {
+		if err := c.Move(pos, cube.Rotation{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var corrections int
+	_, _ = c.ExpectFunc(time.Second, func(pk packet.Packet) bool {
+		if move, ok := pk.(*packet.MovePlayer); ok && move.Mode == packet.MoveModeTeleport {
+			corrections++
+		}
+		return false
+	})
+	// The simulation allows for the upward velocity the player may have had when it spawned during the first
+	// ticks. After that, at most every other movement may be accepted.
+	if want := ticks * 3 / 8; corrections < want {
+		t.Errorf("hovering for %v ticks was corrected %v times, want at least %v", ticks, corrections, want)
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	l, players := newServer(t)
+	c, p := spawn(t, l, players)
+
+	// Behind a proxy, the proxy intercepts the Transfer packet sent to the client and moves the player to the
+	// backend server with this address itself.
+	if err := p.Transfer("127.0.0.1:19133"); err != nil {
+		t.Fatal(err)
+	}
+	pk, err := servertest.Expect[*packet.Transfer](c, timeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pk.Address != "127.0.0.1" || pk.Port != 19133 {
+		t.Errorf("transferred to %v:%v, want 127.0.0.1:19133", pk.Address, pk.Port)
+	}
+}
@@ -167,6 +167,7 @@ func (s *Session) sendBlobHashes(pos world.ChunkPos, c *chunk.Chunk, blockEntiti
 		s.blobs[hashes[i]] = blobs[i]
 	}
 	s.blobMu.Unlock()
+	s.stats.blobsSent.Add(uint64(len(hashes)))
 
 	// Length of 1 byte for the border block count.
 	raw := bytes.NewBuffer(make([]byte, 1, 32))
@@ -238,5 +239,6 @@ func (s *Session) trackBlob(hash uint64, blob []byte) bool {
 	}
 	s.blobs[hash] = blob
 	s.blobMu.Unlock()
+	s.stats.blobsSent.Inc()
 	return true
 }
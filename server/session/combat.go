@@ -0,0 +1,258 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"sync"
+	"time"
+)
+
+// CombatConfig configures the validation of attacks by the client of a Session. If enabled using
+// Session.ValidateCombat, every attack is validated before it is passed to Controllable.AttackEntity. The
+// position of the target is rewound to the positions that the client could have seen it at, taking into
+// account the latency of the client, after which the reach and line of sight are checked using the bounding
+// box of the target. Attacks failing validation are passed to Controllable.RejectAttack and, unless
+// cancelled, rejected.
+type CombatConfig struct {
+	// Reach is the maximum distance in blocks between the eyes of a player and the bounding box of the
+	// entity it attacks. Players in a game mode with a creative inventory may always reach twice as far. If
+	// 0, a reach of 3 blocks is used.
+	Reach float64
+	// Tolerance is the distance in blocks that attacks may exceed the Reach by before they are considered
+	// invalid. It accounts for the client interpolating the movement of entities. If 0, a tolerance of 0.4
+	// blocks is used.
+	Tolerance float64
+	// MaxClicksPerSecond is the maximum amount of clicks, including clicks that hit no entity, that a player
+	// may perform within a second. Attacks exceeding this rate are considered invalid. If 0, a maximum of 20
+	// clicks per second is used. If negative, the click rate is not validated.
+	MaxClicksPerSecond int
+	// Rewind is the maximum duration, on top of the latency of the client, that the positions of entities
+	// are rewound to validate attacks. If 0, a rewind of 150 milliseconds is used.
+	Rewind time.Duration
+	// IgnoreLineOfSight specifies if attacks through blocks should be allowed.
+	IgnoreLineOfSight bool
+}
+
+// AttackViolation describes the reason an attack failed validation.
+type AttackViolation int
+
+const (
+	// AttackViolationReach is the AttackViolation of an attack on an entity that was out of reach.
+	AttackViolationReach AttackViolation = iota + 1
+	// AttackViolationLineOfSight is the AttackViolation of an attack on an entity that was obstructed by
+	// blocks.
+	AttackViolationLineOfSight
+	// AttackViolationClickRate is the AttackViolation of an attack that exceeded the maximum click rate.
+	AttackViolationClickRate
+)
+
+// String ...
+func (v AttackViolation) String() string {
+	switch v {
+	case AttackViolationReach:
+		return "reach"
+	case AttackViolationLineOfSight:
+		return "line of sight"
+	case AttackViolationClickRate:
+		return "click rate"
+	}
+	panic("should never happen")
+}
+
+// positionHistorySize is the amount of positions kept for every entity viewed by a Session that validates
+// combat. Entities move at most once every tick, so this is at least 2 seconds of movement.
+const positionHistorySize = 40
+
+// positionRecord is a position of an entity sent to the client at a specific time.
+type positionRecord struct {
+	pos mgl64.Vec3
+	t   time.Time
+}
+
+// combatValidator validates the attacks of the client of a Session using a CombatConfig.
+type combatValidator struct {
+	conf CombatConfig
+
+	mu      sync.Mutex
+	history map[world.Entity][]positionRecord
+	clicks  []time.Time
+}
+
+// newCombatValidator creates a combatValidator using the CombatConfig passed, filling out default values
+// where needed.
+func newCombatValidator(conf CombatConfig) *combatValidator {
+	if conf.Reach <= 0 {
+		conf.Reach = 3
+	}
+	if conf.Tolerance <= 0 {
+		conf.Tolerance = 0.4
+	}
+	if conf.MaxClicksPerSecond == 0 {
+		conf.MaxClicksPerSecond = 20
+	}
+	if conf.Rewind <= 0 {
+		conf.Rewind = time.Millisecond * 150
+	}
+	return &combatValidator{conf: conf, history: map[world.Entity][]positionRecord{}}
+}
+
+// ValidateCombat enables the validation of attacks by the client of the Session using the CombatConfig
+// passed.
+func (s *Session) ValidateCombat(conf CombatConfig) {
+	if s == Nop {
+		return
+	}
+	s.combat.Store(newCombatValidator(conf))
+}
+
+// record records a position of an entity sent to the client.
+func (v *combatValidator) record(e world.Entity, pos mgl64.Vec3) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	h := v.history[e]
+	if len(h) == positionHistorySize {
+		h = append(h[:0], h[1:]...)
+	}
+	v.history[e] = append(h, positionRecord{pos: pos, t: time.Now()})
+}
+
+// forget removes the position history of an entity, for example when it is no longer viewed by the client.
+func (v *combatValidator) forget(e world.Entity) {
+	v.mu.Lock()
+	delete(v.history, e)
+	v.mu.Unlock()
+}
+
+// click registers a click of the client. True is returned if the click rate is within the maximum allowed.
+func (v *combatValidator) click() bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	now := time.Now()
+	n := 0
+	for _, t := range v.clicks {
+		if now.Sub(t) < time.Second {
+			v.clicks[n] = t
+			n++
+		}
+	}
+	v.clicks = append(v.clicks[:n], now)
+	return v.conf.MaxClicksPerSecond < 0 || len(v.clicks) <= v.conf.MaxClicksPerSecond
+}
+
+// validate validates an attack by the Controllable of the Session on the entity passed. If the attack is
+// invalid, the AttackViolation is returned along with false.
+func (v *combatValidator) validate(s *Session, e world.Entity) (AttackViolation, bool) {
+	if !v.click() {
+		return AttackViolationClickRate, false
+	}
+	reach := v.conf.Reach
+	if s.c.GameMode().CreativeInventory() {
+		reach *= 2
+	}
+	reach += v.conf.Tolerance
+
+	var (
+		w        = s.c.World()
+		eyes     = entity.EyePosition(s.c)
+		dir      = s.c.Rotation().Vec3()
+		box      = e.Type().BBox(e).Grow(0.1)
+		obstruct = false
+	)
+	for _, pos := range v.rewind(s, e) {
+		bb := box.Translate(pos)
+		point, ok := reachPoint(bb, eyes, dir, reach)
+		if !ok {
+			continue
+		}
+		if v.conf.IgnoreLineOfSight || visible(w, eyes, point, bb) {
+			return 0, true
+		}
+		obstruct = true
+	}
+	if obstruct {
+		return AttackViolationLineOfSight, false
+	}
+	return AttackViolationReach, false
+}
+
+// rewind returns the positions of an entity that the client could have seen at the time it attacked it,
+// including the current position of the entity.
+func (v *combatValidator) rewind(s *Session, e world.Entity) []mgl64.Vec3 {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	since := time.Now().Add(-v.conf.Rewind - s.conn.Latency()*2)
+	positions := []mgl64.Vec3{e.Position()}
+	h := v.history[e]
+	for i := len(h) - 1; i >= 0; i-- {
+		positions = append(positions, h[i].pos)
+		if h[i].t.Before(since) {
+			// The client could still have seen this position if no newer position arrived yet, but any
+			// position before it was replaced already.
+			break
+		}
+	}
+	return positions
+}
+
+// reachPoint returns the point on a BBox that the eyes of a player reach when attacking. If the ray from the
+// eyes towards the direction the player is looking intersects with the BBox, the point of intersection is
+// returned. Otherwise, the point on the BBox closest to the eyes is used, because touch screen players do
+// not necessarily look at the entity they attack. False is returned if the point is further away than the
+// reach passed.
+func reachPoint(bb cube.BBox, eyes, dir mgl64.Vec3, reach float64) (mgl64.Vec3, bool) {
+	if bb.Vec3Within(eyes) {
+		return eyes, true
+	}
+	point := closestPoint(bb, eyes)
+	if res, ok := trace.BBoxIntercept(bb, eyes, eyes.Add(dir.Mul(reach))); ok {
+		point = res.Position()
+	}
+	return point, point.Sub(eyes).Len() <= reach
+}
+
+// closestPoint returns the point within a BBox closest to the position passed.
+func closestPoint(bb cube.BBox, pos mgl64.Vec3) mgl64.Vec3 {
+	min, max := bb.Min(), bb.Max()
+	return mgl64.Vec3{
+		math.Max(min[0], math.Min(pos[0], max[0])),
+		math.Max(min[1], math.Min(pos[1], max[1])),
+		math.Max(min[2], math.Min(pos[2], max[2])),
+	}
+}
+
+// visible checks if any of a set of points on the BBox passed is visible from the eyes of a player, without
+// blocks obstructing the view. The point passed is checked first, followed by the centre and top of the
+// BBox.
+func visible(w *world.World, eyes, point mgl64.Vec3, bb cube.BBox) bool {
+	// Points at the very bottom of the BBox are inside the block that the entity stands on.
+	point[1] = math.Max(point[1], bb.Min()[1]+0.2)
+	centre := bb.Min().Add(bb.Max()).Mul(0.5)
+	top := mgl64.Vec3{centre[0], bb.Max()[1] - 0.1, centre[2]}
+	for _, p := range [...]mgl64.Vec3{point, centre, top} {
+		if !obstructed(w, eyes, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// obstructed checks if any block with a collision box is in between the start and end position passed.
+func obstructed(w *world.World, start, end mgl64.Vec3) bool {
+	if start.ApproxEqual(end) {
+		return false
+	}
+	blocked := false
+	trace.TraverseBlocks(start, end, func(pos cube.Pos) bool {
+		if _, ok := trace.BlockIntercept(pos, w, w.Block(pos), start, end); ok {
+			blocked = true
+		}
+		return !blocked
+	})
+	return blocked
+}
@@ -46,6 +46,7 @@ type Controllable interface {
 	BreakBlock(pos cube.Pos)
 	PickBlock(pos cube.Pos)
 	AttackEntity(e world.Entity) bool
+	RejectAttack(e world.Entity, violation AttackViolation) bool
 	Drop(s item.Stack) (n int)
 	SwingArm()
 	PunchAir()
@@ -59,6 +60,14 @@ type Controllable interface {
 	Respawn()
 	Dead() bool
 
+	Sleep(pos cube.Pos)
+	Sleeping() (cube.Pos, bool)
+	Wake()
+
+	Mount(e world.Entity) bool
+	Riding() (world.Entity, bool)
+	Dismount()
+
 	StartSneaking()
 	Sneaking() bool
 	StopSneaking()
@@ -83,6 +92,8 @@ type Controllable interface {
 
 	Exhaust(points float64)
 
+	CorrectMovement(valid, attempted mgl64.Vec3) bool
+
 	OpenSign(pos cube.Pos, frontSide bool)
 	EditSign(pos cube.Pos, frontText, backText string) error
 
@@ -1,11 +1,13 @@
 package session
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/item/trade"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
@@ -72,9 +74,15 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if u, ok := e.(using); ok && u.UsingItem() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagUsingItem)
 	}
+	if b, ok := e.(blocker); ok && b.Blocking() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagBlocking)
+	}
 	if c, ok := e.(arrow); ok && c.Critical() {
 		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagCritical)
 	}
+	if r, ok := e.(returner); ok && r.Returning() {
+		m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagReturnTrident)
+	}
 	if g, ok := e.(gameMode); ok {
 		if g.GameMode().HasCollision() {
 			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagHasCollision)
@@ -164,6 +172,41 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 	if mv, ok := e.(markVariable); ok {
 		m[protocol.EntityDataKeyMarkVariant] = mv.MarkVariant()
 	}
+	if r, ok := e.(entity.Rider); ok {
+		if v, ok := r.Riding(); ok {
+			if rideable, ok := entity.RideableOf(v); ok {
+				seat, _ := rideable.SeatPosition(r)
+				m[protocol.EntityDataKeySeatOffset] = vec64To32(seat.Add(entityOffset(r)))
+			}
+			m.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagRiding)
+		}
+	}
+	if _, ok := e.(entity.Rideable); ok {
+		m[protocol.EntityDataKeyControllingSeatIndex] = int32(0)
+	}
+	if h, ok := e.(hurtable); ok {
+		m[protocol.EntityDataKeyHurt] = h.HurtTime()
+		m[protocol.EntityDataKeyHurtDirection] = h.HurtDirection()
+	}
+	if t, ok := e.(trade.Trader); ok {
+		m[protocol.EntityDataKeyTradeTier] = int32(trade.Tier(t.Experience()))
+		m[protocol.EntityDataKeyMaxTradeTier] = int32(4)
+		m[protocol.EntityDataKeyTradeExperience] = int32(t.Experience())
+	}
+	if sl, ok := e.(sleeper); ok {
+		if pos, ok := sl.Sleeping(); ok {
+			m[protocol.EntityDataKeyBedPosition] = protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
+			m.SetFlag(protocol.EntityDataKeyPlayerFlags, playerFlagSleeping)
+		}
+	}
+}
+
+// playerFlagSleeping is the index of the flag in protocol.EntityDataKeyPlayerFlags that is set while a player is
+// sleeping in a bed.
+const playerFlagSleeping = 1
+
+type sleeper interface {
+	Sleeping() (cube.Pos, bool)
 }
 
 type sneaker interface {
@@ -237,6 +280,14 @@ type using interface {
 	UsingItem() bool
 }
 
+type blocker interface {
+	Blocking() bool
+}
+
+type returner interface {
+	Returning() bool
+}
+
 type arrow interface {
 	Critical() bool
 }
@@ -266,6 +317,11 @@ type variable interface {
 	Variant() int32
 }
 
+type hurtable interface {
+	HurtTime() int32
+	HurtDirection() int32
+}
+
 type markVariable interface {
 	MarkVariant() int32
 }
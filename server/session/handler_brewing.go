@@ -0,0 +1,50 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+const (
+	// brewingIngredientSlot is the slot index of the ingredient in the brewing stand.
+	brewingIngredientSlot = 0x00
+	// brewingFuelSlot is the slot index of the fuel in the brewing stand.
+	brewingFuelSlot = 0x04
+)
+
+// verifyBrewingPlacement checks if the item stack passed may be placed in the slot passed if it is a slot of a
+// brewing stand. Only blaze powder may be placed in the fuel slot, only reagents of potion recipes may be placed in
+// the ingredient slot, and each of the bottle slots may only hold a single potion or glass bottle.
+func (h *ItemStackRequestHandler) verifyBrewingPlacement(slot protocol.StackRequestSlotInfo, i item.Stack) error {
+	switch slot.ContainerID {
+	case protocol.ContainerBrewingStandInput, protocol.ContainerBrewingStandResult, protocol.ContainerBrewingStandFuel:
+	default:
+		// Not a brewing stand slot, so no need to verify anything.
+		return nil
+	}
+	if i.Empty() {
+		return nil
+	}
+	switch slot.Slot {
+	case brewingIngredientSlot:
+		if !recipe.Reagent(i) {
+			return fmt.Errorf("%v is not a valid brewing ingredient", i)
+		}
+	case brewingFuelSlot:
+		if _, ok := i.Item().(item.BlazePowder); !ok {
+			return fmt.Errorf("%v is not a valid brewing fuel", i)
+		}
+	default:
+		switch i.Item().(type) {
+		case item.Potion, item.SplashPotion, item.LingeringPotion, item.GlassBottle:
+		default:
+			return fmt.Errorf("%v cannot be placed in a brewing stand bottle slot", i)
+		}
+		if i.Count() > 1 {
+			return fmt.Errorf("brewing stand bottle slot can only hold a single item, got %v", i.Count())
+		}
+	}
+	return nil
+}
@@ -13,6 +13,9 @@ type ClientCacheBlobStatusHandler struct {
 func (c *ClientCacheBlobStatusHandler) Handle(p packet.Packet, s *Session) error {
 	pk := p.(*packet.ClientCacheBlobStatus)
 
+	s.stats.blobHits.Add(uint64(len(pk.HitHashes)))
+	s.stats.blobMisses.Add(uint64(len(pk.MissHashes)))
+
 	resp := &packet.ClientCacheMissResponse{Blobs: make([]protocol.CacheBlob, 0, len(pk.MissHashes))}
 
 	s.blobMu.Lock()
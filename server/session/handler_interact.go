@@ -26,6 +26,8 @@ func (h *InteractHandler) Handle(p packet.Packet, s *Session) error {
 			WindowID:      0,
 			ContainerType: 0xff,
 		})
+	case packet.InteractActionLeaveVehicle:
+		s.c.Dismount()
 	default:
 		return fmt.Errorf("unexpected interact packet action %v", pk.ActionType)
 	}
@@ -129,6 +129,11 @@ func (h *InventoryTransactionHandler) handleUseItemOnEntityTransaction(data *pro
 	case protocol.UseItemOnEntityActionInteract:
 		valid = s.c.UseItemOnEntity(e)
 	case protocol.UseItemOnEntityActionAttack:
+		if v := s.combat.Load(); v != nil {
+			if violation, ok := v.validate(s, e); !ok && s.c.RejectAttack(e, violation) {
+				break
+			}
+		}
 		valid = s.c.AttackEntity(e)
 	default:
 		return fmt.Errorf("unhandled UseItemOnEntity ActionType %v", data.ActionType)
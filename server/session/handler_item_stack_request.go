@@ -88,6 +88,10 @@ func (h *ItemStackRequestHandler) handleRequest(req protocol.ItemStackRequest, s
 		case *protocol.BeaconPaymentStackRequestAction:
 			err = h.handleBeaconPayment(a, s)
 		case *protocol.CraftRecipeStackRequestAction:
+			if s.containerOpened.Load() && s.openedTrader.Load() != nil {
+				err = h.handleTrade(a, s)
+				break
+			}
 			if s.containerOpened.Load() {
 				var special bool
 				switch s.c.World().Block(s.openedPos.Load()).(type) {
@@ -160,6 +164,9 @@ func (h *ItemStackRequestHandler) handleTransfer(from, to protocol.StackRequestS
 	if dest.Empty() {
 		dest = i.Grow(-math.MaxInt32)
 	}
+	if err := h.verifyBrewingPlacement(to, dest.Grow(int(count))); err != nil {
+		return err
+	}
 
 	invA, _ := s.invByID(int32(from.ContainerID))
 	invB, _ := s.invByID(int32(to.ContainerID))
@@ -184,6 +191,12 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 	}
 	i, _ := h.itemInSlot(a.Source, s)
 	dest, _ := h.itemInSlot(a.Destination, s)
+	if err := h.verifyBrewingPlacement(a.Source, dest); err != nil {
+		return err
+	}
+	if err := h.verifyBrewingPlacement(a.Destination, i); err != nil {
+		return err
+	}
 
 	invA, _ := s.invByID(int32(a.Source.ContainerID))
 	invB, _ := s.invByID(int32(a.Destination.ContainerID))
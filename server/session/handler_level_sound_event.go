@@ -11,6 +11,10 @@ func (l LevelSoundEventHandler) Handle(p packet.Packet, s *Session) error {
 	if pk.SoundType == packet.SoundEventAttackNoDamage && s.c.GameMode().Visible() {
 		s.swingingArm.Store(true)
 		defer s.swingingArm.Store(false)
+		if v := s.combat.Load(); v != nil {
+			// Clicks that hit no entity still count towards the click rate.
+			v.click()
+		}
 		s.c.PunchAir()
 	}
 	return nil
@@ -0,0 +1,45 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// maxVehicleMovement is the maximum distance in blocks that a vehicle may be moved by its driver in a single
+// MoveActorAbsolute packet. It is slightly higher than the distance a boat travels per tick on blue ice.
+const maxVehicleMovement = 4.0
+
+// MoveActorAbsoluteHandler handles the MoveActorAbsolute packet, which the client sends to move the vehicle it
+// is driving, such as a boat.
+type MoveActorAbsoluteHandler struct{}
+
+// Handle ...
+func (h *MoveActorAbsoluteHandler) Handle(p packet.Packet, s *Session) error {
+	pk := p.(*packet.MoveActorAbsolute)
+
+	e, ok := s.entityFromRuntimeID(pk.EntityRuntimeID)
+	if !ok {
+		return fmt.Errorf("invalid entity runtime ID %v", pk.EntityRuntimeID)
+	}
+	ent, ok := e.(*entity.Ent)
+	if !ok {
+		return nil
+	}
+	d, ok := ent.Behaviour().(entity.Drivable)
+	if driver, driven := entity.Driver(e); !ok || !driven || driver != s.c {
+		// The client may only move vehicles that it is driving.
+		return nil
+	}
+	pos, old := vec32To64(pk.Position).Sub(entityOffset(e)), ent.Position()
+	box := ent.Type().BBox(ent)
+	if pos.Sub(old).Len() > maxVehicleMovement || collidingNew(ent.World(), box.Translate(old), box.Translate(pos)) {
+		// The vehicle was moved too far in a single tick or into a block, so it is moved back to its last
+		// position for the driver.
+		s.ViewEntityTeleport(ent, old)
+		return nil
+	}
+	d.Drive(ent, pos, cube.Rotation{float64(pk.Rotation[1]), float64(pk.Rotation[0])})
+	return nil
+}
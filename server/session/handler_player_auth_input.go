@@ -43,13 +43,6 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 	pk.Position = pk.Position.Sub(mgl32.Vec3{0, 1.62}) // Sub the base offset of players from the pos.
 
 	newPos := vec32To64(pk.Position)
-	deltaPos, deltaYaw, deltaPitch := newPos.Sub(pos), float64(pk.Yaw)-yaw, float64(pk.Pitch)-pitch
-	if mgl64.FloatEqual(deltaPos.Len(), 0) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0) {
-		// The PlayerAuthInput packet is sent every tick, so don't do anything if the position and rotation
-		// were unchanged.
-		return nil
-	}
-
 	if expected := s.teleportPos.Load(); expected != nil {
 		if newPos.Sub(*expected).Len() > 1 {
 			// The player has moved before it received the teleport packet. Ignore this movement entirely and
@@ -58,6 +51,28 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 			return nil
 		}
 		s.teleportPos.Store(nil)
+		if v := s.movement.Load(); v != nil {
+			v.synced(*expected)
+		}
+	}
+	if v := s.movement.Load(); v != nil {
+		if valid, ok := v.validate(s, pos, newPos, pk.InputData); !ok {
+			if s.c.CorrectMovement(valid, newPos) {
+				// Teleport the player back to its last valid position. Movement is ignored until the
+				// client has synced itself back to this position.
+				v.correct(valid)
+				s.ViewEntityTeleport(s.c, valid)
+				return nil
+			}
+			v.restart()
+		}
+	}
+
+	deltaPos, deltaYaw, deltaPitch := newPos.Sub(pos), float64(pk.Yaw)-yaw, float64(pk.Pitch)-pitch
+	if mgl64.FloatEqual(deltaPos.Len(), 0) && mgl64.FloatEqual(deltaYaw, 0) && mgl64.FloatEqual(deltaPitch, 0) {
+		// The PlayerAuthInput packet is sent every tick, so don't do anything if the position and rotation
+		// were unchanged.
+		return nil
 	}
 
 	s.c.Move(deltaPos, deltaYaw, deltaPitch)
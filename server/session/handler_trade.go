@@ -0,0 +1,119 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item/trade"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"math"
+)
+
+const (
+	// tradeFirstInputSlot is the slot index of the first input item in the trade window.
+	tradeFirstInputSlot = 0x04
+	// tradeSecondInputSlot is the slot index of the second input item in the trade window.
+	tradeSecondInputSlot = 0x05
+)
+
+// handleTrade handles a CraftRecipe stack request action made in a trade window. The recipe network ID of the
+// action is the index of the offer used, plus one.
+func (h *ItemStackRequestHandler) handleTrade(a *protocol.CraftRecipeStackRequestAction, s *Session) error {
+	t := s.openedTrader.Load()
+	index := int(a.RecipeNetworkID) - 1
+	offers := t.Offers()
+	if index < 0 || index >= len(offers) {
+		return fmt.Errorf("trade offer with network id %v does not exist", a.RecipeNetworkID)
+	}
+	o, tier := offers[index], trade.Tier(t.Experience())
+	if o.Disabled() || o.Tier > tier {
+		return fmt.Errorf("trade offer with network id %v is not available", a.RecipeNetworkID)
+	}
+
+	firstSlot := protocol.StackRequestSlotInfo{ContainerID: protocol.ContainerTradeTwoIngredientOne, Slot: tradeFirstInputSlot}
+	secondSlot := protocol.StackRequestSlotInfo{ContainerID: protocol.ContainerTradeTwoIngredientTwo, Slot: tradeSecondInputSlot}
+	first, _ := h.itemInSlot(firstSlot, s)
+	second, _ := h.itemInSlot(secondSlot, s)
+
+	price := o.Price()
+	if !matchingStacks(first, price) || first.Count() < price.Count() {
+		return fmt.Errorf("first input item is not the same as expected input")
+	}
+	if !o.SecondInput.Empty() && (!matchingStacks(second, o.SecondInput) || second.Count() < o.SecondInput.Count()) {
+		return fmt.Errorf("second input item is not the same as expected input")
+	}
+	if !t.Trade(s.c, index) {
+		return fmt.Errorf("trade offer with network id %v was cancelled", a.RecipeNetworkID)
+	}
+	h.setItemInSlot(firstSlot, first.Grow(-price.Count()), s)
+	if !o.SecondInput.Empty() {
+		h.setItemInSlot(secondSlot, second.Grow(-o.SecondInput.Count()), s)
+	}
+
+	e, w := s.openedEntity.Load(), s.c.World()
+	for _, orb := range entity.NewExperienceOrbs(e.Position(), o.Experience) {
+		w.AddEntity(orb)
+	}
+	if trade.Tier(t.Experience()) != tier {
+		// The trader reached a new tier, so new offers were unlocked.
+		s.sendTrade(e, t, byte(s.openedWindowID.Load()))
+		for _, v := range w.Viewers(e.Position()) {
+			v.ViewEntityState(e)
+		}
+	}
+	return h.createResults(s, o.Output)
+}
+
+// sendTrade sends the offers of the trade.Trader passed to the client, opening a trade window with the ID
+// passed if it was not yet open.
+func (s *Session) sendTrade(e world.Entity, t trade.Trader, windowID byte) {
+	offers := t.Offers()
+	recipes := make([]any, 0, len(offers))
+	for i, o := range offers {
+		price, maxUses := o.Price(), o.MaxUses
+		if maxUses == 0 {
+			maxUses = math.MaxInt32
+		}
+		r := map[string]any{
+			"buyA":             nbtconv.WriteItem(price, true),
+			"buyCountA":        int32(price.Count()),
+			"buyCountB":        int32(o.SecondInput.Count()),
+			"sell":             nbtconv.WriteItem(o.Output, true),
+			"uses":             int32(o.Uses),
+			"maxUses":          int32(maxUses),
+			"tier":             int32(o.Tier),
+			"traderExp":        int32(o.TraderExperience),
+			"rewardExp":        boolByte(o.Experience > 0),
+			"demand":           int32(0),
+			"priceMultiplierA": float32(0),
+			"priceMultiplierB": float32(0),
+			"netId":            int32(i + 1),
+		}
+		if !o.SecondInput.Empty() {
+			r["buyB"] = nbtconv.WriteItem(o.SecondInput, true)
+		}
+		recipes = append(recipes, r)
+	}
+	requirements := make([]any, 0, 5)
+	for tier := 0; tier < 5; tier++ {
+		requirements = append(requirements, map[string]any{fmt.Sprint(tier): int32(trade.TierExperience(tier))})
+	}
+	serialised, err := nbt.Marshal(map[string]any{"Recipes": recipes, "TierExpRequirements": requirements})
+	if err != nil {
+		s.log.Errorf("error encoding trade offers: %v", err)
+		return
+	}
+	s.writePacket(&packet.UpdateTrade{
+		WindowID:         windowID,
+		WindowType:       protocol.ContainerTypeTrade,
+		TradeTier:        int32(trade.Tier(t.Experience())),
+		VillagerUniqueID: int64(s.entityRuntimeID(e)),
+		EntityUniqueID:   selfEntityRuntimeID,
+		DisplayName:      t.TradeName(),
+		NewTradeUI:       true,
+		SerialisedOffers: serialised,
+	})
+}
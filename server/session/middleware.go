@@ -0,0 +1,83 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/event"
+	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// PacketMiddleware intercepts packets read from and written to the connection of a Session. Middleware may
+// observe packets, modify or replace them by changing the value pk points to, or drop them by cancelling the
+// event.Context passed. PacketMiddleware is added to a Session using Session.UseMiddleware.
+//
+// Implementations must be comparable using ==, as Session.RemoveMiddleware finds the middleware to remove by
+// comparing it with the middleware in the chain. Using a pointer type, such as *MyMiddleware, is recommended:
+// structs holding a slice, map or func are not comparable and make RemoveMiddleware panic.
+type PacketMiddleware interface {
+	// HandleClientPacket is called for every packet read from the client, before it is handled by the
+	// Session. Cancelling ctx drops the packet so that it is never handled. HandleClientPacket is always
+	// called from the goroutine that reads packets of the Session.
+	HandleClientPacket(ctx *event.Context, s *Session, pk *packet.Packet)
+	// HandleServerPacket is called for every packet written to the client by the Session, before it is sent.
+	// Cancelling ctx drops the packet so that it is never sent. HandleServerPacket may be called from any
+	// goroutine, and may be called concurrently.
+	HandleServerPacket(ctx *event.Context, s *Session, pk *packet.Packet)
+}
+
+// NopPacketMiddleware implements the PacketMiddleware interface but does not execute any code when a packet
+// is intercepted. User middleware may embed NopPacketMiddleware to implement only some of its methods.
+type NopPacketMiddleware struct{}
+
+// Compile time check to make sure NopPacketMiddleware implements PacketMiddleware.
+var _ PacketMiddleware = NopPacketMiddleware{}
+
+func (NopPacketMiddleware) HandleClientPacket(*event.Context, *Session, *packet.Packet) {}
+func (NopPacketMiddleware) HandleServerPacket(*event.Context, *Session, *packet.Packet) {}
+
+// UseMiddleware adds PacketMiddleware to the end of the middleware chain of the Session. Middleware is called
+// in the order that it was added in, and the chain stops at the first middleware that cancels the packet.
+func (s *Session) UseMiddleware(m ...PacketMiddleware) {
+	if s == Nop {
+		return
+	}
+	s.middlewareMu.Lock()
+	defer s.middlewareMu.Unlock()
+	chain := make([]PacketMiddleware, 0, len(s.middleware.Load())+len(m))
+	s.middleware.Store(append(append(chain, s.middleware.Load()...), m...))
+}
+
+// RemoveMiddleware removes PacketMiddleware previously added using UseMiddleware from the middleware chain
+// of the Session. The middleware is found by comparing it with ==, so RemoveMiddleware panics if m, or
+// middleware in the chain of the same type, is not comparable.
+func (s *Session) RemoveMiddleware(m PacketMiddleware) {
+	if s == Nop {
+		return
+	}
+	s.middlewareMu.Lock()
+	defer s.middlewareMu.Unlock()
+	s.middleware.Store(sliceutil.DeleteVal(append([]PacketMiddleware(nil), s.middleware.Load()...), m))
+}
+
+// interceptClientPacket passes a packet read from the client through the middleware chain of the Session. The
+// packet to handle is returned, or nil if the packet was dropped.
+func (s *Session) interceptClientPacket(pk packet.Packet) packet.Packet {
+	for _, m := range s.middleware.Load() {
+		ctx := event.C()
+		if m.HandleClientPacket(ctx, s, &pk); ctx.Cancelled() || pk == nil {
+			return nil
+		}
+	}
+	return pk
+}
+
+// interceptServerPacket passes a packet written to the client through the middleware chain of the Session.
+// The packet to send is returned, or nil if the packet was dropped.
+func (s *Session) interceptServerPacket(pk packet.Packet) packet.Packet {
+	for _, m := range s.middleware.Load() {
+		ctx := event.C()
+		if m.HandleServerPacket(ctx, s, &pk); ctx.Cancelled() || pk == nil {
+			return nil
+		}
+	}
+	return pk
+}
@@ -0,0 +1,428 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+	"math"
+	"sync"
+	"time"
+)
+
+// MovementConfig configures the validation of movement sent by the client of a Session. If enabled using
+// Session.ValidateMovement, every movement of the client is compared with the movement expected by the
+// server. The expected movement is simulated with the same physics used by entity.MovementComputer, taking
+// into account block collisions, friction, the effect.Speed, effect.JumpBoost, effect.Levitation and
+// effect.SlowFalling effects and gliding. Movement exceeding the expected movement is passed to
+// Controllable.CorrectMovement and, unless cancelled, rejected, after which the player is teleported back to
+// its last valid position.
+type MovementConfig struct {
+	// Tolerance is the distance in blocks that the movement of a single tick may exceed the expected
+	// movement by before it is considered invalid. It accounts for small differences between the simulation
+	// of the client and that of the server. If 0, a tolerance of 0.1 blocks is used.
+	Tolerance float64
+	// Grace is the duration after a change that slows down the player, such as an effect ending or the
+	// player being knocked back, during which movement is still validated as if the change did not yet
+	// happen. This accounts for the latency of the client. If 0, a grace of 1 second, plus the latency of the
+	// client, is used.
+	Grace time.Duration
+}
+
+// movementValidator validates the movement sent by the client of a Session using a MovementConfig.
+type movementValidator struct {
+	conf MovementConfig
+
+	// mu protects the fields below. The validator is mostly used from the goroutine reading packets, but
+	// knock back and teleports are registered from other goroutines.
+	mu       sync.Mutex
+	vel      mgl64.Vec3
+	onGround bool
+	reset    bool
+	// correction is the position that the client was last moved back to by the validator, if correcting is
+	// true. The simulation is not restarted once the client syncs to this position.
+	correction mgl64.Vec3
+	correcting bool
+
+	// knockBack is the last velocity set by the server and knockBackTicks the amount of ticks that
+	// movement may still follow this velocity.
+	knockBack      mgl64.Vec3
+	knockBackTicks int
+	// maxSpeed, jumpBoost and levitation hold the highest values of the respective modifiers within the grace
+	// period, and their ticks the remaining amount of ticks before they are reset to the current values.
+	maxSpeed                                    float64
+	jumpBoost, levitation                       int
+	speedTicks, jumpBoostTicks, levitationTicks int
+	// bounce is the vertical velocity that the player may bounce up with in the next tick, after landing on a
+	// block such as a bed.
+	bounce float64
+}
+
+// newMovementValidator creates a movementValidator using the MovementConfig passed, filling out default
+// values where needed.
+func newMovementValidator(conf MovementConfig) *movementValidator {
+	if conf.Tolerance <= 0 {
+		conf.Tolerance = 0.1
+	}
+	if conf.Grace <= 0 {
+		conf.Grace = time.Second
+	}
+	return &movementValidator{conf: conf, reset: true}
+}
+
+// ValidateMovement enables the validation of movement sent by the client of the Session using the
+// MovementConfig passed. Movement is validated from the next movement sent by the client onwards.
+func (s *Session) ValidateMovement(conf MovementConfig) {
+	if s == Nop {
+		return
+	}
+	s.movement.Store(newMovementValidator(conf))
+}
+
+// graceTicks returns the amount of ticks that the grace period of a Session lasts.
+func (v *movementValidator) graceTicks(s *Session) int {
+	return int((v.conf.Grace + s.conn.Latency()*2) / (time.Second / 20))
+}
+
+// restart resets the simulation of the validator, so that the next movement is used as starting point.
+func (v *movementValidator) restart() {
+	v.mu.Lock()
+	v.vel, v.reset, v.bounce, v.correcting = mgl64.Vec3{}, true, 0, false
+	v.mu.Unlock()
+}
+
+// correct registers that the client is moved back to the position passed because its movement was invalid.
+func (v *movementValidator) correct(pos mgl64.Vec3) {
+	v.mu.Lock()
+	v.correction, v.correcting = pos, true
+	v.mu.Unlock()
+}
+
+// synced is called when the client has synced itself to a teleport to the position passed. The simulation is
+// restarted, unless the teleport was a correction by the validator: The simulation then continues, so that a
+// client can't move freely directly after every correction.
+func (v *movementValidator) synced(pos mgl64.Vec3) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.correcting && v.correction == pos {
+		v.correcting = false
+		return
+	}
+	v.vel, v.reset, v.bounce, v.correcting = mgl64.Vec3{}, true, 0, false
+}
+
+// setKnockBack registers a velocity set by the server, such as from knock back, that the client will apply
+// to its movement once it receives it.
+func (v *movementValidator) setKnockBack(vel mgl64.Vec3, ticks int) {
+	v.mu.Lock()
+	v.knockBack, v.knockBackTicks = vel, ticks
+	v.mu.Unlock()
+}
+
+// validate validates the movement from pos to newPos of the Controllable of the Session. If the movement was
+// valid, true is returned. If not, false is returned along with the last valid position of the
+// Controllable.
+func (v *movementValidator) validate(s *Session, pos, newPos mgl64.Vec3, flags uint64) (mgl64.Vec3, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	c, w := s.c, s.c.World()
+	delta := newPos.Sub(pos)
+	box := c.Type().BBox(c)
+	onGround := v.onGround
+	v.onGround = colliding(w, box.Translate(newPos).Extend(mgl64.Vec3{0, -0.05}))
+
+	if exempt(c) {
+		v.vel = delta
+		return newPos, true
+	}
+	if v.reset {
+		// The simulation was restarted, for example after a teleport, so the velocity of the player is not
+		// known. Only block collisions are validated.
+		if collidingNew(w, box.Translate(pos), box.Translate(newPos)) {
+			return pos, false
+		}
+		// The velocity is not known either, but the client can't move up faster than it jumps.
+		v.vel, v.reset = mgl64.Vec3{delta[0], math.Min(delta[1], jumpVelocity), delta[2]}, false
+		return newPos, true
+	}
+	v.updateModifiers(s, flags)
+	tol := v.conf.Tolerance
+
+	expected := v.expectedVelocity(c, w, pos, onGround, flags)
+	v.bounce = 0
+	inFluid, climbing := inLiquid(w, box.Translate(pos)), climbable(w, pos)
+	if inFluid || climbing {
+		// Movement in liquids and on ladders is not simulated precisely. Instead, the player is allowed to
+		// move at most as fast as it could walk, or move up at a fixed rate.
+		expected[1] = math.Max(expected[1], 0.3)
+		if h := math.Max(horizontalLen(expected), v.maxSpeed*2.5); h > 0 {
+			expected[0], expected[2] = h, 0
+		}
+	}
+	knockBack, knocked := v.knockBack, v.knockBackTicks > 0
+	if knocked {
+		v.knockBackTicks--
+	}
+
+	actualH, expectedH := horizontalLen(delta), horizontalLen(expected)
+	valid := true
+	if c.Gliding() {
+		// While gliding, vertical speed may be converted into horizontal speed and the other way around, so
+		// only the total speed is validated.
+		valid = delta.Len() <= expected.Len()+tol || (knocked && delta.Len() <= knockBack.Len()+tol)
+	} else {
+		validH := actualH <= expectedH+tol || (knocked && actualH <= horizontalLen(knockBack)+tol)
+		validY := delta[1] <= expected[1]+tol || (knocked && delta[1] <= knockBack[1]+tol)
+		if !validY && delta[1] <= 0 && v.onGround {
+			// The player fell less than expected, but it landed on a block that stopped it.
+			validY = true
+		}
+		if !validY && onGround && delta[1] <= stepHeight+tol {
+			// The player stepped up a block, such as a slab or stairs.
+			validY = true
+		}
+		valid = validH && validY
+	}
+	if valid && !collidingNew(w, box.Translate(pos), box.Translate(newPos)) {
+		// The velocity used for the next tick is never higher than the velocity expected, so that small
+		// deviations within the tolerance cannot build up over several ticks.
+		maxY := expected[1]
+		if knocked {
+			maxY = math.Max(maxY, knockBack[1])
+		}
+		v.vel = mgl64.Vec3{delta[0], math.Min(delta[1], maxY), delta[2]}
+		if !onGround && !v.onGround && !inFluid && !climbing && !knocked && !c.Gliding() {
+			// While in the air, the vertical velocity follows the simulation of the server rather than the
+			// movement of the client, so that a client falling slower than expected, or hovering, deviates
+			// further from the expected movement every tick.
+			v.vel[1] = expected[1]
+		}
+		if actualH > expectedH && !knocked {
+			v.vel[0], v.vel[2] = v.vel[0]*expectedH/actualH, v.vel[2]*expectedH/actualH
+		}
+		if v.onGround && !onGround && delta[1] < 0 && bouncy(w, newPos) {
+			// The client bounces up with part of the velocity it landed with. The velocity it landed with may
+			// be higher than the movement of this tick, which was stopped by the block.
+			v.bounce = -math.Min(delta[1], expected[1]) * bounceFactor
+		}
+		if v.onGround && v.vel[1] < 0 {
+			v.vel[1] = 0
+		}
+		if knocked && delta.Sub(knockBack).Len() <= tol*2 {
+			// The client applied the knock back, so we no longer need to allow for it.
+			v.knockBackTicks = 0
+		}
+		return newPos, true
+	}
+	// The client is moved back to pos, which resets its velocity.
+	v.vel, v.onGround = mgl64.Vec3{}, onGround
+	return pos, false
+}
+
+// expectedVelocity simulates the velocity that the Controllable is expected to move with in the next tick.
+// The horizontal velocity returned is always positioned on the X axis, as the direction of movement is not
+// validated.
+func (v *movementValidator) expectedVelocity(c Controllable, w *world.World, pos mgl64.Vec3, onGround bool, flags uint64) mgl64.Vec3 {
+	horizontal, vertical := horizontalLen(v.vel), v.vel[1]
+
+	if c.Gliding() {
+		// Gliding converts the vertical velocity into horizontal velocity, with gravity adding at most
+		// 0.08 blocks/tick. Firework rockets attached to the player boost it further.
+		speed := v.vel.Len() + gravity
+		if boosted(c, w) {
+			speed = math.Max(speed, v.vel.Len()*0.5+fireworkBoost)
+		}
+		return mgl64.Vec3{speed}
+	}
+
+	inertia, acceleration := 0.91, v.maxSpeed*0.2
+	if onGround {
+		friction := 0.6
+		if f, ok := w.Block(cube.PosFromVec3(pos).Side(cube.FaceDown)).(block.Frictional); ok {
+			friction = f.Friction()
+		}
+		inertia = friction * 0.91
+		acceleration = v.maxSpeed * (0.16277136 / (inertia * inertia * inertia))
+	}
+	if c.Sneaking() {
+		acceleration *= 0.3
+	}
+	horizontal = horizontal*inertia + acceleration
+
+	g := gravity
+	if _, ok := effectLevel(c, effect.SlowFalling{}); ok && vertical <= 0 {
+		g = 0.01
+	}
+	vertical = (vertical - g) * 0.98
+	if v.levitation > 0 {
+		vertical = v.vel[1] + (0.05*float64(v.levitation)-v.vel[1])*0.2
+	}
+	if onGround {
+		// The player can jump at any time while on the ground. Jumping while sprinting gives an additional
+		// horizontal boost.
+		vertical = math.Max(math.Max(vertical, jumpVelocity+float64(v.jumpBoost)*0.1), v.bounce)
+		if c.Sprinting() || flags&packet.InputFlagStartSprinting != 0 {
+			horizontal += 0.2
+		}
+	}
+	return mgl64.Vec3{horizontal, vertical}
+}
+
+// updateModifiers updates the speed, jump boost and levitation of the validator. Modifiers that decreased
+// keep their old value until the grace period passes, because the client only knows about the change once
+// it receives it.
+func (v *movementValidator) updateModifiers(s *Session, flags uint64) {
+	grace := v.graceTicks(s)
+
+	speed := s.c.Speed()
+	if flags&packet.InputFlagStartSprinting != 0 && !s.c.Sprinting() {
+		// The client starts sprinting this tick, but the server only handles this after the movement.
+		speed *= 1.3
+	}
+	if speed >= v.maxSpeed || v.speedTicks <= 0 {
+		v.maxSpeed, v.speedTicks = speed, grace
+	}
+	v.speedTicks--
+
+	jumpBoost, _ := effectLevel(s.c, effect.JumpBoost{})
+	v.jumpBoost, v.jumpBoostTicks = updateModifier(v.jumpBoost, jumpBoost, v.jumpBoostTicks, grace)
+
+	levitation, _ := effectLevel(s.c, effect.Levitation{})
+	v.levitation, v.levitationTicks = updateModifier(v.levitation, levitation, v.levitationTicks, grace)
+}
+
+// updateModifier updates a modifier with the current value passed. If the current value is lower than the
+// value of the modifier, the modifier is only lowered once ticks reaches 0.
+func updateModifier(modifier, current, ticks, grace int) (int, int) {
+	if current >= modifier || ticks <= 0 {
+		return current, grace
+	}
+	return modifier, ticks - 1
+}
+
+const (
+	// gravity is the gravity applied to players every tick.
+	gravity = 0.08
+	// jumpVelocity is the vertical velocity of a player when it jumps.
+	jumpVelocity = 0.42
+	// stepHeight is the height of blocks that a player can step up without jumping.
+	stepHeight = 0.6
+	// fireworkBoost is the highest speed gained in a single tick from a firework rocket while gliding.
+	fireworkBoost = 0.85
+	// bounceFactor is the part of the vertical velocity that a player keeps when bouncing on a bed.
+	bounceFactor = 0.66
+)
+
+// exempt checks if the movement of a Controllable should not be validated at all, such as when it is
+// flying, riding an entity or not colliding with blocks.
+func exempt(c Controllable) bool {
+	if c.Flying() || c.Dead() || !c.GameMode().HasCollision() {
+		return true
+	}
+	if _, riding := c.Riding(); riding {
+		return true
+	}
+	_, sleeping := c.Sleeping()
+	return sleeping
+}
+
+// effectLevel returns the level of an effect of the type passed that the Controllable has.
+func effectLevel(c Controllable, t effect.Type) (int, bool) {
+	for _, e := range c.Effects() {
+		if e.Type() == t {
+			return e.Level(), true
+		}
+	}
+	return 0, false
+}
+
+// boosted checks if a firework rocket attached to the Controllable is currently boosting it.
+func boosted(c Controllable, w *world.World) bool {
+	for _, e := range w.EntitiesWithin(c.Type().BBox(c).Translate(c.Position()).Grow(4), nil) {
+		if ent, ok := e.(*entity.Ent); ok {
+			if f, ok := ent.Behaviour().(*entity.FireworkBehaviour); ok && f.Attached() && f.Owner() == c {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// colliding checks if the BBox passed collides with the BBox of any block in the world.World.
+func colliding(w *world.World, box cube.BBox) bool {
+	for _, blockBox := range blockBBoxesWithin(w, box) {
+		if blockBox.IntersectsWith(box) {
+			return true
+		}
+	}
+	return false
+}
+
+// collidingNew checks if the BBox to collides with the BBox of any block that the BBox from did not collide
+// with yet. Blocks that a player was already inside of, such as blocks placed inside the player, are
+// ignored so that the player can move out of them.
+func collidingNew(w *world.World, from, to cube.BBox) bool {
+	// Shrink the boxes slightly to account for the limited precision of positions sent by the client.
+	from, to = from.Grow(-0.01), to.Grow(-0.01)
+	for _, blockBox := range blockBBoxesWithin(w, to) {
+		if blockBox.IntersectsWith(to) && !blockBox.IntersectsWith(from) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockBBoxesWithin returns the BBoxes of all blocks around the BBox passed.
+func blockBBoxesWithin(w *world.World, box cube.BBox) []cube.BBox {
+	min, max := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+
+	var boxes []cube.BBox
+	for y := min[1] - 1; y <= max[1]; y++ {
+		for x := min[0]; x <= max[0]; x++ {
+			for z := min[2]; z <= max[2]; z++ {
+				pos := cube.Pos{x, y, z}
+				for _, blockBox := range w.Block(pos).Model().BBox(pos, w) {
+					boxes = append(boxes, blockBox.Translate(pos.Vec3()))
+				}
+			}
+		}
+	}
+	return boxes
+}
+
+// inLiquid checks if the BBox passed is in any liquid.
+func inLiquid(w *world.World, box cube.BBox) bool {
+	min, max := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for y := min[1]; y <= max[1]; y++ {
+		for x := min[0]; x <= max[0]; x++ {
+			for z := min[2]; z <= max[2]; z++ {
+				if _, ok := w.Liquid(cube.Pos{x, y, z}); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// climbable checks if the block at the position passed may be climbed.
+func climbable(w *world.World, pos mgl64.Vec3) bool {
+	c, ok := w.Block(cube.PosFromVec3(pos)).(block.Climbable)
+	return ok && c.Climbable()
+}
+
+// bouncy checks if the block that a player at the position passed is standing on makes it bounce when
+// landing on it.
+func bouncy(w *world.World, pos mgl64.Vec3) bool {
+	_, ok := w.Block(cube.PosFromVec3(pos.Sub(mgl64.Vec3{0, 0.01}))).(block.Bed)
+	return ok
+}
+
+// horizontalLen returns the length of the vector passed on the X and Z axes.
+func horizontalLen(vec mgl64.Vec3) float64 {
+	return math.Sqrt(vec[0]*vec[0] + vec[2]*vec[2])
+}
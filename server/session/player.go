@@ -52,6 +52,15 @@ func (s *Session) closeCurrentContainer() {
 	}
 	s.closeWindow()
 
+	s.openedTrader.Store(nil)
+	if e := s.openedEntity.Swap(nil); e != nil {
+		if ent, ok := e.(*entity.Ent); ok {
+			if c, ok := ent.Behaviour().(entity.Container); ok {
+				c.RemoveViewer(s)
+			}
+		}
+		return
+	}
 	pos := s.openedPos.Load()
 	w := s.c.World()
 	b := w.Block(pos)
@@ -94,6 +103,8 @@ func (s *Session) SendRespawn(pos mgl64.Vec3) {
 // sendRecipes sends the current crafting recipes to the session.
 func (s *Session) sendRecipes() {
 	recipes := make([]protocol.Recipe, 0, len(recipe.Recipes()))
+	var potionRecipes []protocol.PotionRecipe
+	var potionContainerChangeRecipes []protocol.PotionContainerChangeRecipe
 	for index, i := range recipe.Recipes() {
 		networkID := uint32(index) + 1
 		s.recipes[networkID] = i
@@ -130,9 +141,35 @@ func (s *Session) sendRecipes() {
 				Block:           i.Block(),
 				RecipeNetworkID: networkID,
 			})
+		case recipe.Potion:
+			inputID, inputMeta, _ := world.ItemRuntimeID(i.Input()[0].Item())
+			reagentID, reagentMeta, _ := world.ItemRuntimeID(i.Input()[1].Item())
+			outputID, outputMeta, _ := world.ItemRuntimeID(i.Output()[0].Item())
+			potionRecipes = append(potionRecipes, protocol.PotionRecipe{
+				InputPotionID:        inputID,
+				InputPotionMetadata:  int32(inputMeta),
+				ReagentItemID:        reagentID,
+				ReagentItemMetadata:  int32(reagentMeta),
+				OutputPotionID:       outputID,
+				OutputPotionMetadata: int32(outputMeta),
+			})
+		case recipe.PotionContainerChange:
+			inputID, _, _ := world.ItemRuntimeID(i.Input()[0].Item())
+			reagentID, _, _ := world.ItemRuntimeID(i.Input()[1].Item())
+			outputID, _, _ := world.ItemRuntimeID(i.Output()[0].Item())
+			potionContainerChangeRecipes = append(potionContainerChangeRecipes, protocol.PotionContainerChangeRecipe{
+				InputItemID:   inputID,
+				ReagentItemID: reagentID,
+				OutputItemID:  outputID,
+			})
 		}
 	}
-	s.writePacket(&packet.CraftingData{Recipes: recipes, ClearRecipes: true})
+	s.writePacket(&packet.CraftingData{
+		Recipes:                      recipes,
+		PotionRecipes:                potionRecipes,
+		PotionContainerChangeRecipes: potionContainerChangeRecipes,
+		ClearRecipes:                 true,
+	})
 }
 
 // sendInv sends the inventory passed to the client with the window ID.
@@ -186,6 +223,9 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 		// Armour inventory.
 		return s.armour.Inventory(), true
 	case protocol.ContainerLevelEntity:
+		if s.containerOpened.Load() && s.openedEntity.Load() != nil {
+			return s.openedWindow.Load(), true
+		}
 		if s.containerOpened.Load() {
 			b := s.c.World().Block(s.openedPos.Load())
 			if _, chest := b.(block.Chest); chest {
@@ -194,6 +234,10 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 				return s.openedWindow.Load(), true
 			}
 		}
+	case protocol.ContainerTradeTwoIngredientOne, protocol.ContainerTradeTwoIngredientTwo:
+		if s.containerOpened.Load() && s.openedTrader.Load() != nil {
+			return s.ui, true
+		}
 	case protocol.ContainerBarrel:
 		if s.containerOpened.Load() {
 			if _, barrel := s.c.World().Block(s.openedPos.Load()).(block.Barrel); barrel {
@@ -249,6 +293,12 @@ func (s *Session) invByID(id int32) (*inventory.Inventory, bool) {
 				return s.openedWindow.Load(), true
 			}
 		}
+	case protocol.ContainerBrewingStandInput, protocol.ContainerBrewingStandResult, protocol.ContainerBrewingStandFuel:
+		if s.containerOpened.Load() {
+			if _, ok := s.c.World().Block(s.openedPos.Load()).(block.BrewingStand); ok {
+				return s.openedWindow.Load(), true
+			}
+		}
 	}
 	return nil, false
 }
@@ -428,6 +478,15 @@ func (s *Session) SendAbsorption(value float64) {
 	})
 }
 
+// SendChargeItemComplete sends a packet to the player indicating that the item it is charging, such as a
+// crossbow, has finished charging.
+func (s *Session) SendChargeItemComplete() {
+	s.writePacket(&packet.ActorEvent{
+		EntityRuntimeID: selfEntityRuntimeID,
+		EventType:       packet.ActorEventFinishedChargingCrossbow,
+	})
+}
+
 // SendEffect sends an effects passed to the player.
 func (s *Session) SendEffect(e effect.Effect) {
 	s.SendEffectRemoval(e.Type())
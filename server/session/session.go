@@ -11,6 +11,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/item/trade"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/form"
 	"github.com/df-mc/dragonfly/server/world"
@@ -70,6 +71,8 @@ type Session struct {
 	openedContainerID              atomic.Uint32
 	openedWindow                   atomic.Value[*inventory.Inventory]
 	openedPos                      atomic.Value[cube.Pos]
+	openedEntity                   atomic.Value[world.Entity]
+	openedTrader                   atomic.Value[trade.Trader]
 	swingingArm                    atomic.Bool
 
 	recipes map[uint32]recipe.Recipe
@@ -81,6 +84,14 @@ type Session struct {
 
 	joinMessage, quitMessage string
 
+	movement atomic.Value[*movementValidator]
+	combat   atomic.Value[*combatValidator]
+
+	middlewareMu sync.Mutex
+	middleware   atomic.Value[[]PacketMiddleware]
+
+	stats stats
+
 	closeBackground chan struct{}
 }
 
@@ -309,6 +320,10 @@ func (s *Session) handlePackets() {
 		if err != nil {
 			return
 		}
+		s.stats.packetsReceived.Inc()
+		if pk = s.interceptClientPacket(pk); pk == nil {
+			continue
+		}
 		if err := s.handlePacket(pk); err != nil {
 			// An error occurred during the handling of a packet. Print the error and stop handling any more
 			// packets.
@@ -460,6 +475,7 @@ func (s *Session) registerHandlers() {
 		packet.IDLevelSoundEvent:       &LevelSoundEventHandler{},
 		packet.IDMobEquipment:          &MobEquipmentHandler{},
 		packet.IDModalFormResponse:     &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
+		packet.IDMoveActorAbsolute:     &MoveActorAbsoluteHandler{},
 		packet.IDMovePlayer:            nil,
 		packet.IDPlayerAction:          &PlayerActionHandler{},
 		packet.IDPlayerAuthInput:       &PlayerAuthInputHandler{},
@@ -490,7 +506,12 @@ func (s *Session) writePacket(pk packet.Packet) {
 	if s == Nop {
 		return
 	}
-	_ = s.conn.WritePacket(pk)
+	if pk = s.interceptServerPacket(pk); pk == nil {
+		return
+	}
+	if err := s.conn.WritePacket(pk); err == nil {
+		s.stats.packetsSent.Inc()
+	}
 }
 
 // initPlayerList initialises the player list of the session and sends the session itself to all other
@@ -0,0 +1,41 @@
+package session
+
+import (
+	"github.com/df-mc/atomic"
+)
+
+// Stats holds network statistics of a Session, such as the amount of packets sent and received. Stats may be
+// obtained using Session.Stats and are typically used to export metrics of a server.
+type Stats struct {
+	// PacketsReceived is the total amount of packets received from the client.
+	PacketsReceived uint64
+	// PacketsSent is the total amount of packets sent to the client.
+	PacketsSent uint64
+	// BlobsSent is the total amount of chunk blobs sent to the client as a hash, so that the client may use
+	// its own cache if it has the blob already. It is always 0 if the client has its cache disabled.
+	BlobsSent uint64
+	// BlobHits is the total amount of blobs that the client had cached.
+	BlobHits uint64
+	// BlobMisses is the total amount of blobs that the client did not have cached and had to request.
+	BlobMisses uint64
+}
+
+// stats holds the counters of a Session used to produce its Stats.
+type stats struct {
+	packetsReceived, packetsSent    atomic.Uint64
+	blobsSent, blobHits, blobMisses atomic.Uint64
+}
+
+// Stats returns the current Stats of the Session.
+func (s *Session) Stats() Stats {
+	if s == Nop {
+		return Stats{}
+	}
+	return Stats{
+		PacketsReceived: s.stats.packetsReceived.Load(),
+		PacketsSent:     s.stats.packetsSent.Load(),
+		BlobsSent:       s.stats.blobsSent.Load(),
+		BlobHits:        s.stats.blobHits.Load(),
+		BlobMisses:      s.stats.blobMisses.Load(),
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/trade"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/particle"
 	"github.com/df-mc/dragonfly/server/world/sound"
@@ -55,6 +56,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 	if s.entityHidden(e) {
 		return
 	}
+	defer s.viewEntityLinks(e)
 	var runtimeID uint64
 
 	_, controllable := e.(Controllable)
@@ -159,6 +161,58 @@ func (s *Session) ViewEntity(e world.Entity) {
 	})
 }
 
+// driving checks if the Controllable of the Session is driving the entity passed, meaning the client controls
+// the movement of the entity.
+func (s *Session) driving(e world.Entity) bool {
+	ent, ok := e.(*entity.Ent)
+	if !ok {
+		return false
+	}
+	if _, ok := ent.Behaviour().(entity.Drivable); !ok {
+		return false
+	}
+	driver, ok := entity.Driver(e)
+	return ok && driver == world.Entity(s.c)
+}
+
+// viewEntityLinks shows the links between the entity passed and the vehicle it is riding or the passengers
+// riding it, so that entities riding each other are displayed as such when they are first viewed.
+func (s *Session) viewEntityLinks(e world.Entity) {
+	if r, ok := e.(entity.Rider); ok {
+		if v, ok := r.Riding(); ok {
+			s.viewEntityLink(e, v, riderLinkType(e, v))
+		}
+	}
+	if r, ok := entity.RideableOf(e); ok {
+		for _, p := range r.Passengers() {
+			s.viewEntityLink(p, e, riderLinkType(p, e))
+		}
+	}
+}
+
+// viewEntityLink sends a link of the type passed between a rider and the vehicle it rides. Nothing is sent if
+// either of the entities is not currently shown to the session.
+func (s *Session) viewEntityLink(rider, vehicle world.Entity, linkType byte) {
+	riderID, vehicleID := s.entityRuntimeID(rider), s.entityRuntimeID(vehicle)
+	if riderID == 0 || vehicleID == 0 || s.entityHidden(rider) || s.entityHidden(vehicle) {
+		return
+	}
+	s.writePacket(&packet.SetActorLink{EntityLink: protocol.EntityLink{
+		RiddenEntityUniqueID: int64(vehicleID),
+		RiderEntityUniqueID:  int64(riderID),
+		Type:                 linkType,
+	}})
+}
+
+// riderLinkType returns the type of link between a rider and the vehicle it rides, depending on whether the
+// rider is the driver of the vehicle.
+func riderLinkType(rider, vehicle world.Entity) byte {
+	if d, ok := entity.Driver(vehicle); ok && d == rider {
+		return protocol.EntityLinkRider
+	}
+	return protocol.EntityLinkPassenger
+}
+
 // ViewEntityGameMode ...
 func (s *Session) ViewEntityGameMode(e world.Entity) {
 	if s.entityHidden(e) {
@@ -191,6 +245,12 @@ func (s *Session) HideEntity(e world.Entity) {
 		// The entity was already removed some other way. We don't need to send a packet.
 		return
 	}
+	if s.openedEntity.Load() == e {
+		s.closeCurrentContainer()
+	}
+	if v := s.combat.Load(); v != nil {
+		v.forget(e)
+	}
 	s.writePacket(&packet.RemoveActor{EntityUniqueID: int64(id)})
 }
 
@@ -200,6 +260,15 @@ func (s *Session) ViewEntityMovement(e world.Entity, pos mgl64.Vec3, rot cube.Ro
 	if id == selfEntityRuntimeID || s.entityHidden(e) {
 		return
 	}
+	if s.driving(e) {
+		// The client moves the vehicle it is driving itself, so sending its movement back would only make it
+		// stutter.
+		return
+	}
+
+	if v := s.combat.Load(); v != nil {
+		v.record(e, pos)
+	}
 
 	flags := byte(0)
 	if onGround {
@@ -218,6 +287,9 @@ func (s *Session) ViewEntityVelocity(e world.Entity, velocity mgl64.Vec3) {
 	if s.entityHidden(e) {
 		return
 	}
+	if v := s.movement.Load(); v != nil && e == s.c {
+		v.setKnockBack(velocity, v.graceTicks(s))
+	}
 	s.writePacket(&packet.SetActorMotion{
 		EntityRuntimeID: s.entityRuntimeID(e),
 		Velocity:        vec64To32(velocity),
@@ -248,6 +320,8 @@ func (s *Session) ViewEntityTeleport(e world.Entity, position mgl64.Vec3) {
 	if id == selfEntityRuntimeID {
 		s.chunkLoader.Move(position)
 		s.teleportPos.Store(&position)
+	} else if v := s.combat.Load(); v != nil {
+		v.record(e, position)
 	}
 
 	s.writePacket(&packet.SetActorMotion{EntityRuntimeID: id})
@@ -518,6 +592,8 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		return
 	case sound.Explosion:
 		pk.SoundType = packet.SoundEventExplode
+	case sound.ShieldBlock:
+		pk.SoundType = packet.SoundEventShieldBlock
 	case sound.Thunder:
 		pk.SoundType, pk.EntityType = packet.SoundEventThunder, "minecraft:lightning_bolt"
 	case sound.Click:
@@ -578,12 +654,98 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventBlast
 	case sound.FireworkTwinkle:
 		pk.SoundType = packet.SoundEventTwinkle
+	case sound.FishingHookSplash:
+		pk.SoundType = packet.SoundEventSplash
 	case sound.FurnaceCrackle:
 		pk.SoundType = packet.SoundEventFurnaceUse
 	case sound.BlastFurnaceCrackle:
 		pk.SoundType = packet.SoundEventBlastFurnaceUse
 	case sound.SmokerCrackle:
 		pk.SoundType = packet.SoundEventSmokerUse
+	case sound.PotionBrewed:
+		pk.SoundType = packet.SoundEventPotionBrewed
+	case sound.CauldronFillWater:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronFillWater,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronTakeWater:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronTakeWater,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronFillLava:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronFillLava,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronTakeLava:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronTakeLava,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronFillPowderSnow:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronFillPowderSnow,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronTakePowderSnow:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronTakePowderSnow,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronFillPotion:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronFillPotion,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronTakePotion:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronTakePotion,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronAddDye:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronAddDye,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronDyeArmour:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronDyeArmor,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronCleanArmour:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronCleanArmor,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronCleanBanner:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronCleanBanner,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronExplode:
+		s.writePacket(&packet.LevelEvent{
+			EventType: packet.LevelEventCauldronExplode,
+			Position:  vec64To32(pos),
+		})
+		return
+	case sound.CauldronDripWater:
+		pk.SoundType = packet.SoundEventPointedDripstoneCauldronDripWater
+	case sound.CauldronDripLava:
+		pk.SoundType = packet.SoundEventPointedDripstoneCauldronDripLava
 	case sound.UseSpyglass:
 		pk.SoundType = packet.SoundEventUseSpyglass
 	case sound.StopUsingSpyglass:
@@ -697,6 +859,45 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventBow
 	case sound.ArrowHit:
 		pk.SoundType = packet.SoundEventBowHit
+	case sound.CrossbowLoad:
+		switch so.Stage {
+		case sound.CrossbowLoadingStart:
+			pk.SoundType = packet.SoundEventCrossbowLoadingStart
+			if so.QuickCharge {
+				pk.SoundType = packet.SoundEventCrossbowQuickChargeStart
+			}
+		case sound.CrossbowLoadingMiddle:
+			pk.SoundType = packet.SoundEventCrossbowLoadingMiddle
+			if so.QuickCharge {
+				pk.SoundType = packet.SoundEventCrossbowQuickChargeMiddle
+			}
+		default:
+			pk.SoundType = packet.SoundEventCrossbowLoadingEnd
+			if so.QuickCharge {
+				pk.SoundType = packet.SoundEventCrossbowQuickChargeEnd
+			}
+		}
+	case sound.CrossbowShoot:
+		pk.SoundType = packet.SoundEventCrossbowShoot
+	case sound.TridentThrow:
+		pk.SoundType = packet.SoundEventTridentThrow
+	case sound.TridentHit:
+		pk.SoundType = packet.SoundEventTridentHit
+	case sound.TridentHitGround:
+		pk.SoundType = packet.SoundEventTridentHitGround
+	case sound.TridentReturn:
+		pk.SoundType = packet.SoundEventTridentReturn
+	case sound.TridentRiptide:
+		switch so.Level {
+		case 1:
+			pk.SoundType = packet.SoundEventTridentRiptide1
+		case 2:
+			pk.SoundType = packet.SoundEventTridentRiptide2
+		default:
+			pk.SoundType = packet.SoundEventTridentRiptide3
+		}
+	case sound.TridentThunder:
+		pk.SoundType = packet.SoundEventTridentThunder
 	case sound.ItemThrow:
 		pk.SoundType, pk.EntityType = packet.SoundEventThrow, "minecraft:player"
 	case sound.LevelUp:
@@ -810,6 +1011,33 @@ func (s *Session) ViewFurnaceUpdate(prevCookTime, cookTime, prevRemainingFuelTim
 	}
 }
 
+// ViewBrewingUpdate updates a brewing stand for the associated session based on previous times and fuel values.
+func (s *Session) ViewBrewingUpdate(prevBrewTime, brewTime time.Duration, prevFuelAmount, fuelAmount, prevFuelTotal, fuelTotal int32) {
+	if prevBrewTime != brewTime {
+		s.writePacket(&packet.ContainerSetData{
+			WindowID: byte(s.openedWindowID.Load()),
+			Key:      packet.ContainerDataBrewingStandBrewTime,
+			Value:    int32(brewTime.Milliseconds() / 50),
+		})
+	}
+
+	if prevFuelAmount != fuelAmount {
+		s.writePacket(&packet.ContainerSetData{
+			WindowID: byte(s.openedWindowID.Load()),
+			Key:      packet.ContainerDataBrewingStandFuelAmount,
+			Value:    fuelAmount,
+		})
+	}
+
+	if prevFuelTotal != fuelTotal {
+		s.writePacket(&packet.ContainerSetData{
+			WindowID: byte(s.openedWindowID.Load()),
+			Key:      packet.ContainerDataBrewingStandFuelTotal,
+			Value:    fuelTotal,
+		})
+	}
+}
+
 // ViewBlockUpdate ...
 func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
 	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
@@ -857,6 +1085,11 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			ActionType:      packet.AnimateActionCriticalHit,
 			EntityRuntimeID: s.entityRuntimeID(e),
 		})
+	case entity.WakeUpAction:
+		s.writePacket(&packet.Animate{
+			ActionType:      packet.AnimateActionStopSleep,
+			EntityRuntimeID: s.entityRuntimeID(e),
+		})
 	case entity.DeathAction:
 		s.writePacket(&packet.ActorEvent{
 			EntityRuntimeID: s.entityRuntimeID(e),
@@ -878,6 +1111,24 @@ func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
 			EntityRuntimeID: s.entityRuntimeID(e),
 			EventType:       packet.ActorEventFireworksExplode,
 		})
+	case entity.FishingHookBubbleAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventFishhookBubble,
+		})
+	case entity.FishingHookTeaseAction:
+		s.writePacket(&packet.ActorEvent{
+			EntityRuntimeID: s.entityRuntimeID(e),
+			EventType:       packet.ActorEventFishhookTease,
+		})
+	case entity.MountAction:
+		linkType := byte(protocol.EntityLinkPassenger)
+		if act.Driver {
+			linkType = protocol.EntityLinkRider
+		}
+		s.viewEntityLink(e, act.Vehicle, linkType)
+	case entity.DismountAction:
+		s.viewEntityLink(e, act.Vehicle, protocol.EntityLinkRemove)
 	case entity.EatAction:
 		if user, ok := e.(item.User); ok {
 			held, _ := user.HeldItems()
@@ -982,6 +1233,8 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos) {
 		containerType = protocol.ContainerTypeBlastFurnace
 	case block.Smoker:
 		containerType = protocol.ContainerTypeSmoker
+	case block.BrewingStand:
+		containerType = protocol.ContainerTypeBrewingStand
 	}
 
 	s.writePacket(&packet.ContainerOpen{
@@ -993,6 +1246,45 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos) {
 	s.sendInv(b.Inventory(), uint32(nextID))
 }
 
+// OpenEntityContainer opens the inventory of an entity, such as a chest minecart, for the Session.
+func (s *Session) OpenEntityContainer(e world.Entity, c entity.Container) {
+	if s.containerOpened.Load() && s.openedEntity.Load() == e {
+		return
+	}
+	s.closeCurrentContainer()
+	c.AddViewer(s)
+
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(c.Inventory())
+	s.openedEntity.Store(e)
+
+	containerType := byte(protocol.ContainerTypeContainer)
+	if m, ok := c.(*entity.MinecartBehaviour); ok && m.Hopper() {
+		containerType = protocol.ContainerTypeHopper
+	}
+	s.writePacket(&packet.ContainerOpen{
+		WindowID:                nextID,
+		ContainerType:           containerType,
+		ContainerEntityUniqueID: int64(s.entityRuntimeID(e)),
+	})
+	s.sendInv(c.Inventory(), uint32(nextID))
+}
+
+// OpenTrade opens a trade window with the offers of the trade.Trader passed for the Session. The entity passed
+// is the entity that the Controllable of the Session trades with. It may be the Controllable itself if the
+// trade.Trader is not backed by an entity.
+func (s *Session) OpenTrade(e world.Entity, t trade.Trader) {
+	s.closeCurrentContainer()
+
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(inventory.New(1, nil))
+	s.openedEntity.Store(e)
+	s.openedTrader.Store(t)
+	s.sendTrade(e, t, nextID)
+}
+
 // ViewSlotChange ...
 func (s *Session) ViewSlotChange(slot int, newItem item.Stack) {
 	if !s.containerOpened.Load() {
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"net"
+	"sync"
+	"time"
+)
+
+// ThrottleConfig holds settings for limiting the rate at which connections
+// from a single IP address are accepted by a Server.
+type ThrottleConfig struct {
+	// Connections is the maximum amount of connections accepted from a single
+	// IP address within Period. Connections exceeding this limit are refused.
+	// If 0 or lower, connections are not throttled.
+	Connections int
+	// Period is the period over which Connections are counted. If zero, a
+	// period of one minute is used.
+	Period time.Duration
+	// Message is the message that connections refused are disconnected with.
+	// If empty, a default message is used.
+	Message string
+}
+
+// throttle limits the rate at which connections are accepted per IP address.
+// The methods of throttle may be called on a nil *throttle, in which case all
+// connections are accepted.
+type throttle struct {
+	conf ThrottleConfig
+
+	mu sync.Mutex
+	// connections holds the times at which the connections within the period
+	// of the throttle were accepted, indexed by IP address.
+	connections map[string][]time.Time
+	lastPrune   time.Time
+}
+
+// newThrottle creates a throttle using the ThrottleConfig passed. If the
+// ThrottleConfig is nil or does not limit connections, newThrottle returns
+// nil.
+func newThrottle(conf *ThrottleConfig) *throttle {
+	if conf == nil || conf.Connections <= 0 {
+		return nil
+	}
+	t := &throttle{conf: *conf, connections: map[string][]time.Time{}}
+	if t.conf.Period <= 0 {
+		t.conf.Period = time.Minute
+	}
+	if t.conf.Message == "" {
+		t.conf.Message = "Too many connections. Please try again later."
+	}
+	return t
+}
+
+// allow records a connection from the address passed and checks if it may be
+// accepted. If not, the message to disconnect the connection with is
+// returned.
+func (t *throttle) allow(addr net.Addr) (string, bool) {
+	ip := addrIP(addr)
+	if t == nil || ip == "" {
+		return "", true
+	}
+	now := time.Now()
+	since := now.Add(-t.conf.Period)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now.Sub(t.lastPrune) > t.conf.Period {
+		// Prune the connections of IP addresses that have not connected
+		// within the period, so that the map doesn't grow indefinitely.
+		t.lastPrune = now
+		for k, times := range t.connections {
+			if times[len(times)-1].Before(since) {
+				delete(t.connections, k)
+			}
+		}
+	}
+	times := t.connections[ip]
+	for len(times) > 0 && times[0].Before(since) {
+		times = times[1:]
+	}
+	if len(times) >= t.conf.Connections {
+		t.connections[ip] = times
+		return t.conf.Message, false
+	}
+	t.connections[ip] = append(times, now)
+	return "", true
+}
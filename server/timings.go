@@ -0,0 +1,168 @@
+package server
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"time"
+)
+
+// TimingsCommand returns a cmd.Command named "timings" that shows the tick
+// performance of the world.World of the cmd.Source executing it and allows
+// collecting timings using world.World.StartTimings. The command is not
+// registered by default and may be registered using cmd.Register.
+//
+// Running /timings shows the TPS and MSPT of the world, /timings start starts
+// collecting timings, /timings report shows the timings collected so far and
+// /timings stop stops collecting timings and shows them.
+//
+// allow is called to check if a cmd.Source may execute the command. If nil,
+// only sources other than players, such as the console and RCON clients, may
+// execute it.
+func TimingsCommand(allow func(src cmd.Source) bool) cmd.Command {
+	a := timingsAllower{allow: allow}
+	return cmd.New("timings", "Shows the tick performance of the world and collects timings.", nil,
+		timingsPerformance{timingsAllower: a},
+		timingsStart{timingsAllower: a},
+		timingsReport{timingsAllower: a},
+		timingsStop{timingsAllower: a},
+	)
+}
+
+// timingsAllower implements cmd.Allower for the Runnables of the timings
+// command.
+type timingsAllower struct {
+	allow func(src cmd.Source) bool
+}
+
+// Allow ...
+func (a timingsAllower) Allow(src cmd.Source) bool {
+	if a.allow == nil {
+		return nonPlayerSource(src)
+	}
+	return a.allow(src)
+}
+
+// nonPlayerSource checks if a cmd.Source is not a *player.Player, such as the
+// console or an RCON client. It is used by commands that players may only
+// execute if explicitly allowed.
+func nonPlayerSource(src cmd.Source) bool {
+	_, ok := src.(*player.Player)
+	return !ok
+}
+
+// timingsPerformance implements the /timings command.
+type timingsPerformance struct {
+	timingsAllower
+}
+
+// Run ...
+func (timingsPerformance) Run(src cmd.Source, o *cmd.Output) {
+	w := src.World()
+	o.Printf("Performance of %v (%v):", w.Name(), w.Dimension())
+	for _, perf := range w.Performance() {
+		o.Printf("- Last %v: %.2f TPS, %v MSPT (max %v)", perf.Period, perf.TPS, millis(perf.AverageTick), millis(perf.MaxTick))
+	}
+	if _, ok := w.Timings(); ok {
+		o.Printf("Timings are being collected. Use /timings report or /timings stop to view them.")
+	}
+}
+
+// timingsStart implements the /timings start command.
+type timingsStart struct {
+	timingsAllower
+	Start cmd.SubCommand `cmd:"start"`
+}
+
+// Run ...
+func (timingsStart) Run(src cmd.Source, o *cmd.Output) {
+	w := src.World()
+	w.StartTimings()
+	o.Printf("Started collecting timings of %v (%v).", w.Name(), w.Dimension())
+}
+
+// timingsReport implements the /timings report command.
+type timingsReport struct {
+	timingsAllower
+	Report cmd.SubCommand `cmd:"report"`
+}
+
+// Run ...
+func (timingsReport) Run(src cmd.Source, o *cmd.Output) {
+	t, ok := src.World().Timings()
+	if !ok {
+		o.Errorf("No timings are being collected. Use /timings start to start collecting them.")
+		return
+	}
+	printTimings(src.World(), t, o)
+}
+
+// timingsStop implements the /timings stop command.
+type timingsStop struct {
+	timingsAllower
+	Stop cmd.SubCommand `cmd:"stop"`
+}
+
+// Run ...
+func (timingsStop) Run(src cmd.Source, o *cmd.Output) {
+	t, ok := src.World().StopTimings()
+	if !ok {
+		o.Errorf("No timings are being collected. Use /timings start to start collecting them.")
+		return
+	}
+	printTimings(src.World(), t, o)
+}
+
+// timingsLimit is the maximum amount of entity and block timings printed by
+// the timings command.
+const timingsLimit = 10
+
+// printTimings prints world.Timings collected in a world.World to a
+// cmd.Output.
+func printTimings(w *world.World, t world.Timings, o *cmd.Output) {
+	o.Printf("Timings of %v (%v) over %v (%v ticks):", w.Name(), w.Dimension(), t.Duration.Round(time.Millisecond), t.Ticks)
+	if t.Ticks == 0 {
+		return
+	}
+	o.Printf("Average tick: %v MSPT", millis(t.Total/time.Duration(t.Ticks)))
+	for _, phase := range t.Phases {
+		o.Printf("- %v: %v", phase.Name, timingStats(phase, t))
+	}
+	if len(t.Entities) > 0 {
+		o.Printf("Entities:")
+		for _, e := range limitTimings(t.Entities) {
+			o.Printf("- %v (%v ticked): %v", e.Name, e.Count, timingStats(e, t))
+		}
+	}
+	if len(t.Blocks) > 0 {
+		o.Printf("Blocks:")
+		for _, b := range limitTimings(t.Blocks) {
+			o.Printf("- %v [%v] (%v ticked): %v", b.Name, b.Phase, b.Count, timingStats(b, t))
+		}
+	}
+}
+
+// timingStats formats the time spent per tick and the percentage of the total
+// tick time of a world.Timing.
+func timingStats(timing world.Timing, t world.Timings) string {
+	percentage := 0.0
+	if t.Total > 0 {
+		percentage = float64(timing.Total) / float64(t.Total) * 100
+	}
+	return fmt.Sprintf("%v ms/tick (%.1f%%)", millis(timing.Total/time.Duration(t.Ticks)), percentage)
+}
+
+// limitTimings returns at most the first timingsLimit world.Timing values of the
+// slice passed.
+func limitTimings(timings []world.Timing) []world.Timing {
+	if len(timings) > timingsLimit {
+		return timings[:timingsLimit]
+	}
+	return timings
+}
+
+// millis formats a time.Duration as milliseconds with two decimals.
+func millis(d time.Duration) string {
+	return fmt.Sprintf("%.2f", float64(d)/float64(time.Millisecond))
+}
@@ -0,0 +1,170 @@
+package world
+
+import (
+	"github.com/go-gl/mathgl/mgl64"
+	"math"
+	"time"
+)
+
+// Border is a square border around the centre of a World. Entities are unable
+// to move through the Border, and players outside the Border take damage. A
+// Border with a Size of 0 is disabled, which is the default.
+type Border struct {
+	// Centre is the centre of the Border on the X and Z axes.
+	Centre mgl64.Vec2
+	// Size is the current length of each side of the Border in blocks. If 0,
+	// the Border is disabled.
+	Size float64
+	// TargetSize is the size that the Border is growing or shrinking towards.
+	// The Border reaches this size after TargetTicks ticks.
+	TargetSize float64
+	// TargetTicks is the amount of ticks left until the Border reaches its
+	// TargetSize. If 0, the Border does not change in size.
+	TargetTicks int64
+	// DamagePerBlock is the damage dealt every second to players outside the
+	// Border for every block they are beyond the SafeZone.
+	DamagePerBlock float64
+	// SafeZone is the distance in blocks that players may be outside the
+	// Border without taking damage.
+	SafeZone float64
+	// WarningDistance is the distance in blocks from the Border at which
+	// players start seeing the Border.
+	WarningDistance float64
+}
+
+// NewBorder returns a Border with the centre and size passed and the default
+// damage, safe zone and warning distance values.
+func NewBorder(centre mgl64.Vec2, size float64) Border {
+	return Border{Centre: centre, Size: size, DamagePerBlock: 0.2, SafeZone: 5, WarningDistance: 5}
+}
+
+// Enabled checks if the Border is enabled, which is the case if its Size is
+// bigger than 0.
+func (b Border) Enabled() bool {
+	return b.Size > 0
+}
+
+// Min returns the minimum X and Z coordinates of the Border.
+func (b Border) Min() mgl64.Vec2 {
+	return b.Centre.Sub(mgl64.Vec2{b.Size / 2, b.Size / 2})
+}
+
+// Max returns the maximum X and Z coordinates of the Border.
+func (b Border) Max() mgl64.Vec2 {
+	return b.Centre.Add(mgl64.Vec2{b.Size / 2, b.Size / 2})
+}
+
+// Contains checks if the position passed is within the Border. Contains
+// always returns true if the Border is not enabled.
+func (b Border) Contains(pos mgl64.Vec3) bool {
+	return b.Distance(pos) >= 0
+}
+
+// Distance returns the distance from the position passed to the closest edge
+// of the Border on the X and Z axes. The distance is positive if the position
+// is within the Border and negative if it is outside of it. If the Border is
+// not enabled, math.MaxFloat64 is returned.
+func (b Border) Distance(pos mgl64.Vec3) float64 {
+	if !b.Enabled() {
+		return math.MaxFloat64
+	}
+	min, max := b.Min(), b.Max()
+	return math.Min(math.Min(pos[0]-min[0], max[0]-pos[0]), math.Min(pos[2]-min[1], max[1]-pos[2]))
+}
+
+// Clamp clamps the position passed so that its X and Z coordinates are within
+// the Border. The position is returned unchanged if the Border is not
+// enabled.
+func (b Border) Clamp(pos mgl64.Vec3) mgl64.Vec3 {
+	if !b.Enabled() {
+		return pos
+	}
+	min, max := b.Min(), b.Max()
+	pos[0] = math.Max(min[0], math.Min(max[0], pos[0]))
+	pos[2] = math.Max(min[1], math.Min(max[1], pos[2]))
+	return pos
+}
+
+// Damage returns the damage that should be dealt every second to a player at
+// the position passed. 0 is returned if the position is within the Border or
+// its SafeZone, or if DamagePerBlock is 0.
+func (b Border) Damage(pos mgl64.Vec3) float64 {
+	outside := -b.Distance(pos) - b.SafeZone
+	if outside <= 0 {
+		return 0
+	}
+	return outside * b.DamagePerBlock
+}
+
+// scaled returns the Border with its Centre, Size and TargetSize multiplied by
+// the factor passed.
+func (b Border) scaled(f float64) Border {
+	b.Centre, b.Size, b.TargetSize = b.Centre.Mul(f), b.Size*f, b.TargetSize*f
+	return b
+}
+
+// tick progresses the Border towards its TargetSize if it is resizing.
+func (b *Border) tick() {
+	if b.TargetTicks <= 0 {
+		return
+	}
+	b.Size += (b.TargetSize - b.Size) / float64(b.TargetTicks)
+	b.TargetTicks--
+	if b.TargetTicks == 0 {
+		b.Size = b.TargetSize
+	}
+}
+
+// Border returns the Border of the World. The Border returned is a copy, so
+// changing it has no effect on the World until it is passed to SetBorder.
+// The Border is stored in Overworld coordinates, so in the Nether its Centre
+// and Size are divided by 8.
+func (w *World) Border() Border {
+	if w == nil {
+		return Border{}
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Border.scaled(1 / w.borderScale())
+}
+
+// SetBorder changes the Border of the World. Like other Settings, the Border
+// is shared with other worlds that use the same Settings, scaled to the
+// coordinates of their Dimension. Passing a Border with a Size of 0 disables
+// the Border.
+func (w *World) SetBorder(b Border) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Border = b.scaled(w.borderScale())
+}
+
+// ResizeBorder gradually grows or shrinks the Border of the World to the size
+// passed over the time.Duration d. If d is 0 or lower, the Border is resized
+// immediately.
+func (w *World) ResizeBorder(size float64, d time.Duration) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+
+	size *= w.borderScale()
+	w.set.Border.TargetSize, w.set.Border.TargetTicks = size, d.Milliseconds()/50
+	if w.set.Border.TargetTicks <= 0 {
+		w.set.Border.Size, w.set.Border.TargetTicks = size, 0
+	}
+}
+
+// borderScale returns the factor by which coordinates in the Dimension of the
+// World are multiplied to obtain the Overworld coordinates that the Border is
+// stored in. One block in the Nether corresponds with 8 blocks in the
+// Overworld.
+func (w *World) borderScale() float64 {
+	if w.conf.Dim == Nether {
+		return 8
+	}
+	return 1
+}
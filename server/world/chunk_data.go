@@ -0,0 +1,93 @@
+package world
+
+import (
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+	"golang.org/x/exp/slices"
+)
+
+// ChunkData returns the custom data stored in the chunk at a ChunkPos under a
+// key in a namespace, such as the name of a plugin, as set using
+// SetChunkData. If no data is stored under the key, false is returned. The
+// chunk is loaded if it is not yet loaded. The data returned is a copy and may
+// be modified freely.
+func (w *World) ChunkData(pos ChunkPos, namespace, key string) ([]byte, bool) {
+	if w == nil {
+		return nil, false
+	}
+	c := w.chunk(pos)
+	defer c.Unlock()
+
+	data, ok := c.CustomData[namespace+":"+key]
+	return slices.Clone(data), ok
+}
+
+// SetChunkData stores custom data in the chunk at a ChunkPos under a key in a
+// namespace, such as the name of a plugin. The data is saved together with
+// the chunk by the Provider of the World, but is not visible to clients.
+// Passing nil data removes the data stored under the key. The chunk is loaded
+// if it is not yet loaded.
+func (w *World) SetChunkData(pos ChunkPos, namespace, key string, data []byte) {
+	if w == nil {
+		return
+	}
+	c := w.chunk(pos)
+	defer c.Unlock()
+
+	if data == nil {
+		if _, ok := c.CustomData[namespace+":"+key]; ok {
+			delete(c.CustomData, namespace+":"+key)
+			c.modified = true
+		}
+		return
+	}
+	if c.CustomData == nil {
+		c.CustomData = make(map[string][]byte)
+	}
+	c.CustomData[namespace+":"+key] = slices.Clone(data)
+	c.modified = true
+}
+
+// ChunkDataKey is a namespaced key under which a value of type T may be
+// stored in chunks of a World. Values are encoded using NBT, so T must be a
+// type that can be encoded using the nbt package, such as a struct with
+// exported fields, a map or a primitive.
+type ChunkDataKey[T any] struct {
+	// Namespace is the namespace of the key, which is usually the name of the
+	// plugin that stores data under it.
+	Namespace string
+	// Key is the name of the key within the Namespace.
+	Key string
+}
+
+// Get reads the value stored under the ChunkDataKey in the chunk at a
+// ChunkPos in the World passed. If no value is stored, or if the value could
+// not be decoded into T, the zero value of T and false are returned.
+func (k ChunkDataKey[T]) Get(w *World, pos ChunkPos) (T, bool) {
+	var v T
+	data, ok := w.ChunkData(pos, k.Namespace, k.Key)
+	if !ok {
+		return v, false
+	}
+	if err := nbt.UnmarshalEncoding(data, &v, nbt.LittleEndian); err != nil {
+		var zero T
+		return zero, false
+	}
+	return v, true
+}
+
+// Set stores a value under the ChunkDataKey in the chunk at a ChunkPos in the
+// World passed. An error is returned if the value could not be encoded.
+func (k ChunkDataKey[T]) Set(w *World, pos ChunkPos, v T) error {
+	data, err := nbt.MarshalEncoding(v, nbt.LittleEndian)
+	if err != nil {
+		return err
+	}
+	w.SetChunkData(pos, k.Namespace, k.Key, data)
+	return nil
+}
+
+// Delete removes the value stored under the ChunkDataKey in the chunk at a
+// ChunkPos in the World passed.
+func (k ChunkDataKey[T]) Delete(w *World, pos ChunkPos) {
+	w.SetChunkData(pos, k.Namespace, k.Key, nil)
+}
@@ -35,6 +35,10 @@ type Handler interface {
 	// wood, that can be broken by fire. HandleBlockBurn is often succeeded by HandleFireSpread, when fire spreads to
 	// the position of the original block and the event.Context is not cancelled in HandleBlockBurn.
 	HandleBlockBurn(ctx *event.Context, pos cube.Pos)
+	// HandleTreeGrow handles a tree growing from a sapling at a cube.Pos. The blocks that will be placed to form the
+	// tree are passed, including those replacing the sapling(s) themselves. The map may be modified to change the tree
+	// that is grown, or ctx.Cancel() may be called to prevent the tree from growing altogether.
+	HandleTreeGrow(ctx *event.Context, pos cube.Pos, blocks map[cube.Pos]Block)
 	// HandleEntitySpawn handles an entity being spawned into a World through a call to World.AddEntity.
 	HandleEntitySpawn(e Entity)
 	// HandleEntityDespawn handles an entity being despawned from a World through a call to World.RemoveEntity.
@@ -59,6 +63,7 @@ func (NopHandler) HandleLiquidHarden(*event.Context, cube.Pos, Block, Block, Blo
 func (NopHandler) HandleSound(*event.Context, Sound, mgl64.Vec3)                      {}
 func (NopHandler) HandleFireSpread(*event.Context, cube.Pos, cube.Pos)                {}
 func (NopHandler) HandleBlockBurn(*event.Context, cube.Pos)                           {}
+func (NopHandler) HandleTreeGrow(*event.Context, cube.Pos, map[cube.Pos]Block)        {}
 func (NopHandler) HandleEntitySpawn(Entity)                                           {}
 func (NopHandler) HandleEntityDespawn(Entity)                                         {}
 func (NopHandler) HandleClose()                                                       {}
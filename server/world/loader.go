@@ -95,6 +95,7 @@ func (l *Loader) Load(n int) {
 		l.w.addViewer(c, l)
 
 		l.loaded[pos] = c
+		l.w.stats.chunksSent.Inc()
 
 		// Shift the first element from the load queue off so that we can take a new one during the next
 		// iteration.
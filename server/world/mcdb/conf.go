@@ -76,6 +76,9 @@ func (conf Config) Open(dir string) (*DB, error) {
 		if ver != leveldat.Version && ver >= 10 {
 			return nil, fmt.Errorf("open db: level.dat version %v is unsupported", ver)
 		}
+		// Older level.dat files may not hold these fields, in which case
+		// they keep the defaults set here.
+		db.ldat.PlayersSleepingPercentage, db.ldat.RespawnBlocksExplode = 100, true
 		if err = ldat.Unmarshal(db.ldat); err != nil {
 			return nil, fmt.Errorf("open db: %w", err)
 		}
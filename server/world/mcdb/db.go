@@ -10,6 +10,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/df-mc/dragonfly/server/world/mcdb/leveldat"
 	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/util"
 	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft/nbt"
 	"golang.org/x/exp/maps"
@@ -179,6 +180,10 @@ func (db *DB) column(k dbKey) (*world.Column, error) {
 		// Same as with entities, an ErrNotFound is fine here.
 		return nil, fmt.Errorf("read block entities: %w", err)
 	}
+	col.CustomData, err = db.customData(k)
+	if err != nil {
+		return nil, fmt.Errorf("read custom data: %w", err)
+	}
 	return col, nil
 }
 
@@ -305,6 +310,18 @@ func (db *DB) blockEntities(k dbKey, c *chunk.Chunk) (map[cube.Pos]world.Block,
 	return blockEntities, nil
 }
 
+func (db *DB) customData(k dbKey) (map[string][]byte, error) {
+	prefix := k.Sum(keyCustomData)
+	data := make(map[string][]byte)
+
+	iter := db.ldb.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+	for iter.Next() {
+		data[string(iter.Key()[len(prefix):])] = append([]byte(nil), iter.Value()...)
+	}
+	return data, iter.Error()
+}
+
 // StoreColumn stores a world.Column at a position and dimension in the DB. An
 // error is returned if storing was unsuccessful.
 func (db *DB) StoreColumn(pos world.ChunkPos, dim world.Dimension, col *world.Column) error {
@@ -326,7 +343,9 @@ func (db *DB) storeColumn(k dbKey, col *world.Column) error {
 	db.storeFinalisation(batch, k, finalisationPopulated)
 	db.storeEntities(batch, k, col.Entities)
 	db.storeBlockEntities(batch, k, col.BlockEntities)
-
+	if err := db.storeCustomData(batch, k, col.CustomData); err != nil {
+		return err
+	}
 	return db.ldb.Write(batch, nil)
 }
 
@@ -396,6 +415,27 @@ func (db *DB) storeBlockEntities(batch *leveldb.Batch, k dbKey, blockEntities ma
 	batch.Put(k.Sum(keyBlockEntities), buf.Bytes())
 }
 
+func (db *DB) storeCustomData(batch *leveldb.Batch, k dbKey, data map[string][]byte) error {
+	prefix := k.Sum(keyCustomData)
+
+	// Custom data that was removed from the column must also be removed from
+	// the database, so we first delete all existing custom data keys.
+	iter := db.ldb.NewIterator(util.BytesPrefix(prefix), nil)
+	for iter.Next() {
+		if _, ok := data[string(iter.Key()[len(prefix):])]; !ok {
+			batch.Delete(append([]byte(nil), iter.Key()...))
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("read custom data: %w", err)
+	}
+	for key, v := range data {
+		batch.Put(k.Sum(append([]byte{keyCustomData}, key...)...), v)
+	}
+	return nil
+}
+
 // NewColumnIterator returns a ColumnIterator that may be used to iterate over all
 // position/chunk pairs in a database.
 // An IteratorRange r may be passed to specify limits in terms of what chunks
@@ -32,6 +32,10 @@ const (
 	// keyChecksum holds a list of checksums of some sort. It's not clear of what data this checksum is composed or what
 	// these checksums are used for.
 	keyChecksums = ';' // 3b
+	// keyCustomData is not used by vanilla. It is followed by a 'namespace:key' string and holds custom data
+	// stored in a world.Column. Because these keys are longer than vanilla chunk keys, they are ignored by vanilla
+	// and other tools.
+	keyCustomData = 0xdf
 )
 
 // Keys on a per-world basis. These are found only once in a leveldb world save.
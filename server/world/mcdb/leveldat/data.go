@@ -3,6 +3,7 @@ package leveldat
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"math"
 	"time"
@@ -131,9 +132,18 @@ type Data struct {
 	WorldPolicies                  map[string]any `nbt:"world_policies"`
 	WorldVersion                   int32          `nbt:"WorldVersion"`
 	RespawnBlocksExplode           bool           `nbt:"respawnblocksexplode"`
+	PlayersSleepingPercentage      int32          `nbt:"playerssleepingpercentage"`
 	ShowBorderEffect               bool           `nbt:"showbordereffect"`
 	PermissionsLevel               int32          `nbt:"permissionsLevel"`
 	PlayerPermissionsLevel         int32          `nbt:"playerPermissionsLevel"`
+	BorderCenterX                  float64        `nbt:"borderCenterX"`
+	BorderCenterZ                  float64        `nbt:"borderCenterZ"`
+	BorderSize                     float64        `nbt:"borderSize"`
+	BorderSizeLerpTarget           float64        `nbt:"borderSizeLerpTarget"`
+	BorderSizeLerpTime             int64          `nbt:"borderSizeLerpTime"`
+	BorderDamagePerBlock           float64        `nbt:"borderDamagePerBlock"`
+	BorderSafeZone                 float64        `nbt:"borderSafeZone"`
+	BorderWarningBlocks            float64        `nbt:"borderWarningBlocks"`
 }
 
 // FillDefault fills out d with all the default level.dat values.
@@ -195,6 +205,7 @@ func (d *Data) FillDefault() {
 	d.SendCommandFeedback = true
 	d.ServerChunkTickRange = 6
 	d.ShowBorderEffect = true
+	d.PlayersSleepingPercentage = 100
 	d.ShowDeathMessages = true
 	d.ShowTags = true
 	d.SpawnMobs = true
@@ -226,6 +237,17 @@ func (d *Data) Settings() *world.Settings {
 		DefaultGameMode: mode,
 		Difficulty:      difficulty,
 		TickRange:       d.ServerChunkTickRange,
+		Border: world.Border{
+			Centre:          mgl64.Vec2{d.BorderCenterX, d.BorderCenterZ},
+			Size:            d.BorderSize,
+			TargetSize:      d.BorderSizeLerpTarget,
+			TargetTicks:     d.BorderSizeLerpTime,
+			DamagePerBlock:  d.BorderDamagePerBlock,
+			SafeZone:        d.BorderSafeZone,
+			WarningDistance: d.BorderWarningBlocks,
+		},
+		RequiredSleepingPercentage: int(d.PlayersSleepingPercentage),
+		RespawnBlocksExplode:       d.RespawnBlocksExplode,
 	}
 }
 
@@ -251,4 +273,9 @@ func (d *Data) PutSettings(s *world.Settings) {
 	d.GameType = int32(mode)
 	difficulty, _ := world.DifficultyID(s.Difficulty)
 	d.Difficulty = int32(difficulty)
+	d.BorderCenterX, d.BorderCenterZ = s.Border.Centre[0], s.Border.Centre[1]
+	d.BorderSize, d.BorderSizeLerpTarget, d.BorderSizeLerpTime = s.Border.Size, s.Border.TargetSize, s.Border.TargetTicks
+	d.BorderDamagePerBlock, d.BorderSafeZone, d.BorderWarningBlocks = s.Border.DamagePerBlock, s.Border.SafeZone, s.Border.WarningDistance
+	d.PlayersSleepingPercentage = int32(s.RequiredSleepingPercentage)
+	d.RespawnBlocksExplode = s.RespawnBlocksExplode
 }
@@ -0,0 +1,137 @@
+package mcdb
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/util"
+)
+
+// PruneConfig holds the parameters used to prune chunks from a DB using
+// DB.Prune.
+type PruneConfig struct {
+	// Range limits what chunks are considered for pruning. Chunks outside of
+	// Range are never deleted. If nil, all chunks in the DB are considered.
+	Range *IteratorRange
+	// Border, if non-nil, is the area of chunks to keep. Any chunk within Range
+	// but outside of Border is deleted. A zero Min and Max of Border results
+	// in no chunks being deleted because of the Border.
+	Border *IteratorRange
+	// Generator, if non-nil, returns the world.Generator originally used to
+	// generate chunks of a world.Dimension. If set, chunks without entities,
+	// block entities or custom data of which the blocks and biomes are equal
+	// to those produced by the world.Generator are considered never modified
+	// and are deleted. Such chunks will simply be generated again when loaded.
+	Generator func(dim world.Dimension) world.Generator
+	// Progress, if non-nil, is called for every chunk considered, after it was
+	// either kept or deleted.
+	Progress func(p PruneProgress)
+}
+
+// PruneProgress holds the progress of a DB.Prune call.
+type PruneProgress struct {
+	// Pos and Dim are the position and dimension of the chunk that was last
+	// considered.
+	Pos world.ChunkPos
+	Dim world.Dimension
+	// Checked is the amount of chunks checked so far, and Deleted the amount
+	// of those that were deleted.
+	Checked, Deleted int
+}
+
+// Prune deletes chunks from the DB according to the PruneConfig passed. The
+// final progress is returned, holding the amount of chunks checked and
+// deleted. An error is returned if reading or deleting any of the chunks
+// failed.
+func (db *DB) Prune(conf PruneConfig) (PruneProgress, error) {
+	var progress PruneProgress
+
+	iter := db.NewColumnIterator(conf.Range)
+	defer iter.Release()
+	for iter.Next() {
+		pos, dim, col := iter.Position(), iter.Dimension(), iter.Column()
+		progress.Pos, progress.Dim = pos, dim
+		progress.Checked++
+
+		if conf.outsideBorder(pos, dim) || conf.unmodified(pos, dim, col) {
+			if err := db.DeleteColumn(pos, dim); err != nil {
+				return progress, fmt.Errorf("prune: %w", err)
+			}
+			progress.Deleted++
+		}
+		if conf.Progress != nil {
+			conf.Progress(progress)
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return progress, fmt.Errorf("prune: %w", err)
+	}
+	return progress, nil
+}
+
+// outsideBorder checks if a chunk position in a dimension is outside the
+// Border of the PruneConfig.
+func (conf PruneConfig) outsideBorder(pos world.ChunkPos, dim world.Dimension) bool {
+	if conf.Border == nil || (conf.Border.Min == world.ChunkPos{} && conf.Border.Max == world.ChunkPos{}) {
+		return false
+	}
+	return !conf.Border.within(pos, dim)
+}
+
+// unmodified checks if the world.Column passed is equal to the one that would
+// be generated by the world.Generator for the dimension at the same position.
+func (conf PruneConfig) unmodified(pos world.ChunkPos, dim world.Dimension, col *world.Column) bool {
+	if conf.Generator == nil || len(col.Entities) > 0 || len(col.BlockEntities) > 0 || len(col.CustomData) > 0 {
+		return false
+	}
+	g := conf.Generator(dim)
+	if g == nil {
+		return false
+	}
+	generated := chunk.New(world.BlockRuntimeID(nil), dim.Range())
+	g.GenerateChunk(pos, generated)
+
+	r := dim.Range()
+	for x := uint8(0); x < 16; x++ {
+		for z := uint8(0); z < 16; z++ {
+			for y := int16(r[0]); y <= int16(r[1]); y++ {
+				if col.Biome(x, y, z) != generated.Biome(x, y, z) {
+					return false
+				}
+				for layer := uint8(0); layer < 2; layer++ {
+					if col.Block(x, y, z, layer) != generated.Block(x, y, z, layer) {
+						return false
+					}
+				}
+			}
+		}
+	}
+	return true
+}
+
+// DeleteColumn deletes all data of the world.Column at a position and
+// dimension from the DB. Deleting a column that does not exist is not an
+// error.
+func (db *DB) DeleteColumn(pos world.ChunkPos, dim world.Dimension) error {
+	prefix := index(pos, dim)
+	batch := new(leveldb.Batch)
+
+	iter := db.ldb.NewIterator(util.BytesPrefix(prefix), nil)
+	for iter.Next() {
+		// Keys of the chunk are the prefix followed by either a single tag, a
+		// tag with a sub chunk index or the custom data tag with a key. Any
+		// other keys sharing the prefix belong to chunks in other dimensions.
+		if n := len(iter.Key()) - len(prefix); n == 1 || n == 2 || (n > 2 && iter.Key()[len(prefix)] == keyCustomData) {
+			batch.Delete(append([]byte(nil), iter.Key()...))
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("delete column %v (%v): %w", pos, dim, err)
+	}
+	if err := db.ldb.Write(batch, nil); err != nil {
+		return fmt.Errorf("delete column %v (%v): %w", pos, dim, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"runtime"
+	"sync"
+)
+
+// PregenConfig holds the parameters used to pre-generate chunks using
+// PregenConfig.Pregenerate. Pre-generating chunks before players join reduces
+// the amount of work a World has to do while players are exploring it.
+type PregenConfig struct {
+	// Provider is the Provider that generated chunks are stored in using
+	// Provider.StoreColumn. Chunks already present in the Provider are left
+	// untouched.
+	Provider Provider
+	// Generator is the Generator used to generate chunks that are not yet
+	// present in the Provider.
+	Generator Generator
+	// Dim is the Dimension to generate chunks for. If nil, Dim defaults to
+	// Overworld.
+	Dim Dimension
+	// Centre is the chunk position around which chunks are generated.
+	Centre ChunkPos
+	// Radius is the radius in chunks around Centre in which chunks are
+	// generated. Chunks are generated in a circle rather than a square.
+	Radius int
+	// Workers is the amount of goroutines that generate chunks concurrently.
+	// If left as 0, Workers defaults to runtime.NumCPU().
+	Workers int
+	// Progress, if non-nil, is called after every chunk processed with the
+	// amount of chunks processed so far and the total amount of chunks within
+	// the radius. Progress may be called from multiple goroutines, but never
+	// concurrently.
+	Progress func(done, total int)
+}
+
+// Pregenerate generates all chunks within the radius of the PregenConfig that
+// are not yet present in its Provider and stores them. An error is returned if
+// loading or storing any of the chunks failed, in which case generation stops
+// early.
+func (conf PregenConfig) Pregenerate() error {
+	if conf.Provider == nil {
+		return fmt.Errorf("pregenerate: no provider set")
+	}
+	if conf.Generator == nil {
+		conf.Generator = NopGenerator{}
+	}
+	if conf.Dim == nil {
+		conf.Dim = Overworld
+	}
+	if conf.Workers <= 0 {
+		conf.Workers = runtime.NumCPU()
+	}
+	if conf.Radius < 0 {
+		conf.Radius = 0
+	}
+
+	positions := conf.positions()
+	queue := make(chan ChunkPos)
+	errs := make(chan error, conf.Workers)
+	done := make(chan struct{})
+
+	var (
+		mu        sync.Mutex
+		processed int
+		wg        sync.WaitGroup
+		closeOnce sync.Once
+	)
+	for i := 0; i < conf.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for pos := range queue {
+				if err := conf.generate(pos); err != nil {
+					errs <- err
+					closeOnce.Do(func() { close(done) })
+					return
+				}
+				mu.Lock()
+				processed++
+				if conf.Progress != nil {
+					conf.Progress(processed, len(positions))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, pos := range positions {
+		select {
+		case queue <- pos:
+		case <-done:
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return fmt.Errorf("pregenerate: %w", err)
+	}
+	return nil
+}
+
+// positions returns all chunk positions within the radius of the PregenConfig,
+// ordered from the centre outwards.
+func (conf PregenConfig) positions() []ChunkPos {
+	r := int32(conf.Radius)
+	positions := make([]ChunkPos, 0, (2*r+1)*(2*r+1))
+	for dist := int32(0); dist <= r; dist++ {
+		for x := -dist; x <= dist; x++ {
+			for z := -dist; z <= dist; z++ {
+				if x != -dist && x != dist && z != -dist && z != dist {
+					// Not on the edge of the current ring, so the position was
+					// already added in an earlier iteration.
+					continue
+				}
+				if x*x+z*z > r*r {
+					continue
+				}
+				positions = append(positions, ChunkPos{conf.Centre[0] + x, conf.Centre[1] + z})
+			}
+		}
+	}
+	return positions
+}
+
+// generate generates the chunk at the position passed and stores it in the
+// Provider of the PregenConfig if it was not yet present.
+func (conf PregenConfig) generate(pos ChunkPos) error {
+	_, err := conf.Provider.LoadColumn(pos, conf.Dim)
+	if err == nil {
+		// The chunk already exists, so it must not be overwritten.
+		return nil
+	} else if !errors.Is(err, leveldb.ErrNotFound) {
+		return fmt.Errorf("load column %v: %w", pos, err)
+	}
+	col := newColumn(chunk.New(airRID, conf.Dim.Range()))
+	conf.Generator.GenerateChunk(pos, col.Chunk)
+	col.Compact()
+
+	if err := conf.Provider.StoreColumn(pos, conf.Dim, col); err != nil {
+		return fmt.Errorf("store column %v: %w", pos, err)
+	}
+	return nil
+}
@@ -41,6 +41,16 @@ type Settings struct {
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// Border is the Border of the World. Entities are unable to move through the Border and players outside of it
+	// take damage. The Border is disabled if its Size is 0. It is stored in Overworld coordinates and scaled by
+	// World.Border for the Nether.
+	Border Border
+	// RequiredSleepingPercentage is the percentage of players in the World that must be sleeping for the night to be
+	// skipped. At least one player must always be sleeping.
+	RequiredSleepingPercentage int
+	// RespawnBlocksExplode specifies if beds explode when used in dimensions in which players cannot sleep, such as
+	// the Nether and the End.
+	RespawnBlocksExplode bool
 }
 
 // defaultSettings returns the default Settings for a new World.
@@ -52,5 +62,8 @@ func defaultSettings() *Settings {
 		TimeCycle:       true,
 		WeatherCycle:    true,
 		TickRange:       6,
+
+		RequiredSleepingPercentage: 100,
+		RespawnBlocksExplode:       true,
 	}
 }
@@ -0,0 +1,82 @@
+package world
+
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// Sleeper represents an entity that is able to sleep in a bed. The night is skipped in a World if enough of the
+// Sleepers in it are sleeping. Sleepers in a GameMode that is not visible, such as spectator mode, are not
+// counted.
+type Sleeper interface {
+	Entity
+	// Sleep makes the Sleeper sleep in the bed at the cube.Pos passed.
+	Sleep(pos cube.Pos)
+	// Sleeping returns the position of the bed that the Sleeper is sleeping in and true, or false if the Sleeper
+	// is not currently sleeping.
+	Sleeping() (cube.Pos, bool)
+	// Wake wakes the Sleeper up if it was sleeping.
+	Wake()
+	// Messaget sends a message to the Sleeper that is translated client-side using the translation key and
+	// parameters passed.
+	Messaget(key string, params ...string)
+}
+
+const (
+	// sleepDuration is the amount of ticks that enough Sleepers must have been sleeping before the night is
+	// skipped.
+	sleepDuration = 100
+	// dayDuration is the amount of ticks that a full day and night cycle takes.
+	dayDuration = 24000
+)
+
+// CanSleep checks if Sleepers are able to sleep in the World at a cube.Pos. Sleeping is possible at night and
+// during thunderstorms.
+func (w *World) CanSleep(pos cube.Pos) bool {
+	if w == nil || !w.Dimension().TimeCycle() {
+		return false
+	}
+	t := w.Time() % dayDuration
+	return (t >= 12542 && t <= 23459) || w.ThunderingAt(pos)
+}
+
+// tickSleeping checks if enough Sleepers in the World are sleeping to skip the night. If this has been the case for
+// long enough, the time is set to the next morning, the weather is cleared and all Sleepers are woken up.
+func (t ticker) tickSleeping() {
+	if !t.w.Dimension().TimeCycle() {
+		return
+	}
+	var sleepers []Sleeper
+	total, sleeping := 0, 0
+	for _, e := range t.w.Entities() {
+		s, ok := e.(Sleeper)
+		if !ok {
+			continue
+		}
+		if g, ok := e.(interface{ GameMode() GameMode }); ok && !g.GameMode().Visible() {
+			continue
+		}
+		total++
+		if _, ok := s.Sleeping(); ok {
+			sleepers, sleeping = append(sleepers, s), sleeping+1
+		}
+	}
+	t.w.set.Lock()
+	required := (total*t.w.set.RequiredSleepingPercentage + 99) / 100
+	t.w.set.Unlock()
+
+	if sleeping == 0 || sleeping < required {
+		t.w.sleepTicks = 0
+		return
+	}
+	if t.w.sleepTicks++; t.w.sleepTicks < sleepDuration {
+		return
+	}
+	t.w.sleepTicks = 0
+
+	tim := t.w.Time()
+	t.w.SetTime(tim - tim%dayDuration + dayDuration)
+	t.w.StopRaining()
+	for _, s := range sleepers {
+		s.Wake()
+	}
+}
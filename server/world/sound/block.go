@@ -161,6 +161,9 @@ type BlastFurnaceCrackle struct{ sound }
 // SmokerCrackle is a sound played every one to five seconds from a smoker.
 type SmokerCrackle struct{ sound }
 
+// PotionBrewed is a sound played when a brewing stand finishes brewing potions.
+type PotionBrewed struct{ sound }
+
 // ComposterEmpty is a sound played when a composter has been emptied.
 type ComposterEmpty struct{ sound }
 
@@ -173,6 +176,51 @@ type ComposterFillLayer struct{ sound }
 // ComposterReady is a sound played when a composter has produced bone meal and is ready to be collected.
 type ComposterReady struct{ sound }
 
+// CauldronFillWater is a sound played when water is added to a cauldron.
+type CauldronFillWater struct{ sound }
+
+// CauldronTakeWater is a sound played when water is taken from a cauldron.
+type CauldronTakeWater struct{ sound }
+
+// CauldronFillLava is a sound played when lava is added to a cauldron.
+type CauldronFillLava struct{ sound }
+
+// CauldronTakeLava is a sound played when lava is taken from a cauldron.
+type CauldronTakeLava struct{ sound }
+
+// CauldronFillPowderSnow is a sound played when powder snow is added to a cauldron.
+type CauldronFillPowderSnow struct{ sound }
+
+// CauldronTakePowderSnow is a sound played when powder snow is taken from a cauldron.
+type CauldronTakePowderSnow struct{ sound }
+
+// CauldronFillPotion is a sound played when a potion is added to a cauldron.
+type CauldronFillPotion struct{ sound }
+
+// CauldronTakePotion is a sound played when a potion is taken from a cauldron.
+type CauldronTakePotion struct{ sound }
+
+// CauldronAddDye is a sound played when the water in a cauldron is dyed.
+type CauldronAddDye struct{ sound }
+
+// CauldronDyeArmour is a sound played when leather armour is dyed using the dyed water in a cauldron.
+type CauldronDyeArmour struct{ sound }
+
+// CauldronCleanArmour is a sound played when the dye is washed off leather armour in a cauldron.
+type CauldronCleanArmour struct{ sound }
+
+// CauldronCleanBanner is a sound played when a pattern is washed off a banner in a cauldron.
+type CauldronCleanBanner struct{ sound }
+
+// CauldronExplode is a sound played when incompatible contents are mixed in a cauldron, emptying it.
+type CauldronExplode struct{ sound }
+
+// CauldronDripWater is a sound played when water drips into a cauldron from dripstone above it.
+type CauldronDripWater struct{ sound }
+
+// CauldronDripLava is a sound played when lava drips into a cauldron from dripstone above it.
+type CauldronDripLava struct{ sound }
+
 // sound implements the world.Sound interface.
 type sound struct{}
 
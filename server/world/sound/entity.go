@@ -23,6 +23,9 @@ type Fall struct {
 	sound
 }
 
+// ShieldBlock is a sound played when an entity blocks damage using a shield.
+type ShieldBlock struct{ sound }
+
 // Burp is a sound played when a player finishes eating an item.
 type Burp struct{ sound }
 
@@ -58,3 +61,6 @@ type FireworkBlast struct{ sound }
 
 // FireworkTwinkle is a sound played when a firework explodes and should twinkle.
 type FireworkTwinkle struct{ sound }
+
+// FishingHookSplash is a sound played when a fish bites the hook of a fishing rod, pulling it under water.
+type FishingHookSplash struct{ sound }
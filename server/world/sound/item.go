@@ -50,6 +50,52 @@ type BowShoot struct{ sound }
 // ArrowHit is a sound played when an arrow hits ground.
 type ArrowHit struct{ sound }
 
+// CrossbowLoad is a sound played while a crossbow is being loaded.
+type CrossbowLoad struct {
+	// Stage is the stage of the loading of the crossbow. It is either CrossbowLoadingStart,
+	// CrossbowLoadingMiddle or CrossbowLoadingEnd.
+	Stage int
+	// QuickCharge specifies if the crossbow is enchanted with quick charge, which changes the sound played.
+	QuickCharge bool
+
+	sound
+}
+
+const (
+	// CrossbowLoadingStart is the stage of a CrossbowLoad sound played when a crossbow starts loading.
+	CrossbowLoadingStart = iota
+	// CrossbowLoadingMiddle is the stage of a CrossbowLoad sound played halfway through loading a crossbow.
+	CrossbowLoadingMiddle
+	// CrossbowLoadingEnd is the stage of a CrossbowLoad sound played when a crossbow is fully loaded.
+	CrossbowLoadingEnd
+)
+
+// CrossbowShoot is a sound played when a crossbow is shot.
+type CrossbowShoot struct{ sound }
+
+// TridentThrow is a sound played when a trident is thrown.
+type TridentThrow struct{ sound }
+
+// TridentHit is a sound played when a thrown trident hits an entity.
+type TridentHit struct{ sound }
+
+// TridentHitGround is a sound played when a thrown trident hits the ground.
+type TridentHitGround struct{ sound }
+
+// TridentReturn is a sound played when a trident enchanted with loyalty returns to its owner.
+type TridentReturn struct{ sound }
+
+// TridentRiptide is a sound played when a player launches itself using a trident enchanted with riptide.
+type TridentRiptide struct {
+	// Level is the level of the riptide enchantment, ranging from 1 to 3.
+	Level int
+
+	sound
+}
+
+// TridentThunder is a sound played when a trident enchanted with channeling summons lightning.
+type TridentThunder struct{ sound }
+
 // Teleport is a sound played upon teleportation of an enderman, or teleportation of a player by an ender pearl or a chorus fruit.
 type Teleport struct{ sound }
 
@@ -0,0 +1,64 @@
+package world
+
+import (
+	"github.com/df-mc/atomic"
+	"time"
+)
+
+// Stats holds statistics of a World, such as the duration of its ticks and the amount of chunks loaded. Stats
+// may be obtained using World.Stats and are typically used to export metrics of a server.
+type Stats struct {
+	// Ticks is the total amount of ticks performed by the World. Ticks are skipped if no viewers are in the
+	// World.
+	Ticks uint64
+	// TickDuration is the total duration of all Ticks performed.
+	TickDuration time.Duration
+	// LastTickDuration is the duration of the most recent tick.
+	LastTickDuration time.Duration
+	// TPS is the average amount of ticks per second over the shortest of the PerformancePeriods.
+	TPS float64
+	// LoadedChunks is the amount of chunks currently loaded in the World.
+	LoadedChunks int
+	// Entities is the amount of entities currently in the World.
+	Entities int
+	// Viewers is the amount of Loaders currently in the World.
+	Viewers int
+	// ChunksSent is the total amount of chunks sent to the viewers of Loaders using Loader.Load.
+	ChunksSent uint64
+}
+
+// stats holds the counters of a World used to produce its Stats. Tick durations are kept in the tickHistory
+// of the World instead.
+type stats struct {
+	chunksSent atomic.Uint64
+}
+
+// Stats returns the current Stats of the World.
+func (w *World) Stats() Stats {
+	if w == nil {
+		return Stats{}
+	}
+	w.chunkMu.Lock()
+	chunks := len(w.chunks)
+	w.chunkMu.Unlock()
+
+	w.entityMu.RLock()
+	entities := len(w.entities)
+	w.entityMu.RUnlock()
+
+	w.viewersMu.Lock()
+	viewers := len(w.viewers)
+	w.viewersMu.Unlock()
+
+	ticks, total, last := w.history.totals()
+	return Stats{
+		Ticks:            ticks,
+		TickDuration:     total,
+		LastTickDuration: last,
+		TPS:              w.history.performance(PerformancePeriods[0]).TPS,
+		LoadedChunks:     chunks,
+		Entities:         entities,
+		Viewers:          viewers,
+		ChunksSent:       w.stats.chunksSent.Load(),
+	}
+}
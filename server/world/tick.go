@@ -34,13 +34,22 @@ func (t ticker) tickLoop() {
 
 // tick performs a tick on the World and updates the time, weather, blocks and entities that require updates.
 func (t ticker) tick() {
+	start := time.Now()
 	viewers, loaders := t.w.allViewers()
 
 	t.w.set.Lock()
 	if len(viewers) == 0 && t.w.set.CurrentTick != 0 {
 		t.w.set.Unlock()
+		// The tick is skipped, but it still counts towards the tick rate of the World.
+		t.w.history.record(start, time.Since(start), false)
 		return
 	}
+	p := t.w.profiler.Load()
+	defer func() {
+		d := time.Since(start)
+		t.w.history.record(start, d, true)
+		p.tick(d)
+	}()
 	if t.w.advance {
 		t.w.set.CurrentTick++
 		if t.w.set.TimeCycle {
@@ -49,6 +58,7 @@ func (t ticker) tick() {
 		if t.w.set.WeatherCycle {
 			t.w.advanceWeather()
 		}
+		t.w.set.Border.tick()
 	}
 
 	rain, thunder, tick, tim := t.w.set.Raining, t.w.set.Thundering && t.w.set.Raining, t.w.set.CurrentTick, int(t.w.set.Time)
@@ -64,18 +74,35 @@ func (t ticker) tick() {
 			}
 		}
 	}
+	p.phase(TickPhaseTime, start)
+
 	if thunder {
+		phaseStart := p.now()
 		t.w.tickLightning()
+		p.phase(TickPhaseLightning, phaseStart)
 	}
 
-	t.tickEntities(tick)
-	t.tickBlocksRandomly(loaders, tick)
-	t.tickScheduledBlocks(tick)
-	t.performNeighbourUpdates()
+	phaseStart := p.now()
+	t.tickEntities(tick, p)
+	p.phase(TickPhaseEntities, phaseStart)
+
+	phaseStart = p.now()
+	t.tickSleeping()
+	p.phase(TickPhaseSleeping, phaseStart)
+
+	t.tickBlocksRandomly(loaders, tick, p)
+
+	phaseStart = p.now()
+	t.tickScheduledBlocks(tick, p)
+	p.phase(TickPhaseScheduledUpdates, phaseStart)
+
+	phaseStart = p.now()
+	t.performNeighbourUpdates(p)
+	p.phase(TickPhaseNeighbourUpdates, phaseStart)
 }
 
 // tickScheduledBlocks executes scheduled block updates in chunks that are currently loaded.
-func (t ticker) tickScheduledBlocks(tick int64) {
+func (t ticker) tickScheduledBlocks(tick int64, p *profiler) {
 	t.w.updateMu.Lock()
 	positions := make([]cube.Pos, 0, len(t.w.scheduledUpdates)/4)
 	for pos, scheduledTick := range t.w.scheduledUpdates {
@@ -87,19 +114,24 @@ func (t ticker) tickScheduledBlocks(tick int64) {
 	t.w.updateMu.Unlock()
 
 	for _, pos := range positions {
-		if ticker, ok := t.w.Block(pos).(ScheduledTicker); ok {
+		b := t.w.Block(pos)
+		if ticker, ok := b.(ScheduledTicker); ok {
+			start := p.now()
 			ticker.ScheduledTick(pos, t.w, t.w.r)
+			p.block(b, TickPhaseScheduledUpdates, start)
 		}
 		if liquid, ok := t.w.additionalLiquid(pos); ok {
 			if ticker, ok := liquid.(ScheduledTicker); ok {
+				start := p.now()
 				ticker.ScheduledTick(pos, t.w, t.w.r)
+				p.block(liquid, TickPhaseScheduledUpdates, start)
 			}
 		}
 	}
 }
 
 // performNeighbourUpdates performs all block updates that came as a result of a neighbouring block being changed.
-func (t ticker) performNeighbourUpdates() {
+func (t ticker) performNeighbourUpdates(p *profiler) {
 	t.w.updateMu.Lock()
 	positions := slices.Clone(t.w.neighbourUpdates)
 	t.w.neighbourUpdates = t.w.neighbourUpdates[:0]
@@ -107,12 +139,17 @@ func (t ticker) performNeighbourUpdates() {
 
 	for _, update := range positions {
 		pos, changedNeighbour := update.pos, update.neighbour
-		if ticker, ok := t.w.Block(pos).(NeighbourUpdateTicker); ok {
+		b := t.w.Block(pos)
+		if ticker, ok := b.(NeighbourUpdateTicker); ok {
+			start := p.now()
 			ticker.NeighbourUpdateTick(pos, changedNeighbour, t.w)
+			p.block(b, TickPhaseNeighbourUpdates, start)
 		}
 		if liquid, ok := t.w.additionalLiquid(pos); ok {
 			if ticker, ok := liquid.(NeighbourUpdateTicker); ok {
+				start := p.now()
 				ticker.NeighbourUpdateTick(pos, changedNeighbour, t.w)
+				p.block(liquid, TickPhaseNeighbourUpdates, start)
 			}
 		}
 	}
@@ -120,8 +157,9 @@ func (t ticker) performNeighbourUpdates() {
 
 // tickBlocksRandomly executes random block ticks in each sub chunk in the world that has at least one viewer
 // registered from the viewers passed.
-func (t ticker) tickBlocksRandomly(loaders []*Loader, tick int64) {
+func (t ticker) tickBlocksRandomly(loaders []*Loader, tick int64, p *profiler) {
 	var (
+		phaseStart    = p.now()
 		r             = int32(t.w.tickRange())
 		g             randUint4
 		blockEntities []cube.Pos
@@ -179,15 +217,25 @@ func (t ticker) tickBlocksRandomly(loaders []*Loader, tick int64) {
 	t.w.chunkMu.Unlock()
 
 	for _, pos := range randomBlocks {
-		if rb, ok := t.w.Block(pos).(RandomTicker); ok {
+		b := t.w.Block(pos)
+		if rb, ok := b.(RandomTicker); ok {
+			start := p.now()
 			rb.RandomTick(pos, t.w, t.w.r)
+			p.block(b, TickPhaseRandomTicks, start)
 		}
 	}
+	p.phase(TickPhaseRandomTicks, phaseStart)
+
+	phaseStart = p.now()
 	for _, pos := range blockEntities {
-		if tb, ok := t.w.Block(pos).(TickerBlock); ok {
+		b := t.w.Block(pos)
+		if tb, ok := b.(TickerBlock); ok {
+			start := p.now()
 			tb.Tick(tick, pos, t.w)
+			p.block(b, TickPhaseBlockEntities, start)
 		}
 	}
+	p.phase(TickPhaseBlockEntities, phaseStart)
 }
 
 // anyWithinDistance checks if any of the ChunkPos loaded are within the distance r of the ChunkPos pos.
@@ -205,7 +253,7 @@ func (t ticker) anyWithinDistance(pos ChunkPos, loaded []ChunkPos, r int32) bool
 
 // tickEntities ticks all entities in the world, making sure they are still located in the correct chunks and
 // updating where necessary.
-func (t ticker) tickEntities(tick int64) {
+func (t ticker) tickEntities(tick int64, p *profiler) {
 	type entityToMove struct {
 		e             Entity
 		after         *Column
@@ -283,7 +331,9 @@ func (t ticker) tickEntities(tick int64) {
 		if ticker.World() == t.w {
 			// We gather entities to ticker and ticker them later, so that the lock on the entity mutex is no longer
 			// active.
+			start := p.now()
 			ticker.Tick(t.w, tick)
+			p.entity(ticker.Type(), start)
 		}
 	}
 }
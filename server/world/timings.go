@@ -0,0 +1,367 @@
+package world
+
+import (
+	"golang.org/x/exp/slices"
+	"sync"
+	"time"
+)
+
+// TickPhase is a phase of a tick of a World. Timings collected using World.StartTimings are broken down by
+// TickPhase.
+type TickPhase int
+
+const (
+	// TickPhaseTime is the phase in which the time, weather and world border are updated and sent to viewers.
+	TickPhaseTime TickPhase = iota
+	// TickPhaseLightning is the phase in which lightning strikes during thunderstorms.
+	TickPhaseLightning
+	// TickPhaseEntities is the phase in which entities are moved between chunks and ticked.
+	TickPhaseEntities
+	// TickPhaseSleeping is the phase in which the night is skipped if enough players are sleeping.
+	TickPhaseSleeping
+	// TickPhaseRandomTicks is the phase in which random positions in chunks near viewers are selected and the
+	// blocks at those positions are ticked randomly.
+	TickPhaseRandomTicks
+	// TickPhaseBlockEntities is the phase in which blocks with block entities near viewers are ticked.
+	TickPhaseBlockEntities
+	// TickPhaseScheduledUpdates is the phase in which scheduled block updates are performed.
+	TickPhaseScheduledUpdates
+	// TickPhaseNeighbourUpdates is the phase in which blocks are updated as a result of a neighbouring block
+	// changing.
+	TickPhaseNeighbourUpdates
+
+	tickPhaseCount = iota
+)
+
+// String ...
+func (p TickPhase) String() string {
+	switch p {
+	case TickPhaseTime:
+		return "time"
+	case TickPhaseLightning:
+		return "lightning"
+	case TickPhaseEntities:
+		return "entities"
+	case TickPhaseSleeping:
+		return "sleeping"
+	case TickPhaseRandomTicks:
+		return "random ticks"
+	case TickPhaseBlockEntities:
+		return "block entities"
+	case TickPhaseScheduledUpdates:
+		return "scheduled updates"
+	case TickPhaseNeighbourUpdates:
+		return "neighbour updates"
+	}
+	panic("should never happen")
+}
+
+// Timing holds the time spent on a specific part of ticks of a World.
+type Timing struct {
+	// Name is the name of the part of the tick timed. For timings of a TickPhase, this is the name of the
+	// phase. For timings of entities and blocks, this is the name of the entity type or block.
+	Name string
+	// Phase is the TickPhase that the time was spent in.
+	Phase TickPhase
+	// Count is the amount of times the part was timed. For entities and blocks, this is the amount of times
+	// an entity or block was ticked.
+	Count int
+	// Total is the total time spent.
+	Total time.Duration
+}
+
+// Timings is a report of the timings of a World collected after a call to World.StartTimings.
+type Timings struct {
+	// Duration is the duration over which the timings were collected.
+	Duration time.Duration
+	// Ticks is the amount of ticks timed.
+	Ticks int
+	// Total is the total time spent ticking.
+	Total time.Duration
+	// Phases holds a Timing for every TickPhase, ordered by TickPhase.
+	Phases []Timing
+	// Entities holds a Timing for every entity type ticked, sorted by the Total time spent, highest first.
+	Entities []Timing
+	// Blocks holds a Timing for every combination of block and TickPhase, sorted by the Total time spent,
+	// highest first. Blocks are timed in the TickPhaseRandomTicks, TickPhaseBlockEntities,
+	// TickPhaseScheduledUpdates and TickPhaseNeighbourUpdates phases.
+	Blocks []Timing
+}
+
+// profiler collects the Timings of a World.
+type profiler struct {
+	mu       sync.Mutex
+	start    time.Time
+	ticks    int
+	total    time.Duration
+	phases   [tickPhaseCount]Timing
+	entities map[string]*Timing
+	blocks   map[timingKey]*Timing
+}
+
+// timingKey is the key of a Timing of a block.
+type timingKey struct {
+	name  string
+	phase TickPhase
+}
+
+// newProfiler returns a new profiler that starts collecting timings immediately.
+func newProfiler() *profiler {
+	return &profiler{start: time.Now(), entities: map[string]*Timing{}, blocks: map[timingKey]*Timing{}}
+}
+
+// now returns the current time if the profiler is non-nil, so that no time is spent obtaining the current
+// time if no timings are collected.
+func (p *profiler) now() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// tick records a tick that took the duration passed. tick may be called on a nil *profiler.
+func (p *profiler) tick(d time.Duration) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	p.ticks++
+	p.total += d
+	p.mu.Unlock()
+}
+
+// phase records the time spent in a TickPhase since the start time passed. phase may be called on a nil
+// *profiler.
+func (p *profiler) phase(phase TickPhase, start time.Time) {
+	if p == nil {
+		return
+	}
+	d := time.Since(start)
+	p.mu.Lock()
+	p.phases[phase].Count++
+	p.phases[phase].Total += d
+	p.mu.Unlock()
+}
+
+// entity records the time spent ticking an entity of the type passed since the start time passed. entity may
+// be called on a nil *profiler.
+func (p *profiler) entity(t EntityType, start time.Time) {
+	if p == nil {
+		return
+	}
+	d := time.Since(start)
+	name := t.EncodeEntity()
+	p.mu.Lock()
+	timing, ok := p.entities[name]
+	if !ok {
+		timing = &Timing{Name: name, Phase: TickPhaseEntities}
+		p.entities[name] = timing
+	}
+	timing.Count++
+	timing.Total += d
+	p.mu.Unlock()
+}
+
+// block records the time spent ticking a block in a TickPhase since the start time passed. block may be
+// called on a nil *profiler.
+func (p *profiler) block(b Block, phase TickPhase, start time.Time) {
+	if p == nil {
+		return
+	}
+	d := time.Since(start)
+	name, _ := b.EncodeBlock()
+	key := timingKey{name: name, phase: phase}
+	p.mu.Lock()
+	timing, ok := p.blocks[key]
+	if !ok {
+		timing = &Timing{Name: name, Phase: phase}
+		p.blocks[key] = timing
+	}
+	timing.Count++
+	timing.Total += d
+	p.mu.Unlock()
+}
+
+// timings returns the Timings collected by the profiler so far.
+func (p *profiler) timings() Timings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	t := Timings{Duration: time.Since(p.start), Ticks: p.ticks, Total: p.total, Phases: make([]Timing, tickPhaseCount)}
+	for i, phase := range p.phases {
+		phase.Name, phase.Phase = TickPhase(i).String(), TickPhase(i)
+		t.Phases[i] = phase
+	}
+	t.Entities, t.Blocks = sortedTimings(p.entities), sortedTimings(p.blocks)
+	return t
+}
+
+// sortedTimings returns the Timings in the map passed, sorted by the total time spent, highest first.
+func sortedTimings[K comparable](m map[K]*Timing) []Timing {
+	timings := make([]Timing, 0, len(m))
+	for _, t := range m {
+		timings = append(timings, *t)
+	}
+	slices.SortFunc(timings, func(a, b Timing) bool {
+		return a.Total > b.Total
+	})
+	return timings
+}
+
+// StartTimings starts collecting Timings of the ticks of the World, breaking down the time spent by
+// TickPhase, entity type and block. Collecting timings adds a small overhead to every tick, so timings should
+// only be collected when needed. Calling StartTimings while timings are already being collected discards
+// the Timings collected so far.
+func (w *World) StartTimings() {
+	if w == nil {
+		return
+	}
+	w.profiler.Store(newProfiler())
+}
+
+// StopTimings stops collecting Timings of the World and returns the Timings collected since the last call to
+// StartTimings. False is returned if no timings were being collected.
+func (w *World) StopTimings() (Timings, bool) {
+	if w == nil {
+		return Timings{}, false
+	}
+	p := w.profiler.Swap(nil)
+	if p == nil {
+		return Timings{}, false
+	}
+	return p.timings(), true
+}
+
+// Timings returns the Timings collected since the last call to StartTimings without stopping the collection.
+// False is returned if no timings are being collected.
+func (w *World) Timings() (Timings, bool) {
+	if w == nil {
+		return Timings{}, false
+	}
+	p := w.profiler.Load()
+	if p == nil {
+		return Timings{}, false
+	}
+	return p.timings(), true
+}
+
+// Performance holds the tick rate of a World measured over a specific period.
+type Performance struct {
+	// Period is the period over which the performance was measured. If the World has been running shorter
+	// than the Period, the performance is measured since the World started ticking.
+	Period time.Duration
+	// TPS is the average amount of ticks per second. A World ticks at most 20 times per second.
+	TPS float64
+	// AverageTick and MaxTick are the average and maximum duration of a tick, also known as MSPT
+	// (milliseconds per tick). If a tick takes longer than 50 milliseconds, the TPS of the World drops
+	// below 20.
+	AverageTick, MaxTick time.Duration
+}
+
+// PerformancePeriods are the periods over which the Performance of a World is measured in World.Performance.
+var PerformancePeriods = []time.Duration{time.Second * 5, time.Minute, time.Minute * 5}
+
+// tickRecord is a record of a single tick in the tick history of a World.
+type tickRecord struct {
+	start time.Time
+	d     time.Duration
+}
+
+// tickHistory holds the tick records of a World for the longest of the PerformancePeriods. It is the only
+// place that tick durations are recorded, and is used for both World.Performance and World.Stats.
+type tickHistory struct {
+	mu      sync.Mutex
+	records []tickRecord
+	next    int
+	first   time.Time
+
+	// ticks is the total amount of ticks performed, excluding ticks skipped because the World had no
+	// viewers. total is their total duration and last the duration of the most recent one.
+	ticks       uint64
+	total, last time.Duration
+}
+
+// tickHistorySize is the amount of tick records kept in a tickHistory: Enough for 5 minutes of ticks.
+const tickHistorySize = 20 * 60 * 5
+
+// record records a tick that started at the time passed and took the duration passed. performed is false if
+// the tick was skipped.
+func (h *tickHistory) record(start time.Time, d time.Duration, performed bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if performed {
+		h.ticks++
+		h.total += d
+		h.last = d
+	}
+	if h.first.IsZero() {
+		h.first = start
+	}
+	if len(h.records) < tickHistorySize {
+		h.records = append(h.records, tickRecord{start: start, d: d})
+		return
+	}
+	h.records[h.next] = tickRecord{start: start, d: d}
+	h.next = (h.next + 1) % tickHistorySize
+}
+
+// totals returns the amount of ticks performed, their total duration and the duration of the most recent
+// one.
+func (h *tickHistory) totals() (ticks uint64, total, last time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.ticks, h.total, h.last
+}
+
+// performance returns the Performance measured over the period passed.
+func (h *tickHistory) performance(period time.Duration) Performance {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	perf := Performance{Period: period}
+	if h.first.IsZero() {
+		return perf
+	}
+	var (
+		since = now.Add(-period)
+		n     int
+		total time.Duration
+	)
+	for _, r := range h.records {
+		if r.start.Before(since) {
+			continue
+		}
+		n++
+		total += r.d
+		if r.d > perf.MaxTick {
+			perf.MaxTick = r.d
+		}
+	}
+	if n == 0 {
+		return perf
+	}
+	elapsed := period
+	if running := now.Sub(h.first); running < period {
+		elapsed = running
+	}
+	perf.AverageTick = total / time.Duration(n)
+	perf.TPS = float64(n) / elapsed.Seconds()
+	if perf.TPS > 20 {
+		perf.TPS = 20
+	}
+	return perf
+}
+
+// Performance returns the Performance of the World measured over each of the PerformancePeriods, in the same
+// order. Performance is always measured, regardless of whether Timings are collected.
+func (w *World) Performance() []Performance {
+	if w == nil {
+		return nil
+	}
+	perf := make([]Performance, len(PerformancePeriods))
+	for i, period := range PerformancePeriods {
+		perf[i] = w.history.performance(period)
+	}
+	return perf
+}
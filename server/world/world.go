@@ -55,6 +55,9 @@ type World struct {
 	entities map[Entity]ChunkPos
 
 	r *rand.Rand
+	// sleepTicks is the amount of ticks that enough Sleepers have been sleeping in the World to skip the night. It
+	// is only used by the ticker.
+	sleepTicks int
 
 	updateMu sync.Mutex
 	// scheduledUpdates is a map of tick time values indexed by the block position at which an update is
@@ -65,6 +68,10 @@ type World struct {
 
 	viewersMu sync.Mutex
 	viewers   map[*Loader]Viewer
+
+	stats    stats
+	history  tickHistory
+	profiler atomic.Value[*profiler]
 }
 
 // New creates a new initialised world. The world may be used right away, but it will not be saved or loaded
@@ -871,6 +878,17 @@ func (w *World) DefaultGameMode() GameMode {
 	return w.set.DefaultGameMode
 }
 
+// RespawnBlocksExplode checks if beds explode when used in the World because players cannot sleep in its
+// Dimension.
+func (w *World) RespawnBlocksExplode() bool {
+	if w == nil {
+		return false
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.RespawnBlocksExplode
+}
+
 // SetTickRange sets the range in chunks around each Viewer that will have the chunks (their blocks and entities)
 // ticked when the World is ticked.
 func (w *World) SetTickRange(v int) {
@@ -1363,6 +1381,9 @@ type Column struct {
 	*chunk.Chunk
 	Entities      []Entity
 	BlockEntities map[cube.Pos]Block
+	// CustomData holds custom data stored in the Column using World.SetChunkData, indexed by keys in the format
+	// 'namespace:key'. CustomData is saved by the Provider, but is never sent to viewers.
+	CustomData map[string][]byte
 
 	viewers []Viewer
 	loaders []*Loader
@@ -1370,5 +1391,5 @@ type Column struct {
 
 // newColumn returns a new Column wrapper around the chunk.Chunk passed.
 func newColumn(c *chunk.Chunk) *Column {
-	return &Column{Chunk: c, BlockEntities: map[cube.Pos]Block{}}
+	return &Column{Chunk: c, BlockEntities: map[cube.Pos]Block{}, CustomData: map[string][]byte{}}
 }